@@ -20,6 +20,7 @@ const trentoAgentCheckId = "trentoAgent"
 type Agent struct {
 	config          *Config
 	collectorClient collector.Client
+	factsRecorder   *factsRecorder
 	discoveries     []discovery.Discovery
 	ctx             context.Context
 	ctxCancel       context.CancelFunc
@@ -29,6 +30,8 @@ type Config struct {
 	InstanceName    string
 	SSHAddress      string
 	DiscoveryPeriod time.Duration
+	// ChecksPeriod is the interval between the evaluations of the checks on the host, 0 disables them
+	ChecksPeriod    time.Duration
 	CollectorConfig *collector.Config
 }
 
@@ -39,24 +42,28 @@ func NewAgent(config *Config) (*Agent, error) {
 		return nil, errors.Wrap(err, "could not create a collector client")
 	}
 
+	// the discoveries publish through the recorder, so their payloads are available as checks facts
+	recorder := newFactsRecorder(collectorClient)
+
 	ctx, ctxCancel := context.WithCancel(context.Background())
 	agent := &Agent{
 		config:          config,
 		collectorClient: collectorClient,
+		factsRecorder:   recorder,
 		ctx:             ctx,
 		ctxCancel:       ctxCancel,
 		discoveries: []discovery.Discovery{
-			discovery.NewClusterDiscovery(collectorClient),
-			discovery.NewSAPSystemsDiscovery(collectorClient),
-			discovery.NewCloudDiscovery(collectorClient),
-			discovery.NewSubscriptionDiscovery(collectorClient),
-			discovery.NewHostDiscovery(config.SSHAddress, collectorClient),
+			discovery.NewClusterDiscovery(recorder),
+			discovery.NewSAPSystemsDiscovery(recorder),
+			discovery.NewCloudDiscovery(recorder),
+			discovery.NewSubscriptionDiscovery(recorder),
+			discovery.NewHostDiscovery(config.SSHAddress, recorder),
 		},
 	}
 	return agent, nil
 }
 
-// Start the Agent. This will start the discovery ticker and the heartbeat ticker
+// Start the Agent. This will start the discovery ticker, the heartbeat ticker and, when enabled, the checks ticker
 func (a *Agent) Start() error {
 	var wg sync.WaitGroup
 
@@ -76,6 +83,16 @@ func (a *Agent) Start() error {
 		log.Info("heartbeat loop stopped.")
 	}(&wg)
 
+	if a.config.ChecksPeriod > 0 {
+		wg.Add(1)
+		go func(wg *sync.WaitGroup) {
+			log.Info("Starting checks loop...")
+			defer wg.Done()
+			a.startChecksTicker()
+			log.Info("checks loop stopped.")
+		}(&wg)
+	}
+
 	wg.Wait()
 
 	return nil
@@ -0,0 +1,124 @@
+package agent
+
+import (
+	"encoding/json"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/trento-project/trento/agent/discovery"
+	"github.com/trento-project/trento/agent/discovery/collector"
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/web/models"
+)
+
+// factsSources maps the discoveries of the agent to the facts sources used by the checks
+var factsSources = map[string]string{
+	discovery.ClusterDiscoveryId:      checks.ClusterFacts,
+	discovery.HostDiscoveryId:         checks.HostFacts,
+	discovery.SAPDiscoveryId:          checks.SAPSystemsFacts,
+	discovery.SubscriptionDiscoveryId: checks.SubscriptionsFacts,
+	discovery.CloudDiscoveryId:        checks.CloudFacts,
+}
+
+// factsRecorder keeps a copy of the last payload published by each discovery, so the checks
+// are evaluated locally against the very same facts the server receives
+type factsRecorder struct {
+	collector.Client
+	facts checks.Facts
+	lock  sync.Mutex
+}
+
+func newFactsRecorder(client collector.Client) *factsRecorder {
+	return &factsRecorder{
+		Client: client,
+		facts:  make(checks.Facts),
+	}
+}
+
+func (r *factsRecorder) Publish(discoveryType string, payload interface{}) error {
+	if source, ok := factsSources[discoveryType]; ok {
+		r.record(source, payload)
+	}
+
+	return r.Client.Publish(discoveryType, payload)
+}
+
+func (r *factsRecorder) record(source string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Errorf("Error recording the %s facts: %s", source, err)
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.facts.Add(source, data); err != nil {
+		log.Errorf("Error recording the %s facts: %s", source, err)
+	}
+}
+
+// Facts returns a snapshot of the recorded facts
+func (r *factsRecorder) Facts() checks.Facts {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	facts := make(checks.Facts, len(r.facts))
+	for source, value := range r.facts {
+		facts[source] = value
+	}
+
+	return facts
+}
+
+// runChecks evaluates the checks selected for the cluster of the host and publishes the results
+func (a *Agent) runChecks() {
+	definitions, err := a.collectorClient.GetChecks()
+	if err != nil {
+		log.Errorf("Error getting the checks from the server: %s", err)
+		return
+	}
+
+	if len(definitions) == 0 {
+		log.Debugf("No checks to run on this host")
+		return
+	}
+
+	facts := a.factsRecorder.Facts()
+	if len(facts) == 0 {
+		log.Infof("No facts discovered yet, the checks will run in the next iteration")
+		return
+	}
+
+	engine, err := checks.NewEngine(definitions)
+	if err != nil {
+		log.Errorf("Error loading the checks received from the server: %s", err)
+		return
+	}
+
+	selected := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		selected = append(selected, definition.ID)
+	}
+
+	host := a.config.InstanceName
+	result := engine.Run(selected, map[string]checks.Facts{host: facts})
+
+	results := make(map[string]*models.Check, len(result.Checks))
+	for checkID, checkResult := range result.Checks {
+		results[checkID] = checkResult.Hosts[host]
+	}
+
+	if err := a.collectorClient.PublishChecksResults(results); err != nil {
+		log.Errorf("Error publishing the checks results: %s", err)
+		return
+	}
+
+	log.Infof("Published the results of %d checks", len(results))
+}
+
+func (a *Agent) startChecksTicker() {
+	internal.Repeat("agent.checks", a.runChecks, a.config.ChecksPeriod, a.ctx)
+}
@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/agent/discovery"
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/web/models"
+)
+
+type fakeCollectorClient struct {
+	published   map[string]interface{}
+	definitions []*checks.Check
+	results     map[string]*models.Check
+}
+
+func (c *fakeCollectorClient) Publish(discoveryType string, payload interface{}) error {
+	c.published[discoveryType] = payload
+	return nil
+}
+
+func (c *fakeCollectorClient) Heartbeat() error {
+	return nil
+}
+
+func (c *fakeCollectorClient) GetChecks() ([]*checks.Check, error) {
+	return c.definitions, nil
+}
+
+func (c *fakeCollectorClient) PublishChecksResults(results map[string]*models.Check) error {
+	c.results = results
+	return nil
+}
+
+func TestFactsRecorder(t *testing.T) {
+	client := &fakeCollectorClient{published: make(map[string]interface{})}
+	recorder := newFactsRecorder(client)
+
+	payload := map[string]interface{}{"Name": "hana_cluster"}
+	err := recorder.Publish(discovery.ClusterDiscoveryId, payload)
+	assert.NoError(t, err)
+	err = recorder.Publish("unknown_discovery", payload)
+	assert.NoError(t, err)
+
+	assert.Equal(t, payload, client.published[discovery.ClusterDiscoveryId])
+	assert.Equal(t, payload, client.published["unknown_discovery"])
+
+	facts := recorder.Facts()
+	assert.Len(t, facts, 1)
+
+	values, err := facts.Lookup("cluster.Name")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"hana_cluster"}, values)
+}
+
+func TestAgentRunChecks(t *testing.T) {
+	client := &fakeCollectorClient{
+		published: make(map[string]interface{}),
+		definitions: []*checks.Check{
+			{
+				ID:     "156F64",
+				Name:   "1.1.1",
+				Group:  "Corosync",
+				Expect: []*checks.Expectation{{Fact: "cluster.Corosync.totem.token", Operator: checks.OpEqual, Value: "30000"}},
+			},
+			{
+				ID:     "A1244C",
+				Name:   "1.1.2",
+				Group:  "Corosync",
+				Expect: []*checks.Expectation{{Fact: "cluster.Corosync.totem.consensus", Operator: checks.OpEqual, Value: "36000"}},
+			},
+		},
+	}
+	recorder := newFactsRecorder(client)
+
+	agent := &Agent{
+		config:          &Config{InstanceName: "node1"},
+		collectorClient: recorder,
+		factsRecorder:   recorder,
+	}
+
+	agent.runChecks()
+	assert.Nil(t, client.results)
+
+	err := recorder.Publish(discovery.ClusterDiscoveryId, map[string]interface{}{
+		"Corosync": map[string]interface{}{
+			"totem": map[string]interface{}{"token": "30000"},
+		},
+	})
+	assert.NoError(t, err)
+
+	agent.runChecks()
+
+	assert.Len(t, client.results, 2)
+	assert.Equal(t, models.CheckPassing, client.results["156F64"].Result)
+	assert.Equal(t, models.CheckCritical, client.results["A1244C"].Result)
+	assert.NotEmpty(t, client.results["A1244C"].Msg)
+}
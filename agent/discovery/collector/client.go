@@ -13,6 +13,9 @@ import (
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/internal/secrets"
+	"github.com/trento-project/trento/web/models"
 
 	"github.com/spf13/afero"
 )
@@ -20,6 +23,8 @@ import (
 type Client interface {
 	Publish(discoveryType string, payload interface{}) error
 	Heartbeat() error
+	GetChecks() ([]*checks.Check, error)
+	PublishChecksResults(results map[string]*models.Check) error
 }
 
 type client struct {
@@ -116,6 +121,48 @@ func (c *client) Heartbeat() error {
 	return nil
 }
 
+// GetChecks returns the definitions of the checks the agent has to evaluate on its host
+func (c *client) GetChecks() ([]*checks.Check, error) {
+	url := fmt.Sprintf("%s/api/hosts/%s/checks", c.getBaseURL(), c.agentID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server responded with status code %d while getting the checks", resp.StatusCode)
+	}
+
+	var definitions []*checks.Check
+	if err := json.NewDecoder(resp.Body).Decode(&definitions); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+// PublishChecksResults sends the results of the checks evaluated on the host, keyed by check id
+func (c *client) PublishChecksResults(results map[string]*models.Check) error {
+	requestBody, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/hosts/%s/checks/results", c.getBaseURL(), c.agentID)
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("server responded with status code %d while publishing the checks results", resp.StatusCode)
+	}
+
+	return nil
+}
+
 func (c *client) getBaseURL() string {
 	protocol := "http"
 	if c.config.EnablemTLS {
@@ -132,7 +179,7 @@ func getTLSConfig(cert, key, ca string) (*tls.Config, error) {
 	caCertPool := x509.NewCertPool()
 	caCertPool.AppendCertsFromPEM(caCert)
 
-	certificate, err := tls.LoadX509KeyPair(cert, key)
+	certificate, err := secrets.LoadX509KeyPair(cert, key)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -10,8 +11,10 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/internal/checks"
 	_ "github.com/trento-project/trento/test"
 	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/models"
 )
 
 const (
@@ -152,3 +155,94 @@ func (suite *CollectorClientTestSuite) TestCollectorClient_Heartbeat() {
 
 	suite.NoError(err)
 }
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_GetChecks() {
+	collectorClient, err := NewCollectorClient(&Config{
+		EnablemTLS:    false,
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	definitions := []*checks.Check{
+		{
+			ID:       "156F64",
+			Name:     "1.1.1",
+			Group:    "Corosync",
+			Severity: models.CheckCritical,
+			Expect: []*checks.Expectation{
+				{Fact: "cluster.Corosync.totem.token", Operator: checks.OpEqual, Value: "30000"},
+			},
+		},
+	}
+
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal(req.URL.String(), fmt.Sprintf("http://localhost:8081/api/hosts/%s/checks", DummyAgentID))
+		suite.Equal("GET", req.Method)
+
+		body, _ := json.Marshal(definitions)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBuffer(body)),
+		}
+	})
+
+	received, err := collectorClient.GetChecks()
+
+	suite.NoError(err)
+	suite.Equal(definitions, received)
+}
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_GetChecksFailure() {
+	collectorClient, err := NewCollectorClient(&Config{
+		EnablemTLS:    false,
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 404,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+		}
+	})
+
+	_, err = collectorClient.GetChecks()
+
+	suite.Error(err)
+}
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_PublishChecksResults() {
+	collectorClient, err := NewCollectorClient(&Config{
+		EnablemTLS:    false,
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	results := map[string]*models.Check{
+		"156F64": {Result: models.CheckPassing},
+		"A1244C": {Result: models.CheckCritical, Msg: "cluster.Corosync.totem.consensus not found"},
+	}
+
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal(req.URL.String(), fmt.Sprintf("http://localhost:8081/api/hosts/%s/checks/results", DummyAgentID))
+
+		expectedBody, _ := json.Marshal(results)
+		bodyBytes, _ := ioutil.ReadAll(req.Body)
+		suite.JSONEq(string(expectedBody), string(bodyBytes))
+
+		return &http.Response{
+			StatusCode: 202,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+		}
+	})
+
+	err = collectorClient.PublishChecksResults(results)
+
+	suite.NoError(err)
+}
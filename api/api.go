@@ -1,13 +1,24 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"time"
 
+	retryGo "github.com/avast/retry-go/v4"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/trento-project/trento/internal/secrets"
 	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
 )
 
 //go:generate mockery --all
@@ -15,38 +26,285 @@ import (
 type TrentoApiService interface {
 	IsWebServerUp() bool
 	GetClustersSettings() (webApi.ClustersSettingsResponse, error)
+	GetChecksTargetsSettings() (webApi.ClustersSettingsResponse, error)
+	StartChecksExecution(clusterID string, trigger string) (*webApi.JSONChecksExecution, error)
+	ClaimChecksExecution(runnerID string) (*webApi.JSONChecksExecution, error)
+	UpdateChecksExecution(id int64, result *webApi.JSONChecksExecutionResult) error
+	ClaimRemediation(runnerID string) (*models.Remediation, error)
+	UpdateRemediation(id int64, result *webApi.JSONRemediationResult) error
+	GetCustomChecks() ([]*models.CustomCheck, error)
+	RunnerHeartbeat(runnerID string, heartbeat *webApi.JSONRunnerHeartbeat) (*webApi.JSONRunnerLeases, error)
+	ReleaseRunnerLeases(runnerID string) error
+
+	ListHosts(ctx context.Context, filter *HostsFilter, page *Page) (models.HostList, error)
+	GetHost(ctx context.Context, id string) (*models.Host, error)
+	ListClusters(ctx context.Context, filter *ClustersFilter, page *Page) (models.ClusterList, error)
+	GetCluster(ctx context.Context, id string) (*models.Cluster, error)
+	ListSAPSystems(ctx context.Context, filter *SAPSystemsFilter, page *Page) (models.SAPSystemList, error)
+	GetSAPSystem(ctx context.Context, id string) (*models.SAPSystem, error)
+	ListDatabases(ctx context.Context, filter *SAPSystemsFilter, page *Page) (models.SAPSystemList, error)
+	GetDatabase(ctx context.Context, id string) (*models.SAPSystem, error)
+	ListTags(ctx context.Context, resourceTypes ...string) ([]string, error)
+	AddTag(ctx context.Context, resourceType string, resourceID string, tag string) error
+	DeleteTag(ctx context.Context, resourceType string, resourceID string, tag string) error
+	GetChecksCatalog(ctx context.Context) (webApi.JSONChecksGroupedCatalog, error)
+	GetChecksSettings(ctx context.Context, targetID string) (*webApi.JSONChecksSettings, error)
+	UpdateChecksSettings(ctx context.Context, targetID string, settings *webApi.JSONChecksSettings) error
+	GetChecksResults(ctx context.Context, targetType string, targetID string) (*models.ChecksResultAsList, error)
+}
+
+// defaultRetryDelay is the delay before the first retry of a request, doubled on each attempt
+const defaultRetryDelay = 1 * time.Second
+
+// errTemporaryStatus marks the responses worth retrying, as the server might be restarting or overloaded
+var errTemporaryStatus = errors.New("temporary server error")
+
+// StatusError is returned when the API answers with an unexpected status code
+type StatusError struct {
+	StatusCode int
+	// Message is the error reported by the server, if any
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("error during the request with status code %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("error during the request with status code %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound tells whether the requested resource does not exist
+func IsNotFound(err error) bool {
+	var statusError *StatusError
+	return errors.As(err, &statusError) && statusError.StatusCode == http.StatusNotFound
+}
+
+func newStatusError(statusCode int, body []byte) *StatusError {
+	var payload struct {
+		Error string `json:"error"`
+	}
+	_ = json.Unmarshal(body, &payload)
+
+	return &StatusError{StatusCode: statusCode, Message: payload.Error}
 }
 
 type trentoApiService struct {
 	apiHost    string
 	apiPort    int
+	scheme     string
+	token      string
+	retries    uint
+	retryDelay time.Duration
 	httpClient *http.Client
 }
 
+// Config of the connection to the web server API. The runner authenticates with the token,
+// or with the client certificate when the connection is over TLS
+type Config struct {
+	Host      string
+	Port      int
+	EnableTLS bool
+	// CA verifies the web server certificate, the system ones are used when empty
+	CA    string
+	Cert  string
+	Key   string
+	Token string
+	// Retries is how many times the idempotent requests are retried after a connection error
+	// or a temporary server error
+	Retries uint
+}
+
 func NewTrentoApiService(apiHost string, apiPort int) *trentoApiService {
 	client := &http.Client{}
-	return &trentoApiService{apiHost: apiHost, apiPort: apiPort, httpClient: client}
+	return &trentoApiService{apiHost: apiHost, apiPort: apiPort, scheme: "http", retryDelay: defaultRetryDelay, httpClient: client}
+}
+
+// NewTrentoApiServiceWithConfig returns an authenticated client of the web server API
+func NewTrentoApiServiceWithConfig(config *Config) (*trentoApiService, error) {
+	service := NewTrentoApiService(config.Host, config.Port)
+	service.token = config.Token
+	service.retries = config.Retries
+
+	if !config.EnableTLS {
+		return service, nil
+	}
+
+	tlsConfig, err := getTLSConfig(config.CA, config.Cert, config.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	service.scheme = "https"
+	service.httpClient.Transport = &http.Transport{
+		TLSClientConfig: tlsConfig,
+	}
+
+	return service, nil
+}
+
+func getTLSConfig(ca, cert, key string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if ca != "" {
+		caCert, err := ioutil.ReadFile(ca)
+		if err != nil {
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM(caCert)
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if cert != "" {
+		certificate, err := secrets.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
 }
 
 func (t *trentoApiService) composeQuery(resource string) string {
-	return fmt.Sprintf("http://%s:%d/api/%s", t.apiHost, t.apiPort, resource)
+	return fmt.Sprintf("%s://%s:%d/api/%s", t.scheme, t.apiHost, t.apiPort, resource)
+}
+
+// newRequest creates a request to the API, authenticated by the token when there is one
+func (t *trentoApiService) newRequest(ctx context.Context, method string, query string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.composeQuery(query), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	return req, nil
 }
 
 func (t *trentoApiService) getJson(query string) ([]byte, int, error) {
-	var err error
+	return t.getJsonContext(context.Background(), query)
+}
+
+func (t *trentoApiService) getJsonContext(ctx context.Context, query string) ([]byte, int, error) {
+	return t.do(ctx, http.MethodGet, query, nil)
+}
+
+func (t *trentoApiService) sendJson(method string, query string, payload interface{}) ([]byte, int, error) {
+	return t.sendJsonContext(context.Background(), method, query, payload)
+}
+
+func (t *trentoApiService) sendJsonContext(ctx context.Context, method string, query string, payload interface{}) ([]byte, int, error) {
+	var data []byte
+	if payload != nil {
+		var err error
+		data, err = json.Marshal(payload)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return t.do(ctx, method, query, data)
+}
+
+// do sends the request, retrying the idempotent ones on connection errors and on temporary server errors.
+// The response of the last attempt is returned when the server keeps failing
+func (t *trentoApiService) do(ctx context.Context, method string, query string, data []byte) ([]byte, int, error) {
+	var body []byte
+	var statusCode int
+
+	attempts := uint(1)
+	if isIdempotent(method) {
+		attempts += t.retries
+	}
+
+	err := retryGo.Do(
+		func() error {
+			var err error
+			body, statusCode, err = t.doOnce(ctx, method, query, data)
+			if err != nil {
+				return err
+			}
+
+			switch statusCode {
+			case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+				return fmt.Errorf("%w: status code %d", errTemporaryStatus, statusCode)
+			default:
+				return nil
+			}
+		},
+		retryGo.Attempts(attempts),
+		retryGo.Delay(t.retryDelay),
+		retryGo.DelayType(retryGo.BackOffDelay),
+		retryGo.RetryIf(func(error) bool {
+			return ctx.Err() == nil
+		}),
+		retryGo.OnRetry(func(n uint, err error) {
+			log.Debugf("Retrying the request %s %s after: %s", method, query, err)
+		}),
+		retryGo.LastErrorOnly(true),
+		retryGo.Context(ctx),
+	)
+	if err != nil && !errors.Is(err, errTemporaryStatus) {
+		return nil, statusCode, err
+	}
 
-	resp, err := t.httpClient.Get(t.composeQuery(query))
+	return body, statusCode, nil
+}
+
+func (t *trentoApiService) doOnce(ctx context.Context, method string, query string, data []byte) ([]byte, int, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := t.newRequest(ctx, method, query, body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := t.httpClient.Do(req)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, resp.StatusCode, err
 	}
 
-	return body, resp.StatusCode, nil
+	return respBody, resp.StatusCode, nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// getResource decodes the resource found at the query, a StatusError is returned when it is not there
+func (t *trentoApiService) getResource(ctx context.Context, query string, resource interface{}) error {
+	body, statusCode, err := t.getJsonContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK {
+		return newStatusError(statusCode, body)
+	}
+
+	return json.Unmarshal(body, resource)
 }
 
 func (t *trentoApiService) IsWebServerUp() bool {
@@ -1,8 +1,14 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -36,3 +42,104 @@ func TestIsWebServerUp(t *testing.T) {
 
 	assert.Equal(t, false, result)
 }
+
+func TestNewTrentoApiServiceWithConfig(t *testing.T) {
+	trentoApi, err := NewTrentoApiServiceWithConfig(&Config{
+		Host:      "192.168.1.10",
+		Port:      8000,
+		EnableTLS: true,
+		Token:     "some-token",
+	})
+	assert.NoError(t, err)
+
+	trentoApi.httpClient = &http.Client{Transport: helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, "https://192.168.1.10:8000/api/runners/runner1/leases", req.URL.String())
+		assert.Equal(t, "Bearer some-token", req.Header.Get("Authorization"))
+
+		return &http.Response{
+			StatusCode: 204,
+			Body:       http.NoBody,
+		}
+	})}
+
+	assert.NoError(t, trentoApi.ReleaseRunnerLeases("runner1"))
+
+	_, err = NewTrentoApiServiceWithConfig(&Config{
+		Host:      "192.168.1.10",
+		Port:      8000,
+		EnableTLS: true,
+		CA:        "/non/existing/ca.pem",
+	})
+	assert.Error(t, err)
+}
+
+// newTestTrentoApiService returns a client of a test server answering with the handler
+func newTestTrentoApiService(t *testing.T, handler http.HandlerFunc) *trentoApiService {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	trentoApi := NewTrentoApiService(serverURL.Hostname(), port)
+	trentoApi.retryDelay = time.Millisecond
+
+	return trentoApi
+}
+
+func TestRetries(t *testing.T) {
+	var requests int
+
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `["prod"]`)
+	})
+	trentoApi.retries = 2
+
+	tags, err := trentoApi.ListTags(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prod"}, tags)
+	assert.Equal(t, 3, requests)
+
+	// the non idempotent requests are not retried
+	requests = 0
+	_, err = trentoApi.ClaimRemediation("runner1")
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+
+	// the last response is returned when the server keeps failing
+	requests = -10
+	_, err = trentoApi.ListTags(context.Background())
+	assert.Equal(t, &StatusError{StatusCode: http.StatusServiceUnavailable}, err)
+	assert.Equal(t, -7, requests)
+}
+
+func TestContextCancellation(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	trentoApi.retries = 5
+	trentoApi.retryDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := trentoApi.ListHosts(ctx, nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestStatusError(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"could not find host"}`)
+	})
+
+	_, err := trentoApi.GetHost(context.Background(), "unknown")
+	assert.True(t, IsNotFound(err))
+	assert.EqualError(t, err, "error during the request with status code 404: could not find host")
+	assert.False(t, IsNotFound(fmt.Errorf("some error")))
+}
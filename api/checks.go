@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
+)
+
+// checkTargetResources are the API resources of each checks target type
+var checkTargetResources = map[string]string{
+	models.CheckTargetCluster:   "clusters",
+	models.CheckTargetHost:      "hosts",
+	models.CheckTargetSAPSystem: "sapsystems",
+}
+
+// GetChecksCatalog returns the checks catalog, grouped by the checks groups
+func (t *trentoApiService) GetChecksCatalog(ctx context.Context) (webApi.JSONChecksGroupedCatalog, error) {
+	var catalog webApi.JSONChecksGroupedCatalog
+	if err := t.getResource(ctx, "checks/catalog", &catalog); err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
+
+// GetChecksSettings returns the selected checks and the connection settings of a checks target
+func (t *trentoApiService) GetChecksSettings(ctx context.Context, targetID string) (*webApi.JSONChecksSettings, error) {
+	var settings webApi.JSONChecksSettings
+	if err := t.getResource(ctx, fmt.Sprintf("checks/%s/settings", url.PathEscape(targetID)), &settings); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// UpdateChecksSettings stores the selected checks and the connection settings of a checks target
+func (t *trentoApiService) UpdateChecksSettings(ctx context.Context, targetID string, settings *webApi.JSONChecksSettings) error {
+	query := fmt.Sprintf("checks/%s/settings", url.PathEscape(targetID))
+
+	body, statusCode, err := t.sendJsonContext(ctx, http.MethodPost, query, settings)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusCreated {
+		return newStatusError(statusCode, body)
+	}
+
+	return nil
+}
+
+// GetChecksResults returns the last checks results of a target, the target type being one of models.CheckTargetTypes
+func (t *trentoApiService) GetChecksResults(ctx context.Context, targetType string, targetID string) (*models.ChecksResultAsList, error) {
+	resource, ok := checkTargetResources[targetType]
+	if !ok {
+		return nil, fmt.Errorf("unknown checks target type %s", targetType)
+	}
+
+	var results models.ChecksResultAsList
+	if err := t.getResource(ctx, fmt.Sprintf("%s/%s/results", resource, url.PathEscape(targetID)), &results); err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	webApi "github.com/trento-project/trento/web"
+)
+
+// StartChecksExecution records a checks execution the runner is about to run, e.g. a scheduled one
+func (t *trentoApiService) StartChecksExecution(clusterID string, trigger string) (*webApi.JSONChecksExecution, error) {
+	payload := &webApi.JSONChecksExecutionStart{ClusterID: clusterID, Trigger: trigger}
+
+	body, statusCode, err := t.sendJson(http.MethodPost, "checks/executions", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusCreated {
+		return nil, fmt.Errorf("error during the request with status code %d", statusCode)
+	}
+
+	var execution webApi.JSONChecksExecution
+
+	err = json.Unmarshal(body, &execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution, nil
+}
+
+// ClaimChecksExecution takes the oldest queued on-demand checks execution, nil is returned when there is none.
+// Given a runner id, only the executions of the clusters leased to the runner are claimed
+func (t *trentoApiService) ClaimChecksExecution(runnerID string) (*webApi.JSONChecksExecution, error) {
+	query := "checks/executions/claim"
+	if runnerID != "" {
+		query += "?runner_id=" + url.QueryEscape(runnerID)
+	}
+
+	body, statusCode, err := t.sendJson(http.MethodPost, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("error during the request with status code %d", statusCode)
+	}
+
+	var execution webApi.JSONChecksExecution
+
+	err = json.Unmarshal(body, &execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution, nil
+}
+
+// UpdateChecksExecution reports the outcome of a running checks execution
+func (t *trentoApiService) UpdateChecksExecution(id int64, result *webApi.JSONChecksExecutionResult) error {
+	_, statusCode, err := t.sendJson(http.MethodPut, fmt.Sprintf("checks/executions/%d", id), result)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("error during the request with status code %d", statusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	webApi "github.com/trento-project/trento/web"
+)
+
+type ChecksExecutionsApiTestCase struct {
+	suite.Suite
+	trentoApi *trentoApiService
+}
+
+func TestChecksExecutionsApiTestCase(t *testing.T) {
+	suite.Run(t, new(ChecksExecutionsApiTestCase))
+}
+
+func (suite *ChecksExecutionsApiTestCase) SetupTest() {
+	suite.trentoApi = NewTrentoApiService("192.168.1.10", 8000)
+}
+
+func (suite *ChecksExecutionsApiTestCase) Test_StartChecksExecution() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal("POST", req.Method)
+		suite.Equal("http://192.168.1.10:8000/api/checks/executions", req.URL.String())
+		body, _ := io.ReadAll(req.Body)
+		suite.JSONEq(`{"cluster_id":"cluster1","trigger":"scheduled"}`, string(body))
+		return &http.Response{
+			StatusCode: 201,
+			Body:       io.NopCloser(strings.NewReader(`{"id":4,"cluster_id":"cluster1","status":"running","trigger":"scheduled"}`)),
+		}
+	})
+
+	execution, err := suite.trentoApi.StartChecksExecution("cluster1", "scheduled")
+
+	suite.NoError(err)
+	suite.EqualValues(4, execution.ID)
+	suite.Equal("scheduled", execution.Trigger)
+}
+
+func (suite *ChecksExecutionsApiTestCase) Test_ClaimChecksExecution() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal("POST", req.Method)
+		suite.Equal("http://192.168.1.10:8000/api/checks/executions/claim", req.URL.String())
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"id":3,"cluster_id":"cluster1","status":"running"}`)),
+		}
+	})
+
+	execution, err := suite.trentoApi.ClaimChecksExecution("")
+
+	suite.NoError(err)
+	suite.EqualValues(3, execution.ID)
+	suite.Equal("cluster1", execution.ClusterID)
+	suite.Equal("running", execution.Status)
+}
+
+func (suite *ChecksExecutionsApiTestCase) Test_ClaimChecksExecutionLeased() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal("http://192.168.1.10:8000/api/checks/executions/claim?runner_id=dc1+runner", req.URL.String())
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	execution, err := suite.trentoApi.ClaimChecksExecution("dc1 runner")
+
+	suite.NoError(err)
+	suite.Nil(execution)
+}
+
+func (suite *ChecksExecutionsApiTestCase) Test_ClaimChecksExecutionEmptyQueue() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	execution, err := suite.trentoApi.ClaimChecksExecution("")
+
+	suite.NoError(err)
+	suite.Nil(execution)
+}
+
+func (suite *ChecksExecutionsApiTestCase) Test_ClaimChecksExecutionError() {
+	suite.trentoApi.httpClient.Transport = helpers.ErroringRoundTripFunc(func() error {
+		return fmt.Errorf("some error")
+	})
+
+	_, err := suite.trentoApi.ClaimChecksExecution("")
+
+	suite.Error(err)
+}
+
+func (suite *ChecksExecutionsApiTestCase) Test_UpdateChecksExecution() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal("PUT", req.Method)
+		suite.Equal("http://192.168.1.10:8000/api/checks/executions/3", req.URL.String())
+		body, _ := io.ReadAll(req.Body)
+		suite.JSONEq(`{"status":"completed"}`, string(body))
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"status":"completed"}`)),
+		}
+	})
+
+	suite.NoError(suite.trentoApi.UpdateChecksExecution(3, &webApi.JSONChecksExecutionResult{Status: "completed"}))
+}
+
+func (suite *ChecksExecutionsApiTestCase) Test_UpdateChecksExecutionNotFound() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+		}
+	})
+
+	err := suite.trentoApi.UpdateChecksExecution(3, &webApi.JSONChecksExecutionResult{Status: "completed"})
+
+	suite.EqualError(err, "error during the request with status code 404")
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestGetChecksCatalog(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/checks/catalog", r.URL.Path)
+
+		fmt.Fprint(w, `[{"group":"Corosync","checks":[{"id":"1.1.1","name":"token","group":"Corosync"}]}]`)
+	})
+
+	catalog, err := trentoApi.GetChecksCatalog(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, webApi.JSONChecksGroupedCatalog{
+		{Group: "Corosync", Checks: []*models.Check{{ID: "1.1.1", Name: "token", Group: "Corosync"}}},
+	}, catalog)
+}
+
+func TestGetAndUpdateChecksSettings(t *testing.T) {
+	settings := &webApi.JSONChecksSettings{
+		SelectedChecks:     []string{"1.1.1", "1.1.2"},
+		ConnectionSettings: map[string]string{"node1": "root"},
+	}
+
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/checks/cluster1/settings", r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(settings)
+		case http.MethodPost:
+			var received webApi.JSONChecksSettings
+			json.NewDecoder(r.Body).Decode(&received)
+			assert.Equal(t, settings, &received)
+			w.WriteHeader(http.StatusCreated)
+		}
+	})
+
+	found, err := trentoApi.GetChecksSettings(context.Background(), "cluster1")
+	assert.NoError(t, err)
+	assert.Equal(t, settings, found)
+
+	assert.NoError(t, trentoApi.UpdateChecksSettings(context.Background(), "cluster1", settings))
+}
+
+func TestGetChecksResults(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/hosts/host1/results", r.URL.Path)
+
+		fmt.Fprint(w, `{"hosts":{"vmhana01":{"reachable":true,"msg":""}},"checks":[{"id":"1.1.1","hosts":{"vmhana01":{"result":"passing"}}}]}`)
+	})
+
+	results, err := trentoApi.GetChecksResults(context.Background(), models.CheckTargetHost, "host1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.ChecksResultAsList{
+		Hosts: map[string]*models.HostState{"vmhana01": {Reachable: true}},
+		Checks: []*models.ChecksByHost{
+			{ID: "1.1.1", Hosts: map[string]*models.Check{"vmhana01": {Result: models.CheckPassing}}},
+		},
+	}, results)
+
+	_, err = trentoApi.GetChecksResults(context.Background(), "unknown", "host1")
+	assert.EqualError(t, err, "unknown checks target type unknown")
+}
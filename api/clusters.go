@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+// ListClusters returns the clusters matching the filter, all of them when there is no page
+func (t *trentoApiService) ListClusters(ctx context.Context, filter *ClustersFilter, page *Page) (models.ClusterList, error) {
+	var clusters models.ClusterList
+	if err := t.getResource(ctx, listQuery("clusters", filter.values(), page), &clusters); err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+// GetCluster returns a cluster, with the details of the HANA clusters.
+// A StatusError satisfying IsNotFound is returned when there is no such cluster
+func (t *trentoApiService) GetCluster(ctx context.Context, id string) (*models.Cluster, error) {
+	var cluster models.Cluster
+	if err := t.getResource(ctx, "clusters/"+url.PathEscape(id), &cluster); err != nil {
+		return nil, err
+	}
+
+	return &cluster, nil
+}
@@ -9,7 +9,17 @@ import (
 )
 
 func (t *trentoApiService) GetClustersSettings() (webApi.ClustersSettingsResponse, error) {
-	body, statusCode, err := t.getJson("clusters/settings")
+	return t.getSettings("clusters/settings")
+}
+
+// GetChecksTargetsSettings returns the settings of the clusters, plus the ones of the standalone hosts
+// and SAP systems having selected checks
+func (t *trentoApiService) GetChecksTargetsSettings() (webApi.ClustersSettingsResponse, error) {
+	return t.getSettings("checks/targets/settings")
+}
+
+func (t *trentoApiService) getSettings(resource string) (webApi.ClustersSettingsResponse, error) {
+	body, statusCode, err := t.getJson(resource)
 	if err != nil {
 		return nil, err
 	}
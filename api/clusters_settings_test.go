@@ -147,3 +147,21 @@ func assertMatchingHostsOnCluster1(suite *ClusterSettingsApiTestCase, hosts []*m
 	suite.Equal("10.1.2.13", hosts[1].Address)
 	suite.Equal("cloudadmin", hosts[1].User)
 }
+
+func (suite *ClusterSettingsApiTestCase) Test_ChecksTargetsSettingsAreSuccessfullyRetrieved() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal(req.URL.String(), "http://192.168.1.10:8000/api/checks/targets/settings")
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(
+				`[{"id":"host1","target_type":"host","selected_checks":["check1"],"hosts":[{"name":"host1","address":"10.74.1.5","user":"root"}]}]`)),
+		}
+	})
+
+	settings, err := suite.trentoApi.GetChecksTargetsSettings()
+	suite.NoError(err)
+	suite.Len(settings, 1)
+	suite.Equal("host1", settings[0].ID)
+	suite.Equal(models.CheckTargetHost, settings[0].TargetType)
+	suite.Equal([]string{"check1"}, settings[0].SelectedChecks)
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestListClusters(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/clusters", r.URL.Path)
+		assert.Equal(t, "HANA scale-up", r.URL.Query().Get("cluster_type"))
+		assert.NotContains(t, r.URL.Query(), "page")
+
+		fmt.Fprint(w, `[{"id":"cluster1","name":"hana_cluster","cluster_type":"HANA scale-up","sid":"PRD"}]`)
+	})
+
+	clusters, err := trentoApi.ListClusters(context.Background(), &ClustersFilter{ClusterTypes: []string{models.ClusterTypeHANAScaleUp}}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ClusterList{
+		{ID: "cluster1", Name: "hana_cluster", ClusterType: models.ClusterTypeHANAScaleUp, SID: "PRD"},
+	}, clusters)
+}
+
+func TestGetCluster(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/clusters/cluster1", r.URL.Path)
+
+		fmt.Fprint(w, `{"id":"cluster1","cluster_type":"HANA scale-up","details":{"fencing_type":"external/sbd","nodes":[{"name":"node1","hana_status":"Primary"}]}}`)
+	})
+
+	cluster, err := trentoApi.GetCluster(context.Background(), "cluster1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Cluster{
+		ID:          "cluster1",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		Details: &models.HANAClusterDetails{
+			FencingType: "external/sbd",
+			Nodes:       models.ClusterNodes{{Name: "node1", HANAStatus: models.HANAStatusPrimary}},
+		},
+	}, cluster)
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+// GetCustomChecks returns the custom checks authored through the API, to be run next to the built-in ones
+func (t *trentoApiService) GetCustomChecks() ([]*models.CustomCheck, error) {
+	body, statusCode, err := t.getJson("checks/custom")
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("error during the request with status code %d", statusCode)
+	}
+
+	var customChecks []*models.CustomCheck
+	if err := json.Unmarshal(body, &customChecks); err != nil {
+		return nil, err
+	}
+
+	return customChecks, nil
+}
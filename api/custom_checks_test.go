@@ -0,0 +1,42 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestGetCustomChecks(t *testing.T) {
+	trentoApi := NewTrentoApiService("192.168.1.10", 8000)
+	trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, "http://192.168.1.10:8000/api/checks/custom", req.URL.String())
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(
+				`[{"id":"SITE01","version":2,"name":"9.1.1","group":"Site","implementation":"- debug: msg=site"}]`)),
+		}
+	})
+
+	customChecks, err := trentoApi.GetCustomChecks()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.CustomCheck{
+		{ID: "SITE01", Version: 2, Name: "9.1.1", Group: "Site", Implementation: "- debug: msg=site"},
+	}, customChecks)
+
+	trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 500,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	_, err = trentoApi.GetCustomChecks()
+
+	assert.Error(t, err)
+}
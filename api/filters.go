@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Page selects a page of a listing, all the items are listed without it
+type Page struct {
+	Number int
+	Size   int
+}
+
+type HostsFilter struct {
+	SIDs   []string
+	Tags   []string
+	Health []string
+}
+
+type ClustersFilter struct {
+	Names        []string
+	ClusterTypes []string
+	SIDs         []string
+	Tags         []string
+	Health       []string
+}
+
+type SAPSystemsFilter struct {
+	SIDs []string
+	Tags []string
+}
+
+func (f *HostsFilter) values() url.Values {
+	values := url.Values{}
+	if f == nil {
+		return values
+	}
+
+	values["sids"] = f.SIDs
+	values["tags"] = f.Tags
+	values["health"] = f.Health
+
+	return values
+}
+
+func (f *ClustersFilter) values() url.Values {
+	values := url.Values{}
+	if f == nil {
+		return values
+	}
+
+	values["name"] = f.Names
+	values["cluster_type"] = f.ClusterTypes
+	values["sids"] = f.SIDs
+	values["tags"] = f.Tags
+	values["health"] = f.Health
+
+	return values
+}
+
+func (f *SAPSystemsFilter) values() url.Values {
+	values := url.Values{}
+	if f == nil {
+		return values
+	}
+
+	values["sids"] = f.SIDs
+	values["tags"] = f.Tags
+
+	return values
+}
+
+// listQuery returns the query of a listing, with the filter values and the page
+func listQuery(resource string, values url.Values, page *Page) string {
+	if page != nil {
+		values.Set("page", strconv.Itoa(page.Number))
+		values.Set("per_page", strconv.Itoa(page.Size))
+	}
+
+	// the empty filters are not sent
+	for key, value := range values {
+		if len(value) == 0 {
+			delete(values, key)
+		}
+	}
+
+	if len(values) == 0 {
+		return resource
+	}
+
+	return resource + "?" + values.Encode()
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+// ListHosts returns the hosts matching the filter, all of them when there is no page
+func (t *trentoApiService) ListHosts(ctx context.Context, filter *HostsFilter, page *Page) (models.HostList, error) {
+	var hosts models.HostList
+	if err := t.getResource(ctx, listQuery("hosts", filter.values(), page), &hosts); err != nil {
+		return nil, err
+	}
+
+	return hosts, nil
+}
+
+// GetHost returns a host, a StatusError satisfying IsNotFound is returned when there is no such host
+func (t *trentoApiService) GetHost(ctx context.Context, id string) (*models.Host, error) {
+	var host models.Host
+	if err := t.getResource(ctx, "hosts/"+url.PathEscape(id), &host); err != nil {
+		return nil, err
+	}
+
+	return &host, nil
+}
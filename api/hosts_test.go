@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestListHosts(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/hosts", r.URL.Path)
+		assert.Equal(t, []string{"prod", "dc1"}, r.URL.Query()["tags"])
+		assert.Equal(t, "critical", r.URL.Query().Get("health"))
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		assert.Equal(t, "10", r.URL.Query().Get("per_page"))
+		assert.NotContains(t, r.URL.Query(), "sids")
+
+		fmt.Fprint(w, `[{"id":"host1","name":"vmhana01","health":"critical","tags":["prod"]}]`)
+	})
+
+	hosts, err := trentoApi.ListHosts(
+		context.Background(),
+		&HostsFilter{Tags: []string{"prod", "dc1"}, Health: []string{"critical"}},
+		&Page{Number: 2, Size: 10},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.HostList{
+		{ID: "host1", Name: "vmhana01", Health: "critical", Tags: []string{"prod"}},
+	}, hosts)
+}
+
+func TestGetHost(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/hosts/host1", r.URL.Path)
+
+		fmt.Fprint(w, `{"id":"host1","name":"vmhana01","cloud_provider":"azure","cloud_data":{"vmname":"vmhana01"}}`)
+	})
+
+	host, err := trentoApi.GetHost(context.Background(), "host1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Host{
+		ID:            "host1",
+		Name:          "vmhana01",
+		CloudProvider: "azure",
+		CloudData:     models.AzureCloudData{VMName: "vmhana01"},
+	}, host)
+}
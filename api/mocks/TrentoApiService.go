@@ -3,8 +3,11 @@
 package mocks
 
 import (
+	context "context"
 	mock "github.com/stretchr/testify/mock"
+	api "github.com/trento-project/trento/api"
 	web "github.com/trento-project/trento/web"
+	models "github.com/trento-project/trento/web/models"
 )
 
 // TrentoApiService is an autogenerated mock type for the TrentoApiService type
@@ -12,6 +15,195 @@ type TrentoApiService struct {
 	mock.Mock
 }
 
+// AddTag provides a mock function with given fields: ctx, resourceType, resourceID, tag
+func (_m *TrentoApiService) AddTag(ctx context.Context, resourceType string, resourceID string, tag string) error {
+	ret := _m.Called(ctx, resourceType, resourceID, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, resourceType, resourceID, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimChecksExecution provides a mock function with given fields: runnerID
+func (_m *TrentoApiService) ClaimChecksExecution(runnerID string) (*web.JSONChecksExecution, error) {
+	ret := _m.Called(runnerID)
+
+	var r0 *web.JSONChecksExecution
+	if rf, ok := ret.Get(0).(func(string) *web.JSONChecksExecution); ok {
+		r0 = rf(runnerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*web.JSONChecksExecution)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(runnerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimRemediation provides a mock function with given fields: runnerID
+func (_m *TrentoApiService) ClaimRemediation(runnerID string) (*models.Remediation, error) {
+	ret := _m.Called(runnerID)
+
+	var r0 *models.Remediation
+	if rf, ok := ret.Get(0).(func(string) *models.Remediation); ok {
+		r0 = rf(runnerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Remediation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(runnerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTag provides a mock function with given fields: ctx, resourceType, resourceID, tag
+func (_m *TrentoApiService) DeleteTag(ctx context.Context, resourceType string, resourceID string, tag string) error {
+	ret := _m.Called(ctx, resourceType, resourceID, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, resourceType, resourceID, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetChecksCatalog provides a mock function with given fields: ctx
+func (_m *TrentoApiService) GetChecksCatalog(ctx context.Context) (web.JSONChecksGroupedCatalog, error) {
+	ret := _m.Called(ctx)
+
+	var r0 web.JSONChecksGroupedCatalog
+	if rf, ok := ret.Get(0).(func(context.Context) web.JSONChecksGroupedCatalog); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(web.JSONChecksGroupedCatalog)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChecksResults provides a mock function with given fields: ctx, targetType, targetID
+func (_m *TrentoApiService) GetChecksResults(ctx context.Context, targetType string, targetID string) (*models.ChecksResultAsList, error) {
+	ret := _m.Called(ctx, targetType, targetID)
+
+	var r0 *models.ChecksResultAsList
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ChecksResultAsList); ok {
+		r0 = rf(ctx, targetType, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChecksResultAsList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, targetType, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChecksSettings provides a mock function with given fields: ctx, targetID
+func (_m *TrentoApiService) GetChecksSettings(ctx context.Context, targetID string) (*web.JSONChecksSettings, error) {
+	ret := _m.Called(ctx, targetID)
+
+	var r0 *web.JSONChecksSettings
+	if rf, ok := ret.Get(0).(func(context.Context, string) *web.JSONChecksSettings); ok {
+		r0 = rf(ctx, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*web.JSONChecksSettings)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChecksTargetsSettings provides a mock function with given fields:
+func (_m *TrentoApiService) GetChecksTargetsSettings() (web.ClustersSettingsResponse, error) {
+	ret := _m.Called()
+
+	var r0 web.ClustersSettingsResponse
+	if rf, ok := ret.Get(0).(func() web.ClustersSettingsResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(web.ClustersSettingsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCluster provides a mock function with given fields: ctx, id
+func (_m *TrentoApiService) GetCluster(ctx context.Context, id string) (*models.Cluster, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Cluster
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Cluster); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Cluster)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetClustersSettings provides a mock function with given fields:
 func (_m *TrentoApiService) GetClustersSettings() (web.ClustersSettingsResponse, error) {
 	ret := _m.Called()
@@ -35,6 +227,98 @@ func (_m *TrentoApiService) GetClustersSettings() (web.ClustersSettingsResponse,
 	return r0, r1
 }
 
+// GetCustomChecks provides a mock function with given fields:
+func (_m *TrentoApiService) GetCustomChecks() ([]*models.CustomCheck, error) {
+	ret := _m.Called()
+
+	var r0 []*models.CustomCheck
+	if rf, ok := ret.Get(0).(func() []*models.CustomCheck); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CustomCheck)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDatabase provides a mock function with given fields: ctx, id
+func (_m *TrentoApiService) GetDatabase(ctx context.Context, id string) (*models.SAPSystem, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.SAPSystem
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.SAPSystem); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SAPSystem)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHost provides a mock function with given fields: ctx, id
+func (_m *TrentoApiService) GetHost(ctx context.Context, id string) (*models.Host, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Host
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Host); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Host)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSAPSystem provides a mock function with given fields: ctx, id
+func (_m *TrentoApiService) GetSAPSystem(ctx context.Context, id string) (*models.SAPSystem, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.SAPSystem
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.SAPSystem); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SAPSystem)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsWebServerUp provides a mock function with given fields:
 func (_m *TrentoApiService) IsWebServerUp() bool {
 	ret := _m.Called()
@@ -48,3 +332,227 @@ func (_m *TrentoApiService) IsWebServerUp() bool {
 
 	return r0
 }
+
+// ListClusters provides a mock function with given fields: ctx, filter, page
+func (_m *TrentoApiService) ListClusters(ctx context.Context, filter *api.ClustersFilter, page *api.Page) (models.ClusterList, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	var r0 models.ClusterList
+	if rf, ok := ret.Get(0).(func(context.Context, *api.ClustersFilter, *api.Page) models.ClusterList); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.ClusterList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *api.ClustersFilter, *api.Page) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDatabases provides a mock function with given fields: ctx, filter, page
+func (_m *TrentoApiService) ListDatabases(ctx context.Context, filter *api.SAPSystemsFilter, page *api.Page) (models.SAPSystemList, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	var r0 models.SAPSystemList
+	if rf, ok := ret.Get(0).(func(context.Context, *api.SAPSystemsFilter, *api.Page) models.SAPSystemList); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.SAPSystemList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *api.SAPSystemsFilter, *api.Page) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHosts provides a mock function with given fields: ctx, filter, page
+func (_m *TrentoApiService) ListHosts(ctx context.Context, filter *api.HostsFilter, page *api.Page) (models.HostList, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	var r0 models.HostList
+	if rf, ok := ret.Get(0).(func(context.Context, *api.HostsFilter, *api.Page) models.HostList); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.HostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *api.HostsFilter, *api.Page) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSAPSystems provides a mock function with given fields: ctx, filter, page
+func (_m *TrentoApiService) ListSAPSystems(ctx context.Context, filter *api.SAPSystemsFilter, page *api.Page) (models.SAPSystemList, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	var r0 models.SAPSystemList
+	if rf, ok := ret.Get(0).(func(context.Context, *api.SAPSystemsFilter, *api.Page) models.SAPSystemList); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.SAPSystemList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *api.SAPSystemsFilter, *api.Page) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTags provides a mock function with given fields: ctx, resourceTypes
+func (_m *TrentoApiService) ListTags(ctx context.Context, resourceTypes ...string) ([]string, error) {
+	_va := make([]interface{}, len(resourceTypes))
+	for _i := range resourceTypes {
+		_va[_i] = resourceTypes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) []string); ok {
+		r0 = rf(ctx, resourceTypes...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, resourceTypes...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseRunnerLeases provides a mock function with given fields: runnerID
+func (_m *TrentoApiService) ReleaseRunnerLeases(runnerID string) error {
+	ret := _m.Called(runnerID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(runnerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RunnerHeartbeat provides a mock function with given fields: runnerID, heartbeat
+func (_m *TrentoApiService) RunnerHeartbeat(runnerID string, heartbeat *web.JSONRunnerHeartbeat) (*web.JSONRunnerLeases, error) {
+	ret := _m.Called(runnerID, heartbeat)
+
+	var r0 *web.JSONRunnerLeases
+	if rf, ok := ret.Get(0).(func(string, *web.JSONRunnerHeartbeat) *web.JSONRunnerLeases); ok {
+		r0 = rf(runnerID, heartbeat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*web.JSONRunnerLeases)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *web.JSONRunnerHeartbeat) error); ok {
+		r1 = rf(runnerID, heartbeat)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartChecksExecution provides a mock function with given fields: clusterID, trigger
+func (_m *TrentoApiService) StartChecksExecution(clusterID string, trigger string) (*web.JSONChecksExecution, error) {
+	ret := _m.Called(clusterID, trigger)
+
+	var r0 *web.JSONChecksExecution
+	if rf, ok := ret.Get(0).(func(string, string) *web.JSONChecksExecution); ok {
+		r0 = rf(clusterID, trigger)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*web.JSONChecksExecution)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(clusterID, trigger)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateChecksExecution provides a mock function with given fields: id, result
+func (_m *TrentoApiService) UpdateChecksExecution(id int64, result *web.JSONChecksExecutionResult) error {
+	ret := _m.Called(id, result)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *web.JSONChecksExecutionResult) error); ok {
+		r0 = rf(id, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateChecksSettings provides a mock function with given fields: ctx, targetID, settings
+func (_m *TrentoApiService) UpdateChecksSettings(ctx context.Context, targetID string, settings *web.JSONChecksSettings) error {
+	ret := _m.Called(ctx, targetID, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *web.JSONChecksSettings) error); ok {
+		r0 = rf(ctx, targetID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRemediation provides a mock function with given fields: id, result
+func (_m *TrentoApiService) UpdateRemediation(id int64, result *web.JSONRemediationResult) error {
+	ret := _m.Called(id, result)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *web.JSONRemediationResult) error); ok {
+		r0 = rf(id, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
+)
+
+// ClaimRemediation takes the oldest remediation to preview or to apply, nil is returned when there is none.
+// Given a runner id, only the remediations of the clusters leased to the runner are claimed
+func (t *trentoApiService) ClaimRemediation(runnerID string) (*models.Remediation, error) {
+	query := "remediations/claim"
+	if runnerID != "" {
+		query += "?runner_id=" + url.QueryEscape(runnerID)
+	}
+
+	body, statusCode, err := t.sendJson(http.MethodPost, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("error during the request with status code %d", statusCode)
+	}
+
+	var remediation models.Remediation
+
+	err = json.Unmarshal(body, &remediation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remediation, nil
+}
+
+// UpdateRemediation reports the outcome of a remediation preview or apply run
+func (t *trentoApiService) UpdateRemediation(id int64, result *webApi.JSONRemediationResult) error {
+	_, statusCode, err := t.sendJson(http.MethodPut, fmt.Sprintf("remediations/%d", id), result)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("error during the request with status code %d", statusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
+)
+
+type RemediationsApiTestCase struct {
+	suite.Suite
+	trentoApi *trentoApiService
+}
+
+func TestRemediationsApiTestCase(t *testing.T) {
+	suite.Run(t, new(RemediationsApiTestCase))
+}
+
+func (suite *RemediationsApiTestCase) SetupTest() {
+	suite.trentoApi = NewTrentoApiService("192.168.1.10", 8000)
+}
+
+func (suite *RemediationsApiTestCase) Test_ClaimRemediation() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal("POST", req.Method)
+		suite.Equal("http://192.168.1.10:8000/api/remediations/claim?runner_id=runner1", req.URL.String())
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(
+				`{"id":2,"cluster_id":"cluster1","checks":["1.1.1"],"status":"previewing"}`)),
+		}
+	})
+
+	remediation, err := suite.trentoApi.ClaimRemediation("runner1")
+
+	suite.NoError(err)
+	suite.EqualValues(2, remediation.ID)
+	suite.Equal([]string{"1.1.1"}, remediation.Checks)
+	suite.True(remediation.IsPreview())
+}
+
+func (suite *RemediationsApiTestCase) Test_ClaimRemediationNone() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	remediation, err := suite.trentoApi.ClaimRemediation("")
+
+	suite.NoError(err)
+	suite.Nil(remediation)
+}
+
+func (suite *RemediationsApiTestCase) Test_UpdateRemediation() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal("PUT", req.Method)
+		suite.Equal("http://192.168.1.10:8000/api/remediations/2", req.URL.String())
+		body, _ := io.ReadAll(req.Body)
+		suite.JSONEq(`{"failed":false,"diff":{"node1":{"1.1.1":"+token: 30000\n"}}}`, string(body))
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(string(body))),
+		}
+	})
+
+	err := suite.trentoApi.UpdateRemediation(2, &webApi.JSONRemediationResult{
+		Diff: models.RemediationDiff{"node1": {"1.1.1": "+token: 30000\n"}},
+	})
+
+	suite.NoError(err)
+}
+
+func (suite *RemediationsApiTestCase) Test_UpdateRemediationError() {
+	suite.trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 409,
+			Body:       io.NopCloser(strings.NewReader(`{"error":"remediation conflict"}`)),
+		}
+	})
+
+	err := suite.trentoApi.UpdateRemediation(2, &webApi.JSONRemediationResult{})
+
+	suite.Error(err)
+}
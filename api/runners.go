@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	webApi "github.com/trento-project/trento/web"
+)
+
+// RunnerHeartbeat registers the runner as alive and returns the clusters leased to it
+func (t *trentoApiService) RunnerHeartbeat(runnerID string, heartbeat *webApi.JSONRunnerHeartbeat) (*webApi.JSONRunnerLeases, error) {
+	body, statusCode, err := t.sendJson(http.MethodPost, fmt.Sprintf("runners/%s/heartbeat", url.PathEscape(runnerID)), heartbeat)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("error during the request with status code %d", statusCode)
+	}
+
+	var leases webApi.JSONRunnerLeases
+
+	err = json.Unmarshal(body, &leases)
+	if err != nil {
+		return nil, err
+	}
+
+	return &leases, nil
+}
+
+// ReleaseRunnerLeases gives back the leases of the runner, so the other runners take over its clusters
+func (t *trentoApiService) ReleaseRunnerLeases(runnerID string) error {
+	_, statusCode, err := t.sendJson(http.MethodDelete, fmt.Sprintf("runners/%s/leases", url.PathEscape(runnerID)), nil)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusNoContent {
+		return fmt.Errorf("error during the request with status code %d", statusCode)
+	}
+
+	return nil
+}
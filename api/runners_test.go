@@ -0,0 +1,63 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/test/helpers"
+	webApi "github.com/trento-project/trento/web"
+)
+
+func TestRunnerHeartbeat(t *testing.T) {
+	trentoApi := NewTrentoApiService("192.168.1.10", 8000)
+	trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "http://192.168.1.10:8000/api/runners/runner%201/heartbeat", req.URL.String())
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{"tags":["dc1"],"cluster_ids":null}`, string(body))
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(
+				`{"leases":[{"cluster_id":"cluster1","runner_id":"runner 1"}],"lease_duration":90}`)),
+		}
+	})
+
+	leases, err := trentoApi.RunnerHeartbeat("runner 1", &webApi.JSONRunnerHeartbeat{Tags: []string{"dc1"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 90, leases.LeaseDuration)
+	assert.Len(t, leases.Leases, 1)
+	assert.Equal(t, "cluster1", leases.Leases[0].ClusterID)
+}
+
+func TestRunnerHeartbeatError(t *testing.T) {
+	trentoApi := NewTrentoApiService("192.168.1.10", 8000)
+	trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 400,
+			Body:       io.NopCloser(strings.NewReader(`{"error":"invalid runner"}`)),
+		}
+	})
+
+	leases, err := trentoApi.RunnerHeartbeat("runner1", &webApi.JSONRunnerHeartbeat{})
+
+	assert.Nil(t, leases)
+	assert.EqualError(t, err, "error during the request with status code 400")
+}
+
+func TestReleaseRunnerLeases(t *testing.T) {
+	trentoApi := NewTrentoApiService("192.168.1.10", 8000)
+	trentoApi.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, "DELETE", req.Method)
+		assert.Equal(t, "http://192.168.1.10:8000/api/runners/runner1/leases", req.URL.String())
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	})
+
+	assert.NoError(t, trentoApi.ReleaseRunnerLeases("runner1"))
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+// ListSAPSystems returns the SAP systems matching the filter, all of them when there is no page
+func (t *trentoApiService) ListSAPSystems(ctx context.Context, filter *SAPSystemsFilter, page *Page) (models.SAPSystemList, error) {
+	return t.listSAPSystems(ctx, "sapsystems", filter, page)
+}
+
+// ListDatabases returns the HANA databases matching the filter, all of them when there is no page
+func (t *trentoApiService) ListDatabases(ctx context.Context, filter *SAPSystemsFilter, page *Page) (models.SAPSystemList, error) {
+	return t.listSAPSystems(ctx, "databases", filter, page)
+}
+
+func (t *trentoApiService) listSAPSystems(ctx context.Context, resource string, filter *SAPSystemsFilter, page *Page) (models.SAPSystemList, error) {
+	var sapSystems models.SAPSystemList
+	if err := t.getResource(ctx, listQuery(resource, filter.values(), page), &sapSystems); err != nil {
+		return nil, err
+	}
+
+	return sapSystems, nil
+}
+
+// GetSAPSystem returns a SAP system, a StatusError satisfying IsNotFound is returned when there is no such system
+func (t *trentoApiService) GetSAPSystem(ctx context.Context, id string) (*models.SAPSystem, error) {
+	return t.getSAPSystem(ctx, "sapsystems", id)
+}
+
+// GetDatabase returns a HANA database, a StatusError satisfying IsNotFound is returned when there is no such database
+func (t *trentoApiService) GetDatabase(ctx context.Context, id string) (*models.SAPSystem, error) {
+	return t.getSAPSystem(ctx, "databases", id)
+}
+
+func (t *trentoApiService) getSAPSystem(ctx context.Context, resource string, id string) (*models.SAPSystem, error) {
+	var sapSystem models.SAPSystem
+	if err := t.getResource(ctx, resource+"/"+url.PathEscape(id), &sapSystem); err != nil {
+		return nil, err
+	}
+
+	return &sapSystem, nil
+}
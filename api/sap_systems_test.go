@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestListSAPSystemsAndDatabases(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "HA1", r.URL.Query().Get("sids"))
+
+		switch r.URL.Path {
+		case "/api/sapsystems":
+			fmt.Fprint(w, `[{"id":"sapsystem1","sid":"HA1","type":"application"}]`)
+		case "/api/databases":
+			fmt.Fprint(w, `[{"id":"database1","sid":"HA1","type":"database"}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	filter := &SAPSystemsFilter{SIDs: []string{"HA1"}}
+
+	sapSystems, err := trentoApi.ListSAPSystems(context.Background(), filter, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SAPSystemList{{ID: "sapsystem1", SID: "HA1", Type: models.SAPSystemTypeApplication}}, sapSystems)
+
+	databases, err := trentoApi.ListDatabases(context.Background(), filter, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SAPSystemList{{ID: "database1", SID: "HA1", Type: models.SAPSystemTypeDatabase}}, databases)
+}
+
+func TestGetDatabase(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/databases/database1", r.URL.Path)
+
+		fmt.Fprint(w, `{"id":"database1","sid":"PRD","type":"database","instances":[{"instance_number":"00","hostname":"vmhana01"}]}`)
+	})
+
+	database, err := trentoApi.GetDatabase(context.Background(), "database1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SAPSystem{
+		ID:        "database1",
+		SID:       "PRD",
+		Type:      models.SAPSystemTypeDatabase,
+		Instances: []*models.SAPSystemInstance{{InstanceNumber: "00", Hostname: "vmhana01"}},
+	}, database)
+}
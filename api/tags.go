@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	webApi "github.com/trento-project/trento/web"
+)
+
+// ListTags returns the tags in use, only the ones of the given resource types when there are any,
+// e.g. models.TagHostResourceType
+func (t *trentoApiService) ListTags(ctx context.Context, resourceTypes ...string) ([]string, error) {
+	var tags []string
+	query := listQuery("tags", url.Values{"resource_type": resourceTypes}, nil)
+	if err := t.getResource(ctx, query, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// AddTag tags a resource, whose type is one of the models tag resource types
+func (t *trentoApiService) AddTag(ctx context.Context, resourceType string, resourceID string, tag string) error {
+	query := fmt.Sprintf("%s/%s/tags", url.PathEscape(resourceType), url.PathEscape(resourceID))
+
+	body, statusCode, err := t.sendJsonContext(ctx, http.MethodPost, query, &webApi.JSONTag{Tag: tag})
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusCreated {
+		return newStatusError(statusCode, body)
+	}
+
+	return nil
+}
+
+// DeleteTag removes a tag of a resource, whose type is one of the models tag resource types
+func (t *trentoApiService) DeleteTag(ctx context.Context, resourceType string, resourceID string, tag string) error {
+	query := fmt.Sprintf("%s/%s/tags/%s", url.PathEscape(resourceType), url.PathEscape(resourceID), url.PathEscape(tag))
+
+	body, statusCode, err := t.sendJsonContext(ctx, http.MethodDelete, query, nil)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusNoContent {
+		return newStatusError(statusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestListTags(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/tags", r.URL.Path)
+		assert.Equal(t, []string{"hosts", "clusters"}, r.URL.Query()["resource_type"])
+
+		fmt.Fprint(w, `["dc1","prod"]`)
+	})
+
+	tags, err := trentoApi.ListTags(context.Background(), models.TagHostResourceType, models.TagClusterResourceType)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dc1", "prod"}, tags)
+}
+
+func TestAddAndDeleteTag(t *testing.T) {
+	trentoApi := newTestTrentoApiService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/hosts/host1/tags":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"tag":"prod"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/hosts/host1/tags/prod":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"resource not found"}`)
+		}
+	})
+
+	assert.NoError(t, trentoApi.AddTag(context.Background(), models.TagHostResourceType, "host1", "prod"))
+	assert.NoError(t, trentoApi.DeleteTag(context.Background(), models.TagHostResourceType, "host1", "prod"))
+
+	err := trentoApi.AddTag(context.Background(), models.TagClusterResourceType, "unknown", "prod")
+	assert.True(t, IsNotFound(err))
+}
@@ -18,6 +18,7 @@ import (
 func NewAgentCmd() *cobra.Command {
 	var sshAddress string
 	var discoveryPeriod int
+	var checksPeriod int
 
 	var collectorHost string
 	var collectorPort int
@@ -48,13 +49,14 @@ func NewAgentCmd() *cobra.Command {
 	startCmd.Flags().StringVar(&sshAddress, "ssh-address", "", "The address to which the trento-agent should be reachable for ssh connection by the runner for check execution.")
 
 	startCmd.Flags().IntVarP(&discoveryPeriod, "discovery-period", "", 10, "Discovery mechanism loop period in seconds")
+	startCmd.Flags().IntVar(&checksPeriod, "checks-period", 60, "Period in seconds of the evaluation of the checks on the host, when the server runs the agent checks engine. 0 disables it")
 
 	startCmd.Flags().StringVar(&collectorHost, "collector-host", "localhost", "Data Collector host")
 	startCmd.Flags().IntVar(&collectorPort, "collector-port", 8081, "Data Collector port")
 
 	startCmd.Flags().BoolVar(&enablemTLS, "enable-mtls", false, "Enable mTLS authentication between server and agent")
 	startCmd.Flags().StringVar(&cert, "cert", "", "mTLS client certificate")
-	startCmd.Flags().StringVar(&key, "key", "", "mTLS client key")
+	startCmd.Flags().StringVar(&key, "key", "", "mTLS client key, either a file path or a secret reference (env:, file: or vault:)")
 	startCmd.Flags().StringVar(&ca, "ca", "", "mTLS Certificate Authority")
 
 	agentCmd.AddCommand(startCmd)
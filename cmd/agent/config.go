@@ -9,14 +9,19 @@ import (
 	"github.com/spf13/viper"
 	"github.com/trento-project/trento/agent"
 	"github.com/trento-project/trento/agent/discovery/collector"
+	"github.com/trento-project/trento/internal/secrets"
 )
 
 func LoadConfig() (*agent.Config, error) {
 	enablemTLS := viper.GetBool("enable-mtls")
 	cert := viper.GetString("cert")
-	key := viper.GetString("key")
 	ca := viper.GetString("ca")
 
+	key, err := secrets.Get("key")
+	if err != nil {
+		return nil, err
+	}
+
 	if enablemTLS {
 		var err error
 
@@ -56,5 +61,6 @@ func LoadConfig() (*agent.Config, error) {
 		InstanceName:    hostname,
 		SSHAddress:      sshAddress,
 		DiscoveryPeriod: time.Duration(viper.GetInt("discovery-period")) * time.Second,
+		ChecksPeriod:    time.Duration(viper.GetInt("checks-period")) * time.Second,
 	}, nil
 }
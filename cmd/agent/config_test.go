@@ -47,6 +47,7 @@ func (suite *AgentCmdTestSuite) TearDownTest() {
 		InstanceName:    "some-hostname",
 		SSHAddress:      "some-ssh-address",
 		DiscoveryPeriod: 10 * time.Second,
+		ChecksPeriod:    120 * time.Second,
 		CollectorConfig: &collector.Config{
 			CollectorHost: "localhost",
 			CollectorPort: 1337,
@@ -69,6 +70,7 @@ func (suite *AgentCmdTestSuite) TestConfigFromFlags() {
 		"start",
 		"--ssh-address=some-ssh-address",
 		"--discovery-period=10",
+		"--checks-period=120",
 		"--collector-host=localhost",
 		"--collector-port=1337",
 		"--enable-mtls",
@@ -81,6 +83,7 @@ func (suite *AgentCmdTestSuite) TestConfigFromFlags() {
 func (suite *AgentCmdTestSuite) TestConfigFromEnv() {
 	os.Setenv("TRENTO_SSH_ADDRESS", "some-ssh-address")
 	os.Setenv("TRENTO_DISCOVERY_PERIOD", "10")
+	os.Setenv("TRENTO_CHECKS_PERIOD", "120")
 	os.Setenv("TRENTO_COLLECTOR_HOST", "localhost")
 	os.Setenv("TRENTO_COLLECTOR_PORT", "1337")
 	os.Setenv("TRENTO_ENABLE_MTLS", "true")
@@ -0,0 +1,469 @@
+package ctl
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/version"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/migrations"
+	"github.com/trento-project/trento/web/models"
+)
+
+// The backup format version follows a MAJOR.MINOR scheme.
+// Minor versions may add new optional datasets, which are ignored by older restores,
+// while major versions introduce incompatible changes.
+const (
+	backupFormatMajor = 1
+	backupFormatMinor = 1
+
+	backupManifestFile = "manifest.json"
+	backupEventsName   = "events"
+)
+
+type backupManifest struct {
+	FormatVersion string            `json:"format_version"`
+	TrentoVersion string            `json:"trento_version"`
+	SchemaVersion uint              `json:"schema_version"`
+	CreatedAt     time.Time         `json:"created_at"`
+	IncludeEvents bool              `json:"include_events"`
+	Files         map[string]string `json:"files"`
+}
+
+type backupDataset struct {
+	name string
+	// newModel returns a pointer to an empty slice of the entities stored in the dataset
+	newModel func() interface{}
+	// serial datasets have auto incremented IDs, which are kept on restore as other entries refer to them,
+	// e.g. the audit log resources, and whose sequences are moved past the restored IDs
+	serial bool
+	// sinceMinor is the minor format version adding the dataset, the archives of older versions don't contain it
+	sinceMinor int
+}
+
+func backupDatasets() []backupDataset {
+	return []backupDataset{
+		{name: "settings", newModel: func() interface{} { return &[]entities.Settings{} }},
+		{name: "tags", newModel: func() interface{} { return &[]models.Tag{} }},
+		{name: "selected_checks", newModel: func() interface{} { return &[]models.SelectedChecks{} }},
+		{name: "connection_settings", newModel: func() interface{} { return &[]models.ConnectionSettings{} }},
+		{name: "checks_catalog", newModel: func() interface{} { return &[]entities.Check{} }},
+		{name: "checks_results", newModel: func() interface{} { return &[]entities.ChecksResult{} }, serial: true},
+		{name: "custom_checks", newModel: func() interface{} { return &[]entities.CustomCheck{} }, sinceMinor: 1},
+		{name: "custom_check_versions", newModel: func() interface{} { return &[]entities.CustomCheckVersion{} }, sinceMinor: 1},
+		{name: "checks_catalog_versions", newModel: func() interface{} { return &[]entities.ChecksCatalogVersion{} }, serial: true, sinceMinor: 1},
+		{name: "check_parameters", newModel: func() interface{} { return &[]entities.CheckParameters{} }, sinceMinor: 1},
+		{name: "remediations", newModel: func() interface{} { return &[]entities.Remediation{} }, serial: true, sinceMinor: 1},
+		{name: "waivers", newModel: func() interface{} { return &[]entities.Waiver{} }, serial: true, sinceMinor: 1},
+		{name: "audit_log_entries", newModel: func() interface{} { return &[]entities.AuditLogEntry{} }, serial: true, sinceMinor: 1},
+		{name: backupEventsName, newModel: func() interface{} { return &[]datapipeline.DataCollectedEvent{} }, serial: true},
+	}
+}
+
+// backupExcludedTables are the tables not backed up: the ones projected from the discovery events, rebuilt as the
+// agents publish their data again, and the ones tracking the runners and their runs, which are started over
+var backupExcludedTables = []string{
+	"subscriptions", "host_telemetry", "clusters", "hosts", "host_heartbeats", "sles_subscriptions",
+	"sap_system_instances", "checks_executions", "host_checks_results", "runners", "runner_leases",
+}
+
+func backupFileName(dataset string) string {
+	return dataset + ".json"
+}
+
+func addBackupCmd(ctlCmd *cobra.Command) {
+	var output string
+	var includeEvents bool
+
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Create a backup archive of the Trento server state",
+		Run: func(*cobra.Command, []string) {
+			db := initDB()
+			output := viper.GetString("output")
+			includeEvents := viper.GetBool("include-events")
+
+			f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				log.Fatal("Error while creating the backup file: ", err)
+			}
+			defer f.Close()
+
+			manifest, err := createBackup(db, f, includeEvents)
+			if err != nil {
+				os.Remove(output)
+				log.Fatal("Error while creating the backup: ", err)
+			}
+
+			log.Infof("Backup of schema version %d stored in %s", manifest.SchemaVersion, output)
+		},
+	}
+
+	backupCmd.Flags().StringVar(&output, "output", fmt.Sprintf("trento-backup-%s.tar.gz", time.Now().Format("20060102150405")), "The path of the backup archive to create.")
+	backupCmd.Flags().BoolVar(&includeEvents, "include-events", false, "Include the raw data discovery events in the backup.")
+
+	ctlCmd.AddCommand(backupCmd)
+}
+
+func addRestoreCmd(ctlCmd *cobra.Command) {
+	var input string
+	var verifyOnly bool
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the Trento server state from a backup archive, replacing the current one",
+		Run: func(*cobra.Command, []string) {
+			input := viper.GetString("input")
+			verifyOnly := viper.GetBool("verify-only")
+
+			f, err := os.Open(input)
+			if err != nil {
+				log.Fatal("Error while opening the backup file: ", err)
+			}
+			defer f.Close()
+
+			if verifyOnly {
+				manifest, _, err := readBackup(f, migrations.Migrations)
+				if err != nil {
+					log.Fatal("Invalid backup archive: ", err)
+				}
+				log.Infof("Backup archive %s created at %s is valid.", input, manifest.CreatedAt)
+				return
+			}
+
+			db := initDB()
+			manifest, err := restoreBackup(db, f, migrations.Migrations)
+			if err != nil {
+				log.Fatal("Error while restoring the backup: ", err)
+			}
+
+			log.Infof("Backup created at %s restored.", manifest.CreatedAt)
+		},
+	}
+
+	restoreCmd.Flags().StringVar(&input, "input", "", "The path of the backup archive to restore.")
+	restoreCmd.Flags().BoolVar(&verifyOnly, "verify-only", false, "Only verify the backup archive, without restoring it.")
+	restoreCmd.MarkFlagRequired("input")
+
+	ctlCmd.AddCommand(restoreCmd)
+}
+
+// createBackup dumps the server state in a gzipped tar archive.
+// All the datasets are read in the same repeatable read transaction, so the backup is consistent.
+func createBackup(gormDB *gorm.DB, w io.Writer, includeEvents bool) (*backupManifest, error) {
+	migrator, err := db.NewMigrator(gormDB, migrations.Migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrator.CheckCompatibility(); err != nil {
+		return nil, err
+	}
+
+	schemaVersion, err := migrator.CurrentVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &backupManifest{
+		FormatVersion: fmt.Sprintf("%d.%d", backupFormatMajor, backupFormatMinor),
+		TrentoVersion: version.Version,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now().UTC(),
+		IncludeEvents: includeEvents,
+		Files:         make(map[string]string),
+	}
+
+	files := make(map[string][]byte)
+	err = gormDB.Transaction(func(tx *gorm.DB) error {
+		for _, dataset := range backupDatasets() {
+			if dataset.name == backupEventsName && !includeEvents {
+				continue
+			}
+
+			data := dataset.newModel()
+			if err := tx.Order(primaryKeyOrder(tx, data)).Find(data).Error; err != nil {
+				return fmt.Errorf("could not read %s: %w", dataset.name, err)
+			}
+
+			content, err := json.Marshal(data)
+			if err != nil {
+				return err
+			}
+
+			fileName := backupFileName(dataset.name)
+			files[fileName] = content
+			manifest.Files[fileName] = checksum(content)
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	manifestContent, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	if err := writeTarFile(tw, backupManifestFile, manifestContent); err != nil {
+		return nil, err
+	}
+	for _, dataset := range backupDatasets() {
+		fileName := backupFileName(dataset.name)
+		if content, ok := files[fileName]; ok {
+			if err := writeTarFile(tw, fileName, content); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return manifest, gw.Close()
+}
+
+// readBackup reads and verifies a backup archive, returning its manifest and the content of its datasets
+func readBackup(r io.Reader, dbMigrations []*db.Migration) (*backupManifest, map[string][]byte, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gr.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, nil, err
+		}
+		files[header.Name] = content
+	}
+
+	manifestContent, ok := files[backupManifestFile]
+	if !ok {
+		return nil, nil, fmt.Errorf("the archive does not contain a %s file", backupManifestFile)
+	}
+
+	var manifest backupManifest
+	if err := json.Unmarshal(manifestContent, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("could not parse the manifest: %w", err)
+	}
+
+	major, minor, err := parseFormatVersion(manifest.FormatVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if major != backupFormatMajor {
+		return nil, nil, fmt.Errorf("unsupported backup format version %s, expected %d.x", manifest.FormatVersion, backupFormatMajor)
+	}
+
+	migrator, err := db.NewMigrator(nil, dbMigrations)
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest.SchemaVersion > migrator.LatestVersion() {
+		return nil, nil, fmt.Errorf("%w: backup is at version %d, latest supported version is %d",
+			db.ErrSchemaTooNew, manifest.SchemaVersion, migrator.LatestVersion())
+	}
+
+	for fileName, expected := range manifest.Files {
+		content, ok := files[fileName]
+		if !ok {
+			return nil, nil, fmt.Errorf("file %s listed in the manifest is missing", fileName)
+		}
+		if checksum(content) != expected {
+			return nil, nil, fmt.Errorf("checksum mismatch for file %s", fileName)
+		}
+	}
+
+	for _, dataset := range backupDatasets() {
+		if dataset.name == backupEventsName && !manifest.IncludeEvents {
+			continue
+		}
+		if dataset.sinceMinor > minor {
+			continue
+		}
+		if _, ok := manifest.Files[backupFileName(dataset.name)]; !ok {
+			return nil, nil, fmt.Errorf("dataset %s is missing", dataset.name)
+		}
+	}
+
+	return &manifest, files, nil
+}
+
+// restoreBackup verifies a backup archive and replaces the current server state with its content.
+// The database schema is migrated to the latest version before restoring the data.
+func restoreBackup(gormDB *gorm.DB, r io.Reader, dbMigrations []*db.Migration) (*backupManifest, error) {
+	manifest, files, err := readBackup(r, dbMigrations)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := db.NewMigrator(gormDB, dbMigrations)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := migrator.Migrate(); err != nil {
+		return nil, err
+	}
+
+	for fileName := range files {
+		if fileName == backupManifestFile {
+			continue
+		}
+		if !isKnownBackupFile(fileName) {
+			log.Warnf("Skipping unknown file %s, created by a newer version of the backup format", fileName)
+		}
+	}
+
+	err = gormDB.Transaction(func(tx *gorm.DB) error {
+		for _, dataset := range backupDatasets() {
+			content, ok := files[backupFileName(dataset.name)]
+			if !ok {
+				if dataset.name != backupEventsName {
+					log.Warnf("The archive does not contain %s, the current entries are kept", dataset.name)
+				}
+				continue
+			}
+
+			data := dataset.newModel()
+			if err := json.Unmarshal(content, data); err != nil {
+				return fmt.Errorf("could not parse %s: %w", dataset.name, err)
+			}
+
+			elem := reflect.New(reflect.TypeOf(data).Elem().Elem()).Interface()
+			if err := tx.Where("1 = 1").Delete(elem).Error; err != nil {
+				return fmt.Errorf("could not clean %s: %w", dataset.name, err)
+			}
+
+			rows := reflect.ValueOf(data).Elem()
+			if rows.Len() == 0 {
+				continue
+			}
+
+			if err := tx.CreateInBatches(data, 100).Error; err != nil {
+				return fmt.Errorf("could not restore %s: %w", dataset.name, err)
+			}
+
+			if dataset.serial {
+				if err := resetSequence(tx, elem); err != nil {
+					return fmt.Errorf("could not reset the %s sequence: %w", dataset.name, err)
+				}
+			}
+			log.Infof("Restored %d %s entries", rows.Len(), dataset.name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func isKnownBackupFile(fileName string) bool {
+	for _, dataset := range backupDatasets() {
+		if backupFileName(dataset.name) == fileName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resetSequence moves the PostgreSQL sequence of the model IDs past the restored ones, so that the new entries don't
+// conflict with them. SQLite picks the IDs after the highest one stored and needs no reset.
+func resetSequence(tx *gorm.DB, model interface{}) error {
+	if tx.Dialector.Name() != db.PostgresDriver {
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+
+	return tx.Exec(fmt.Sprintf(
+		"SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE(MAX(id), 1), MAX(id) IS NOT NULL) FROM %[1]s",
+		stmt.Schema.Table)).Error
+}
+
+func primaryKeyOrder(tx *gorm.DB, model interface{}) string {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil || len(stmt.Schema.PrimaryFieldDBNames) == 0 {
+		return ""
+	}
+
+	return strings.Join(stmt.Schema.PrimaryFieldDBNames, ", ")
+}
+
+func parseFormatVersion(formatVersion string) (int, int, error) {
+	parts := strings.SplitN(formatVersion, ".", 2)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid backup format version %q", formatVersion)
+	}
+
+	minor := 0
+	if len(parts) == 2 {
+		if minor, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, fmt.Errorf("invalid backup format version %q", formatVersion)
+		}
+	}
+
+	return major, minor, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeTarFile(tw *tar.Writer, name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(content)
+	return err
+}
@@ -0,0 +1,283 @@
+package ctl
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/migrations"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type BackupTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+	tx *gorm.DB
+}
+
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupTestSuite))
+}
+
+func (suite *BackupTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+}
+
+func (suite *BackupTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	migrateDB(suite.tx, migrations.Migrations)
+}
+
+func (suite *BackupTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *BackupTestSuite) TestBackupAndRestore() {
+	suite.tx.Create(&entities.Settings{InstallationID: "59fd8017-b7fd-477b-9ebe-b658c558f3e9", EulaAccepted: true})
+	suite.tx.Create(&models.Tag{Value: "tag1", ResourceID: "cluster1", ResourceType: models.TagClusterResourceType})
+	suite.tx.Create(&models.SelectedChecks{ID: "cluster1", SelectedChecks: db.StringArray{"ABCDEF", "123456"}})
+	suite.tx.Create(&models.ConnectionSettings{ID: "cluster1", Node: "node1", User: "root"})
+	suite.tx.Create(&entities.Check{ID: "ABCDEF", Payload: []byte(`{"id":"ABCDEF"}`)})
+	suite.tx.Create(&entities.ChecksResult{ID: 10, GroupID: "cluster1", Payload: []byte(`{"hosts":{}}`)})
+	suite.tx.Create(&entities.ChecksResult{ID: 12, GroupID: "cluster1", Payload: []byte(`{"hosts":{}}`)})
+	suite.tx.Create(&entities.CustomCheck{ID: "SITE01", Version: 2, Name: "9.1.1", Implementation: "- debug:\n"})
+	suite.tx.Create(&entities.CustomCheckVersion{CheckID: "SITE01", Version: 1, Payload: []byte(`{"id":"SITE01"}`)})
+	suite.tx.Create(&entities.CustomCheckVersion{CheckID: "SITE01", Version: 2, Payload: []byte(`{"id":"SITE01"}`)})
+	suite.tx.Create(&entities.ChecksCatalogVersion{ID: 3, Added: []byte(`["ABCDEF"]`), Catalog: []byte(`[{"id":"ABCDEF"}]`)})
+	suite.tx.Create(&entities.CheckParameters{Scope: models.CheckParametersClusterScope, Target: "cluster1", Parameters: []byte(`{"expected_token":"30000"}`)})
+	suite.tx.Create(&entities.Remediation{ID: 5, ClusterID: "cluster1", Checks: db.StringArray{"ABCDEF"}, Status: models.RemediationApplied})
+	suite.tx.Create(&entities.Waiver{ID: 2, CheckID: "ABCDEF", Scope: models.WaiverScopeCluster, Target: "cluster1", Owner: "admin"})
+	suite.tx.Create(&entities.AuditLogEntry{ID: 7, Action: models.AuditWaiverCreated, Actor: "admin"})
+	suite.tx.Create(&datapipeline.DataCollectedEvent{ID: 1, AgentID: "agent1", DiscoveryType: "host_discovery", Payload: []byte("{}")})
+
+	var archive bytes.Buffer
+	manifest, err := createBackup(suite.tx, &archive, false)
+	suite.NoError(err)
+	suite.Equal(migrations.Migrations[len(migrations.Migrations)-1].Version, manifest.SchemaVersion)
+	suite.NotContains(manifest.Files, "events.json")
+
+	suite.tx.Where("1 = 1").Delete(&models.Tag{})
+	suite.tx.Create(&models.Tag{Value: "tag2", ResourceID: "host1", ResourceType: models.TagHostResourceType})
+	suite.tx.Where("1 = 1").Delete(&entities.CustomCheckVersion{})
+
+	_, err = restoreBackup(suite.tx, &archive, migrations.Migrations)
+	suite.NoError(err)
+
+	var tags []models.Tag
+	suite.tx.Find(&tags)
+	suite.Equal([]models.Tag{{Value: "tag1", ResourceID: "cluster1", ResourceType: models.TagClusterResourceType}}, tags)
+
+	var settings entities.Settings
+	suite.tx.First(&settings)
+	suite.Equal("59fd8017-b7fd-477b-9ebe-b658c558f3e9", settings.InstallationID)
+	suite.True(settings.EulaAccepted)
+
+	var selectedChecks models.SelectedChecks
+	suite.tx.First(&selectedChecks)
+	suite.ElementsMatch([]string{"ABCDEF", "123456"}, selectedChecks.SelectedChecks)
+
+	var checksResults []entities.ChecksResult
+	suite.tx.Order("id").Find(&checksResults)
+	suite.Equal(2, len(checksResults))
+	suite.Equal("cluster1", checksResults[0].GroupID)
+	suite.Equal(int64(10), checksResults[0].ID)
+	suite.Equal(int64(12), checksResults[1].ID)
+
+	var customCheck entities.CustomCheck
+	suite.tx.First(&customCheck)
+	suite.Equal("SITE01", customCheck.ID)
+	suite.Equal(2, customCheck.Version)
+
+	var count int64
+	suite.tx.Model(&entities.CustomCheckVersion{}).Count(&count)
+	suite.Equal(int64(2), count)
+
+	var catalogVersions []entities.ChecksCatalogVersion
+	suite.tx.Find(&catalogVersions)
+	suite.Equal(1, len(catalogVersions))
+	suite.Equal(int64(3), catalogVersions[0].ID)
+	suite.JSONEq(`["ABCDEF"]`, string(catalogVersions[0].Added))
+
+	var checkParameters entities.CheckParameters
+	suite.tx.First(&checkParameters)
+	suite.Equal("cluster1", checkParameters.Target)
+	suite.JSONEq(`{"expected_token":"30000"}`, string(checkParameters.Parameters))
+
+	var remediations []entities.Remediation
+	suite.tx.Find(&remediations)
+	suite.Equal(1, len(remediations))
+	suite.Equal(int64(5), remediations[0].ID)
+	suite.Equal(models.RemediationApplied, remediations[0].Status)
+	suite.ElementsMatch([]string{"ABCDEF"}, remediations[0].Checks)
+
+	var waivers []entities.Waiver
+	suite.tx.Find(&waivers)
+	suite.Equal(1, len(waivers))
+	suite.Equal(int64(2), waivers[0].ID)
+	suite.Equal("cluster1", waivers[0].Target)
+
+	var auditLogEntries []entities.AuditLogEntry
+	suite.tx.Find(&auditLogEntries)
+	suite.Equal(1, len(auditLogEntries))
+	suite.Equal(int64(7), auditLogEntries[0].ID)
+	suite.Equal(models.AuditWaiverCreated, auditLogEntries[0].Action)
+
+	suite.tx.Model(&datapipeline.DataCollectedEvent{}).Count(&count)
+	suite.Equal(int64(1), count)
+
+	// the new entries get IDs past the restored ones
+	waiver := entities.Waiver{CheckID: "123456", Scope: models.WaiverScopeCluster, Target: "cluster1", Owner: "admin"}
+	suite.NoError(suite.tx.Create(&waiver).Error)
+	suite.Greater(waiver.ID, int64(2))
+}
+
+func (suite *BackupTestSuite) TestBackupWithEvents() {
+	suite.tx.Create(&datapipeline.DataCollectedEvent{ID: 1, AgentID: "agent1", DiscoveryType: "host_discovery", Payload: []byte("{}")})
+
+	var archive bytes.Buffer
+	manifest, err := createBackup(suite.tx, &archive, true)
+	suite.NoError(err)
+	suite.Contains(manifest.Files, "events.json")
+
+	suite.tx.Where("1 = 1").Delete(&datapipeline.DataCollectedEvent{})
+
+	_, err = restoreBackup(suite.tx, &archive, migrations.Migrations)
+	suite.NoError(err)
+
+	var events []datapipeline.DataCollectedEvent
+	suite.tx.Find(&events)
+	suite.Equal(1, len(events))
+	suite.Equal("agent1", events[0].AgentID)
+}
+
+func (suite *BackupTestSuite) TestBackupDatasetsCoverDBTables() {
+	tableName := func(model interface{}) string {
+		stmt := &gorm.Statement{DB: suite.tx}
+		suite.NoError(stmt.Parse(model))
+		return stmt.Schema.Table
+	}
+
+	covered := append([]string{}, backupExcludedTables...)
+	for _, dataset := range backupDatasets() {
+		covered = append(covered, tableName(dataset.newModel()))
+	}
+
+	// the tables added to the schema are either backed up or explicitly excluded
+	for _, model := range web.DBTables {
+		suite.Contains(covered, tableName(model))
+	}
+}
+
+func buildTestArchive(t *testing.T, manifest *backupManifest, files map[string][]byte) *bytes.Buffer {
+	var archive bytes.Buffer
+	gw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gw)
+
+	manifestContent, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	assert.NoError(t, writeTarFile(tw, backupManifestFile, manifestContent))
+
+	for name, content := range files {
+		assert.NoError(t, writeTarFile(tw, name, content))
+	}
+
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+
+	return &archive
+}
+
+func validTestBackup() (*backupManifest, map[string][]byte) {
+	manifest := &backupManifest{
+		FormatVersion: "1.0",
+		SchemaVersion: 1,
+		CreatedAt:     time.Now(),
+		Files:         make(map[string]string),
+	}
+	files := make(map[string][]byte)
+
+	for _, dataset := range backupDatasets() {
+		if dataset.name == backupEventsName {
+			continue
+		}
+		fileName := backupFileName(dataset.name)
+		files[fileName] = []byte("[]")
+		manifest.Files[fileName] = checksum(files[fileName])
+	}
+
+	return manifest, files
+}
+
+func TestReadBackup(t *testing.T) {
+	manifest, files := validTestBackup()
+	files["future_dataset.json"] = []byte("[]")
+	manifest.FormatVersion = "1.3"
+
+	readManifest, readFiles, err := readBackup(buildTestArchive(t, manifest, files), migrations.Migrations)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3", readManifest.FormatVersion)
+	assert.Equal(t, []byte("[]"), readFiles["tags.json"])
+}
+
+func TestReadBackupChecksumMismatch(t *testing.T) {
+	manifest, files := validTestBackup()
+	files["tags.json"] = []byte(`[{"Value":"tampered"}]`)
+
+	_, _, err := readBackup(buildTestArchive(t, manifest, files), migrations.Migrations)
+
+	assert.EqualError(t, err, "checksum mismatch for file tags.json")
+}
+
+func TestReadBackupMissingDataset(t *testing.T) {
+	manifest, files := validTestBackup()
+	delete(files, "tags.json")
+	delete(manifest.Files, "tags.json")
+
+	_, _, err := readBackup(buildTestArchive(t, manifest, files), migrations.Migrations)
+
+	assert.EqualError(t, err, "dataset tags is missing")
+}
+
+func TestReadBackupOlderMinorVersion(t *testing.T) {
+	manifest, files := validTestBackup()
+	delete(files, "custom_checks.json")
+	delete(manifest.Files, "custom_checks.json")
+
+	_, _, err := readBackup(buildTestArchive(t, manifest, files), migrations.Migrations)
+	assert.NoError(t, err)
+
+	manifest.FormatVersion = "1.1"
+
+	_, _, err = readBackup(buildTestArchive(t, manifest, files), migrations.Migrations)
+	assert.EqualError(t, err, "dataset custom_checks is missing")
+}
+
+func TestReadBackupUnsupportedFormat(t *testing.T) {
+	manifest, files := validTestBackup()
+	manifest.FormatVersion = "2.0"
+
+	_, _, err := readBackup(buildTestArchive(t, manifest, files), migrations.Migrations)
+
+	assert.EqualError(t, err, "unsupported backup format version 2.0, expected 1.x")
+}
+
+func TestReadBackupSchemaTooNew(t *testing.T) {
+	manifest, files := validTestBackup()
+	manifest.SchemaVersion = 1000
+
+	_, _, err := readBackup(buildTestArchive(t, manifest, files), migrations.Migrations)
+
+	assert.ErrorIs(t, err, db.ErrSchemaTooNew)
+}
@@ -42,7 +42,11 @@ func NewCtlCmd() *cobra.Command {
 	addPruneEventsCmd(ctlCmd)
 	addPruneChecksResultsCmd(ctlCmd)
 	addDBResetCmd(ctlCmd)
+	addDBCmd(ctlCmd)
 	addDumpScenarioCmd(ctlCmd)
+	addBackupCmd(ctlCmd)
+	addRestoreCmd(ctlCmd)
+	addRemoteCmds(ctlCmd)
 
 	return ctlCmd
 }
@@ -124,7 +128,11 @@ func addDumpScenarioCmd(ctlCmd *cobra.Command) {
 }
 
 func initDB() *gorm.DB {
-	dbConfig := dbCmd.LoadConfig()
+	dbConfig, err := dbCmd.LoadConfig()
+	if err != nil {
+		log.Fatal("Error while loading the database configuration: ", err)
+	}
+
 	db, err := db.InitDB(dbConfig)
 	if err != nil {
 		log.Fatal("Error while initializing the database: ", err)
@@ -157,15 +165,15 @@ func pruneChecksResults(db *gorm.DB, olderThan time.Duration) {
 	log.Infof("Checks results older than %d days pruned.", olderThan)
 }
 
-func dbReset(db *gorm.DB, tables []interface{}) {
+func dbReset(gormDB *gorm.DB, tables []interface{}) {
 	log.Info("Resetting database...")
-	err := db.Transaction(func(tx *gorm.DB) error {
+	err := gormDB.Transaction(func(tx *gorm.DB) error {
 		for _, t := range tables {
-			stmt := &gorm.Statement{DB: db}
+			stmt := &gorm.Statement{DB: gormDB}
 			stmt.Parse(t)
 			tableName := stmt.Schema.Table
 
-			err := tx.Exec(fmt.Sprintf("TRUNCATE TABLE %s", tableName)).Error
+			err := db.TruncateTable(tx, tableName)
 			if err != nil {
 				log.Fatalf("Error while truncating table %s: %s", tableName, err)
 			}
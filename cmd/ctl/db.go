@@ -0,0 +1,137 @@
+package ctl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/web/migrations"
+)
+
+func addDBCmd(ctlCmd *cobra.Command) {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Database schema management commands",
+	}
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply all the pending database migrations",
+		Run: func(*cobra.Command, []string) {
+			db := initDB()
+
+			migrateDB(db, migrations.Migrations)
+		},
+	}
+
+	var steps int
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Revert the last applied database migrations",
+		Run: func(*cobra.Command, []string) {
+			db := initDB()
+			steps := viper.GetInt("steps")
+
+			rollbackDB(db, migrations.Migrations, steps)
+		},
+	}
+
+	rollbackCmd.Flags().IntVar(&steps, "steps", 1, "The number of migrations to revert.")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the database migrations",
+		Run: func(*cobra.Command, []string) {
+			db := initDB()
+
+			printDBStatus(db, migrations.Migrations, os.Stdout)
+		},
+	}
+
+	dbCmd.AddCommand(migrateCmd)
+	dbCmd.AddCommand(rollbackCmd)
+	dbCmd.AddCommand(statusCmd)
+
+	ctlCmd.AddCommand(dbCmd)
+}
+
+func newMigrator(gormDB *gorm.DB, dbMigrations []*db.Migration) *db.Migrator {
+	migrator, err := db.NewMigrator(gormDB, dbMigrations)
+	if err != nil {
+		log.Fatal("Error while loading the database migrations: ", err)
+	}
+
+	return migrator
+}
+
+func migrateDB(gormDB *gorm.DB, dbMigrations []*db.Migration) {
+	migrator := newMigrator(gormDB, dbMigrations)
+
+	migrated, err := migrator.Migrate()
+	if err != nil {
+		log.Fatal("Error while migrating the database: ", err)
+	}
+
+	if len(migrated) == 0 {
+		log.Info("Database schema is up to date.")
+		return
+	}
+
+	log.Infof("%d migrations applied. Database schema is at version %d.", len(migrated), migrated[len(migrated)-1].Version)
+}
+
+func rollbackDB(gormDB *gorm.DB, dbMigrations []*db.Migration, steps int) {
+	migrator := newMigrator(gormDB, dbMigrations)
+
+	rolledBack, err := migrator.Rollback(steps)
+	if err != nil {
+		log.Fatal("Error while rolling back the database: ", err)
+	}
+
+	version, err := migrator.CurrentVersion()
+	if err != nil {
+		log.Fatal("Error while reading the database schema version: ", err)
+	}
+
+	log.Infof("%d migrations reverted. Database schema is at version %d.", len(rolledBack), version)
+}
+
+func printDBStatus(gormDB *gorm.DB, dbMigrations []*db.Migration, out io.Writer) {
+	migrator := newMigrator(gormDB, dbMigrations)
+
+	status, err := migrator.Status()
+	if err != nil {
+		log.Fatal("Error while reading the database migrations status: ", err)
+	}
+
+	version, err := migrator.CurrentVersion()
+	if err != nil {
+		log.Fatal("Error while reading the database schema version: ", err)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tDESCRIPTION\tSTATUS\tAPPLIED AT")
+	for _, s := range status {
+		state := "pending"
+		appliedAt := "-"
+		if s.Applied {
+			state = "applied"
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if s.Version > migrator.LatestVersion() {
+			state = "unknown"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Description, state, appliedAt)
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\nCurrent version: %d, latest supported version: %d\n", version, migrator.LatestVersion())
+}
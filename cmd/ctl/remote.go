@@ -0,0 +1,599 @@
+package ctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trento-project/trento/api"
+	"github.com/trento-project/trento/internal/secrets"
+	"github.com/trento-project/trento/web/models"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+// addRemoteCmds adds the commands querying the landscape through the web server API,
+// so they don't need the database credentials
+func addRemoteCmds(ctlCmd *cobra.Command) {
+	addHostsCmd(ctlCmd)
+	addClustersCmd(ctlCmd)
+	addSAPSystemsCmd(ctlCmd, "sapsystems", "SAP systems", models.SAPSystemTypeApplication)
+	addSAPSystemsCmd(ctlCmd, "databases", "HANA databases", models.SAPSystemTypeDatabase)
+	addChecksCmd(ctlCmd)
+}
+
+// addApiFlags adds the flags of the web server API connection and of the output format
+func addApiFlags(cmd *cobra.Command) {
+	var apiHost string
+	var apiPort int
+	var enableApiTLS bool
+	var apiCA string
+	var apiCert string
+	var apiKey string
+	var apiToken string
+	var apiRetries uint
+	var output string
+
+	cmd.PersistentFlags().StringVar(&apiHost, "api-host", "127.0.0.1", "Trento web server API host")
+	cmd.PersistentFlags().IntVar(&apiPort, "api-port", 8080, "Trento web server API port")
+	cmd.PersistentFlags().BoolVar(&enableApiTLS, "enable-api-tls", false, "Connect to the Trento web server API over TLS, verifying its certificate")
+	cmd.PersistentFlags().StringVar(&apiCA, "api-ca", "", "Certificate Authority verifying the web server certificate, the system ones are used when empty")
+	cmd.PersistentFlags().StringVar(&apiCert, "api-cert", "", "Client certificate authenticating to the web server API")
+	cmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "Key of the client certificate, either a file path or a secret reference (env:, file: or vault:)")
+	cmd.PersistentFlags().StringVar(&apiToken, "api-token", "", "Token authenticating to the web server API, either the token or a secret reference (env:, file: or vault:)")
+	cmd.PersistentFlags().UintVar(&apiRetries, "api-retries", 3, "How many times the requests are retried when the web server API is unavailable")
+	cmd.PersistentFlags().StringVarP(&output, "output", "o", outputTable, fmt.Sprintf("Output format, one of: %s", strings.Join(outputFormats, ", ")))
+}
+
+// addPageFlags adds the pagination flags of the list commands
+func addPageFlags(cmd *cobra.Command) {
+	var page int
+	var perPage int
+
+	cmd.Flags().IntVar(&page, "page", 1, "Page number, used along with --per-page")
+	cmd.Flags().IntVar(&perPage, "per-page", 0, "Items per page, all the items are listed when 0")
+}
+
+// addTagFlag adds the tag filter of the list commands, --tags is accepted as an alias of --tag
+func addTagFlag(cmd *cobra.Command, description string) {
+	var tags []string
+
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, fmt.Sprintf("Only list the %s with any of these tags", description))
+	cmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "tags" {
+			name = "tag"
+		}
+		return pflag.NormalizedName(name)
+	})
+}
+
+func loadApiConfig() (*api.Config, error) {
+	enableApiTLS := viper.GetBool("enable-api-tls")
+	apiCA := viper.GetString("api-ca")
+	apiCert := viper.GetString("api-cert")
+
+	apiKey, err := secrets.Get("api-key")
+	if err != nil {
+		return nil, err
+	}
+
+	apiToken, err := secrets.Get("api-token")
+	if err != nil {
+		return nil, err
+	}
+
+	if (apiCert == "") != (apiKey == "") {
+		return nil, fmt.Errorf("you must provide both the client certificate and its key")
+	}
+	if (apiCert != "" || apiCA != "") && !enableApiTLS {
+		return nil, fmt.Errorf("the certificates are only used with the API TLS enabled")
+	}
+
+	return &api.Config{
+		Host:      viper.GetString("api-host"),
+		Port:      viper.GetInt("api-port"),
+		EnableTLS: enableApiTLS,
+		CA:        apiCA,
+		Cert:      apiCert,
+		Key:       apiKey,
+		Token:     apiToken,
+		Retries:   viper.GetUint("api-retries"),
+	}, nil
+}
+
+func loadOutputFormat() (string, error) {
+	output := viper.GetString("output")
+	for _, format := range outputFormats {
+		if output == format {
+			return output, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown output format %s, use one of: %s", output, strings.Join(outputFormats, ", "))
+}
+
+func loadPage() *api.Page {
+	perPage := viper.GetInt("per-page")
+	if perPage <= 0 {
+		return nil
+	}
+
+	return &api.Page{Number: viper.GetInt("page"), Size: perPage}
+}
+
+// runRemote runs a command against the web server API, it is interrupted with Ctrl+C
+func runRemote(run func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error) {
+	format, err := loadOutputFormat()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config, err := loadApiConfig()
+	if err != nil {
+		log.Fatal("Error while loading the API configuration: ", err)
+	}
+
+	client, err := api.NewTrentoApiServiceWithConfig(config)
+	if err != nil {
+		log.Fatal("Error while creating the API client: ", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, client, format, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// printOutput writes the data in the requested format, the table one is written by printTable
+func printOutput(out io.Writer, format string, data interface{}, printTable func(w io.Writer)) error {
+	switch format {
+	case outputJSON:
+		content, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(content))
+		return err
+	case outputYAML:
+		// the data goes through JSON first, so the YAML keys are the same as the API ones
+		content, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(content, &generic); err != nil {
+			return err
+		}
+		return yaml.NewEncoder(out).Encode(generic)
+	default:
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		printTable(w)
+		return w.Flush()
+	}
+}
+
+// notFoundError replaces the API error with a friendlier one when the resource does not exist
+func notFoundError(err error, resource string, id string) error {
+	if api.IsNotFound(err) {
+		return fmt.Errorf("%s %s not found", resource, id)
+	}
+
+	return fmt.Errorf("error while getting the %s %s: %w", resource, id, err)
+}
+
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	return strings.Join(values, ",")
+}
+
+func addHostsCmd(ctlCmd *cobra.Command) {
+	hostsCmd := &cobra.Command{
+		Use:   "hosts",
+		Short: "Query the hosts through the web server API",
+	}
+	addApiFlags(hostsCmd)
+
+	var sids, health []string
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the hosts",
+		Args:  cobra.NoArgs,
+		Run: func(*cobra.Command, []string) {
+			filter := &api.HostsFilter{
+				SIDs:   viper.GetStringSlice("sids"),
+				Tags:   viper.GetStringSlice("tag"),
+				Health: viper.GetStringSlice("health"),
+			}
+			page := loadPage()
+
+			runRemote(func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error {
+				return listHosts(ctx, client, filter, page, format, out)
+			})
+		},
+	}
+
+	listCmd.Flags().StringSliceVar(&sids, "sids", nil, "Only list the hosts running any of these SAP systems")
+	addTagFlag(listCmd, "hosts")
+	listCmd.Flags().StringSliceVar(&health, "health", nil, "Only list the hosts with any of these health states: passing, warning, critical")
+	addPageFlags(listCmd)
+
+	showCmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a host",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runRemote(func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error {
+				return showHost(ctx, client, args[0], format, out)
+			})
+		},
+	}
+
+	hostsCmd.AddCommand(listCmd)
+	hostsCmd.AddCommand(showCmd)
+
+	ctlCmd.AddCommand(hostsCmd)
+}
+
+func listHosts(ctx context.Context, client api.TrentoApiService, filter *api.HostsFilter, page *api.Page, format string, out io.Writer) error {
+	hosts, err := client.ListHosts(ctx, filter, page)
+	if err != nil {
+		return fmt.Errorf("error while listing the hosts: %w", err)
+	}
+
+	return printOutput(out, format, hosts, func(w io.Writer) {
+		printHostsTable(w, hosts)
+	})
+}
+
+func showHost(ctx context.Context, client api.TrentoApiService, id string, format string, out io.Writer) error {
+	host, err := client.GetHost(ctx, id)
+	if err != nil {
+		return notFoundError(err, "host", id)
+	}
+
+	return printOutput(out, format, host, func(w io.Writer) {
+		printHostsTable(w, models.HostList{host})
+	})
+}
+
+func printHostsTable(w io.Writer, hosts models.HostList) {
+	fmt.Fprintln(w, "ID\tNAME\tHEALTH\tADDRESSES\tCLUSTER\tSIDS\tAGENT VERSION\tTAGS")
+	for _, h := range hosts {
+		var sids []string
+		for _, s := range h.SAPSystems {
+			sids = append(sids, s.SID)
+		}
+
+		cluster := h.ClusterName
+		if cluster == "" {
+			cluster = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			h.ID, h.Name, h.Health, joinOrDash(h.IPAddresses), cluster, joinOrDash(sids), h.AgentVersion, joinOrDash(h.Tags))
+	}
+}
+
+func addClustersCmd(ctlCmd *cobra.Command) {
+	clustersCmd := &cobra.Command{
+		Use:   "clusters",
+		Short: "Query the clusters through the web server API",
+	}
+	addApiFlags(clustersCmd)
+
+	var names, clusterTypes, sids, health []string
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the clusters",
+		Args:  cobra.NoArgs,
+		Run: func(*cobra.Command, []string) {
+			filter := &api.ClustersFilter{
+				Names:        viper.GetStringSlice("name"),
+				ClusterTypes: viper.GetStringSlice("cluster-type"),
+				SIDs:         viper.GetStringSlice("sids"),
+				Tags:         viper.GetStringSlice("tag"),
+				Health:       viper.GetStringSlice("health"),
+			}
+			page := loadPage()
+
+			runRemote(func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error {
+				return listClusters(ctx, client, filter, page, format, out)
+			})
+		},
+	}
+
+	listCmd.Flags().StringSliceVar(&names, "name", nil, "Only list the clusters with any of these names")
+	listCmd.Flags().StringSliceVar(&clusterTypes, "cluster-type", nil, "Only list the clusters of any of these types")
+	listCmd.Flags().StringSliceVar(&sids, "sids", nil, "Only list the clusters running any of these SAP systems")
+	addTagFlag(listCmd, "clusters")
+	listCmd.Flags().StringSliceVar(&health, "health", nil, "Only list the clusters with any of these health states: passing, warning, critical")
+	addPageFlags(listCmd)
+
+	showCmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a cluster, with its nodes when it is a HANA one",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runRemote(func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error {
+				return showCluster(ctx, client, args[0], format, out)
+			})
+		},
+	}
+
+	clustersCmd.AddCommand(listCmd)
+	clustersCmd.AddCommand(showCmd)
+
+	ctlCmd.AddCommand(clustersCmd)
+}
+
+func listClusters(ctx context.Context, client api.TrentoApiService, filter *api.ClustersFilter, page *api.Page, format string, out io.Writer) error {
+	clusters, err := client.ListClusters(ctx, filter, page)
+	if err != nil {
+		return fmt.Errorf("error while listing the clusters: %w", err)
+	}
+
+	return printOutput(out, format, clusters, func(w io.Writer) {
+		printClustersTable(w, clusters)
+	})
+}
+
+func showCluster(ctx context.Context, client api.TrentoApiService, id string, format string, out io.Writer) error {
+	cluster, err := client.GetCluster(ctx, id)
+	if err != nil {
+		return notFoundError(err, "cluster", id)
+	}
+
+	return printOutput(out, format, cluster, func(w io.Writer) {
+		printClustersTable(w, models.ClusterList{cluster})
+
+		details, ok := cluster.Details.(*models.HANAClusterDetails)
+		if !ok || len(details.Nodes) == 0 {
+			return
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "NODE\tSITE\tHANA STATUS\tHEALTH\tADDRESSES\tVIRTUAL IPS")
+		for _, n := range details.Nodes {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				n.Name, n.Site, n.HANAStatus, n.Health, joinOrDash(n.IPAddresses), joinOrDash(n.VirtualIPs))
+		}
+	})
+}
+
+func printClustersTable(w io.Writer, clusters models.ClusterList) {
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tSID\tHOSTS\tRESOURCES\tHEALTH\tTAGS")
+	for _, c := range clusters {
+		sid := c.SID
+		if sid == "" {
+			sid = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+			c.ID, c.Name, c.ClusterType, sid, c.HostsNumber, c.ResourcesNumber, c.Health, joinOrDash(c.Tags))
+	}
+}
+
+// addSAPSystemsCmd adds the commands of the SAP systems of the given type, the application ones or the databases
+func addSAPSystemsCmd(ctlCmd *cobra.Command, use string, description string, systemType string) {
+	sapSystemsCmd := &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("Query the %s through the web server API", description),
+	}
+	addApiFlags(sapSystemsCmd)
+
+	var sids []string
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprintf("List the %s", description),
+		Args:  cobra.NoArgs,
+		Run: func(*cobra.Command, []string) {
+			filter := &api.SAPSystemsFilter{
+				SIDs: viper.GetStringSlice("sids"),
+				Tags: viper.GetStringSlice("tag"),
+			}
+			page := loadPage()
+
+			runRemote(func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error {
+				return listSAPSystems(ctx, client, systemType, filter, page, format, out)
+			})
+		},
+	}
+
+	listCmd.Flags().StringSliceVar(&sids, "sids", nil, fmt.Sprintf("Only list the %s with any of these SIDs", description))
+	addTagFlag(listCmd, description)
+	addPageFlags(listCmd)
+
+	showCmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a SAP system with its instances",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runRemote(func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error {
+				return showSAPSystem(ctx, client, systemType, args[0], format, out)
+			})
+		},
+	}
+
+	sapSystemsCmd.AddCommand(listCmd)
+	sapSystemsCmd.AddCommand(showCmd)
+
+	ctlCmd.AddCommand(sapSystemsCmd)
+}
+
+func listSAPSystems(ctx context.Context, client api.TrentoApiService, systemType string, filter *api.SAPSystemsFilter, page *api.Page, format string, out io.Writer) error {
+	var sapSystems models.SAPSystemList
+	var err error
+
+	if systemType == models.SAPSystemTypeDatabase {
+		sapSystems, err = client.ListDatabases(ctx, filter, page)
+	} else {
+		sapSystems, err = client.ListSAPSystems(ctx, filter, page)
+	}
+	if err != nil {
+		return fmt.Errorf("error while listing the SAP systems: %w", err)
+	}
+
+	return printOutput(out, format, sapSystems, func(w io.Writer) {
+		printSAPSystemsTable(w, sapSystems)
+	})
+}
+
+func showSAPSystem(ctx context.Context, client api.TrentoApiService, systemType string, id string, format string, out io.Writer) error {
+	var sapSystem *models.SAPSystem
+	var err error
+
+	if systemType == models.SAPSystemTypeDatabase {
+		sapSystem, err = client.GetDatabase(ctx, id)
+	} else {
+		sapSystem, err = client.GetSAPSystem(ctx, id)
+	}
+	if err != nil {
+		return notFoundError(err, "SAP system", id)
+	}
+
+	return printOutput(out, format, sapSystem, func(w io.Writer) {
+		printSAPSystemsTable(w, models.SAPSystemList{sapSystem})
+
+		instances := sapSystem.GetAllInstances()
+		if len(instances) == 0 {
+			return
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "SID\tINSTANCE\tTYPE\tFEATURES\tHOSTNAME\tCLUSTER")
+		for _, i := range instances {
+			cluster := i.ClusterName
+			if cluster == "" {
+				cluster = "-"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", i.SID, i.InstanceNumber, i.Type, i.Features, i.Hostname, cluster)
+		}
+	})
+}
+
+func printSAPSystemsTable(w io.Writer, sapSystems models.SAPSystemList) {
+	fmt.Fprintln(w, "ID\tSID\tTYPE\tINSTANCES\tTAGS")
+	for _, s := range sapSystems {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", s.ID, s.SID, s.Type, len(s.Instances), joinOrDash(s.Tags))
+	}
+}
+
+func addChecksCmd(ctlCmd *cobra.Command) {
+	checksCmd := &cobra.Command{
+		Use:   "checks",
+		Short: "Query and select the checks through the web server API",
+	}
+	addApiFlags(checksCmd)
+
+	var targetType string
+
+	resultsCmd := &cobra.Command{
+		Use:   "results <target id>",
+		Short: "Show the last checks results of a cluster, a host or a SAP system",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			targetType := viper.GetString("target-type")
+
+			runRemote(func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error {
+				return showChecksResults(ctx, client, targetType, args[0], format, out)
+			})
+		},
+	}
+
+	resultsCmd.Flags().StringVar(&targetType, "target-type", models.CheckTargetCluster,
+		fmt.Sprintf("Type of the checks target, one of: %s", strings.Join(models.CheckTargetTypes, ", ")))
+
+	selectCmd := &cobra.Command{
+		Use:   "select <target id> <check id>...",
+		Short: "Replace the selected checks of a checks target, keeping its connection settings",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(_ *cobra.Command, args []string) {
+			runRemote(func(ctx context.Context, client api.TrentoApiService, format string, out io.Writer) error {
+				return selectChecks(ctx, client, args[0], args[1:], format, out)
+			})
+		},
+	}
+
+	checksCmd.AddCommand(resultsCmd)
+	checksCmd.AddCommand(selectCmd)
+
+	ctlCmd.AddCommand(checksCmd)
+}
+
+func showChecksResults(ctx context.Context, client api.TrentoApiService, targetType string, id string, format string, out io.Writer) error {
+	results, err := client.GetChecksResults(ctx, targetType, id)
+	if err != nil {
+		return notFoundError(err, "checks results of", id)
+	}
+
+	return printOutput(out, format, results, func(w io.Writer) {
+		var hosts []string
+		for host := range results.Hosts {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+
+		fmt.Fprintf(w, "CHECK\tGROUP\t%s\n", strings.Join(hosts, "\t"))
+		for _, c := range results.Checks {
+			row := []string{c.ID, c.Group}
+			for _, host := range hosts {
+				result := "-"
+				if check, ok := c.Hosts[host]; ok {
+					result = check.Result
+				}
+				row = append(row, result)
+			}
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+	})
+}
+
+func selectChecks(ctx context.Context, client api.TrentoApiService, id string, checks []string, format string, out io.Writer) error {
+	settings, err := client.GetChecksSettings(ctx, id)
+	if err != nil {
+		return notFoundError(err, "checks settings of", id)
+	}
+
+	settings.SelectedChecks = checks
+	if settings.ConnectionSettings == nil {
+		settings.ConnectionSettings = map[string]string{}
+	}
+
+	if err := client.UpdateChecksSettings(ctx, id, settings); err != nil {
+		return fmt.Errorf("error while selecting the checks of %s: %w", id, err)
+	}
+
+	return printOutput(out, format, settings, func(w io.Writer) {
+		fmt.Fprintln(w, "SELECTED CHECKS")
+		for _, check := range settings.SelectedChecks {
+			fmt.Fprintln(w, check)
+		}
+	})
+}
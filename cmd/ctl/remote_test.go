@@ -0,0 +1,117 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/trento-project/trento/api"
+	"github.com/trento-project/trento/api/mocks"
+	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestListHostsOutput(t *testing.T) {
+	filter := &api.HostsFilter{Tags: []string{"prod"}}
+	client := new(mocks.TrentoApiService)
+	client.On("ListHosts", mock.Anything, filter, (*api.Page)(nil)).Return(models.HostList{
+		{
+			ID:           "host1",
+			Name:         "vmhana01",
+			Health:       models.CheckPassing,
+			IPAddresses:  []string{"10.0.0.1"},
+			ClusterName:  "hana_cluster",
+			SAPSystems:   []*models.SAPSystem{{SID: "PRD"}},
+			AgentVersion: "1.0.0",
+			Tags:         []string{"prod"},
+		},
+		{ID: "host2", Name: "vmhana02", Health: models.CheckCritical, AgentVersion: "1.0.0"},
+	}, nil)
+
+	var out bytes.Buffer
+	err := listHosts(context.Background(), client, filter, nil, outputTable, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"ID     NAME      HEALTH    ADDRESSES  CLUSTER       SIDS  AGENT VERSION  TAGS\n"+
+		"host1  vmhana01  passing   10.0.0.1   hana_cluster  PRD   1.0.0          prod\n"+
+		"host2  vmhana02  critical  -          -             -     1.0.0          -\n", out.String())
+
+	out.Reset()
+	err = listHosts(context.Background(), client, filter, nil, outputYAML, &out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "- agent_version: 1.0.0\n")
+	assert.Contains(t, out.String(), "  ip_addresses:\n    - 10.0.0.1\n")
+
+	out.Reset()
+	err = listHosts(context.Background(), client, filter, nil, outputJSON, &out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `"cluster_name": "hana_cluster"`)
+}
+
+func TestShowClusterNotFound(t *testing.T) {
+	client := new(mocks.TrentoApiService)
+	client.On("GetCluster", mock.Anything, "unknown").Return(nil, &api.StatusError{StatusCode: 404})
+
+	var out bytes.Buffer
+	err := showCluster(context.Background(), client, "unknown", outputTable, &out)
+	assert.EqualError(t, err, "cluster unknown not found")
+	assert.Empty(t, out.String())
+}
+
+func TestShowChecksResultsTable(t *testing.T) {
+	client := new(mocks.TrentoApiService)
+	client.On("GetChecksResults", mock.Anything, models.CheckTargetCluster, "cluster1").Return(&models.ChecksResultAsList{
+		Hosts: map[string]*models.HostState{"node2": {Reachable: true}, "node1": {Reachable: true}},
+		Checks: []*models.ChecksByHost{
+			{ID: "1.1.1", Group: "Corosync", Hosts: map[string]*models.Check{
+				"node1": {Result: models.CheckPassing},
+				"node2": {Result: models.CheckCritical},
+			}},
+			{ID: "1.2.1", Group: "Pacemaker", Hosts: map[string]*models.Check{
+				"node1": {Result: models.CheckWarning},
+			}},
+		},
+	}, nil)
+
+	var out bytes.Buffer
+	err := showChecksResults(context.Background(), client, models.CheckTargetCluster, "cluster1", outputTable, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"CHECK  GROUP      node1    node2\n"+
+		"1.1.1  Corosync   passing  critical\n"+
+		"1.2.1  Pacemaker  warning  -\n", out.String())
+}
+
+func TestSelectChecks(t *testing.T) {
+	client := new(mocks.TrentoApiService)
+	client.On("GetChecksSettings", mock.Anything, "cluster1").Return(&webApi.JSONChecksSettings{
+		SelectedChecks:     []string{"1.1.1"},
+		ConnectionSettings: map[string]string{"node1": "admin"},
+	}, nil)
+	client.On("UpdateChecksSettings", mock.Anything, "cluster1", &webApi.JSONChecksSettings{
+		SelectedChecks:     []string{"1.1.2", "1.2.1"},
+		ConnectionSettings: map[string]string{"node1": "admin"},
+	}).Return(nil)
+
+	var out bytes.Buffer
+	err := selectChecks(context.Background(), client, "cluster1", []string{"1.1.2", "1.2.1"}, outputTable, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECTED CHECKS\n1.1.2\n1.2.1\n", out.String())
+	client.AssertExpectations(t)
+}
+
+func TestTagFlag(t *testing.T) {
+	ctlCmd := NewCtlCmd()
+	listCmd, _, err := ctlCmd.Find([]string{"sapsystems", "list"})
+	assert.NoError(t, err)
+
+	err = listCmd.ParseFlags([]string{"--tag", "prod", "--tags", "dc1,dc2"})
+	assert.NoError(t, err)
+
+	tags, err := listCmd.Flags().GetStringSlice("tag")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prod", "dc1", "dc2"}, tags)
+}
@@ -3,14 +3,35 @@ package db
 import (
 	"github.com/spf13/viper"
 	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/internal/secrets"
 )
 
-func LoadConfig() *db.Config {
+func LoadConfig() (*db.Config, error) {
+	password, err := secrets.Get("db-password")
+	if err != nil {
+		return nil, err
+	}
+
+	replicaDSN, err := secrets.Get("db-replica-dsn")
+	if err != nil {
+		return nil, err
+	}
+
 	return &db.Config{
+		Driver:   viper.GetString("db-driver"),
 		Host:     viper.GetString("db-host"),
 		Port:     viper.GetInt("db-port"),
 		User:     viper.GetString("db-user"),
-		Password: viper.GetString("db-password"),
+		Password: password,
 		DBName:   viper.GetString("db-name"),
-	}
+		Path:     viper.GetString("db-path"),
+
+		MaxOpenConns:     viper.GetInt("db-max-open-conns"),
+		MaxIdleConns:     viper.GetInt("db-max-idle-conns"),
+		ConnMaxLifetime:  viper.GetDuration("db-conn-max-lifetime"),
+		StatementTimeout: viper.GetDuration("db-statement-timeout"),
+		SSLMode:          viper.GetString("db-sslmode"),
+		SSLRootCert:      viper.GetString("db-sslrootcert"),
+		ReplicaDSN:       replicaDSN,
+	}, nil
 }
@@ -1,19 +1,44 @@
 package db
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
+	"github.com/trento-project/trento/internal/db"
 )
 
 func AddDBFlags(cmd *cobra.Command) {
+	var dbDriver string
 	var dbHost string
 	var dbPort int
 	var dbUser string
 	var dbPassword string
+	var dbPasswordFile string
 	var dbName string
+	var dbPath string
+	var dbMaxOpenConns int
+	var dbMaxIdleConns int
+	var dbConnMaxLifetime time.Duration
+	var dbStatementTimeout time.Duration
+	var dbSSLMode string
+	var dbSSLRootCert string
+	var dbReplicaDSN string
+	var dbReplicaDSNFile string
 
+	cmd.PersistentFlags().StringVar(&dbDriver, "db-driver", db.PostgresDriver, "The database driver, either postgres or sqlite")
 	cmd.PersistentFlags().StringVar(&dbHost, "db-host", "localhost", "The database host")
 	cmd.PersistentFlags().IntVar(&dbPort, "db-port", 5432, "The database port to connect to")
 	cmd.PersistentFlags().StringVar(&dbUser, "db-user", "postgres", "The database user")
-	cmd.PersistentFlags().StringVar(&dbPassword, "db-password", "postgres", "The database password")
+	cmd.PersistentFlags().StringVar(&dbPassword, "db-password", "postgres", "The database password, either a plain value or a secret reference (env:, file: or vault:)")
+	cmd.PersistentFlags().StringVar(&dbPasswordFile, "db-password-file", "", "The file containing the database password")
 	cmd.PersistentFlags().StringVar(&dbName, "db-name", "trento", "The database name that the application will use")
+	cmd.PersistentFlags().StringVar(&dbPath, "db-path", "/var/lib/trento/trento.db", "The database file used by the sqlite driver")
+	cmd.PersistentFlags().IntVar(&dbMaxOpenConns, "db-max-open-conns", 0, "The maximum number of open connections to the database, 0 means unlimited")
+	cmd.PersistentFlags().IntVar(&dbMaxIdleConns, "db-max-idle-conns", 2, "The maximum number of idle connections kept in the pool")
+	cmd.PersistentFlags().DurationVar(&dbConnMaxLifetime, "db-conn-max-lifetime", 0, "The maximum amount of time a connection may be reused, 0 means forever")
+	cmd.PersistentFlags().DurationVar(&dbStatementTimeout, "db-statement-timeout", 0, "Abort any statement taking more than the given time, 0 disables the timeout")
+	cmd.PersistentFlags().StringVar(&dbSSLMode, "db-sslmode", "disable", "The database SSL mode: disable, require, verify-ca or verify-full")
+	cmd.PersistentFlags().StringVar(&dbSSLRootCert, "db-sslrootcert", "", "The CA certificate used to verify the database server certificate")
+	cmd.PersistentFlags().StringVar(&dbReplicaDSN, "db-replica-dsn", "", "The connection string of an optional read-only replica, used by the read heavy queries")
+	cmd.PersistentFlags().StringVar(&dbReplicaDSNFile, "db-replica-dsn-file", "", "The file containing the connection string of the read-only replica")
 }
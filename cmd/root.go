@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/trento-project/trento/cmd/ctl"
 	"github.com/trento-project/trento/cmd/runner"
 	"github.com/trento-project/trento/cmd/web"
+	"github.com/trento-project/trento/internal/secrets"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -29,15 +31,24 @@ func Execute() {
 func init() {
 	var cfgFile string
 	var logLevel string
+	var vaultAddr string
+	var vaultToken string
+	var vaultTokenFile string
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.trento.yaml)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "then minimum severity (error, warn, info, debug) of logs to output")
+	rootCmd.PersistentFlags().StringVar(&vaultAddr, "vault-addr", "", "The address of the HashiCorp Vault server used to resolve the vault: secret references")
+	rootCmd.PersistentFlags().StringVar(&vaultToken, "vault-token", "", "The HashiCorp Vault token")
+	rootCmd.PersistentFlags().StringVar(&vaultTokenFile, "vault-token-file", "", "The file containing the HashiCorp Vault token")
 
 	// Make global flags available in the children commands
 	rootCmd.PersistentFlags().VisitAll(func(f *pflag.Flag) {
 		viper.BindPFlag(f.Name, f)
 	})
 
+	// secrets must never end up in the logs
+	log.AddHook(&secrets.RedactHook{})
+
 	rootCmd.AddCommand(web.NewWebCmd())
 	rootCmd.AddCommand(agent.NewAgentCmd())
 	rootCmd.AddCommand(runner.NewRunnerCmd())
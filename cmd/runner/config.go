@@ -1,17 +1,51 @@
 package runner
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/viper"
+	"github.com/trento-project/trento/internal/secrets"
 	"github.com/trento-project/trento/runner"
 )
 
-func LoadConfig() *runner.Config {
-	return &runner.Config{
-		ApiHost:       viper.GetString("api-host"),
-		ApiPort:       viper.GetInt("api-port"),
-		Interval:      time.Duration(viper.GetInt("interval")) * time.Minute,
-		AnsibleFolder: viper.GetString("ansible-folder"),
+func LoadConfig() (*runner.Config, error) {
+	enableApiTLS := viper.GetBool("enable-api-tls")
+	apiCA := viper.GetString("api-ca")
+	apiCert := viper.GetString("api-cert")
+
+	apiKey, err := secrets.Get("api-key")
+	if err != nil {
+		return nil, err
 	}
+
+	apiToken, err := secrets.Get("api-token")
+	if err != nil {
+		return nil, err
+	}
+
+	if (apiCert == "") != (apiKey == "") {
+		return nil, fmt.Errorf("you must provide both the runner client certificate and its key")
+	}
+	if (apiCert != "" || apiCA != "") && !enableApiTLS {
+		return nil, fmt.Errorf("the certificates are only used with the API TLS enabled")
+	}
+
+	return &runner.Config{
+		ApiHost:               viper.GetString("api-host"),
+		ApiPort:               viper.GetInt("api-port"),
+		Interval:              time.Duration(viper.GetInt("interval")) * time.Minute,
+		ExecutionPollInterval: time.Duration(viper.GetInt("execution-poll-interval")) * time.Second,
+		AnsibleFolder:         viper.GetString("ansible-folder"),
+		Concurrency:           viper.GetInt("concurrency"),
+		JobTimeout:            time.Duration(viper.GetInt("job-timeout")) * time.Minute,
+		RunnerID:              viper.GetString("runner-id"),
+		Tags:                  viper.GetStringSlice("tags"),
+		ClusterIDs:            viper.GetStringSlice("clusters"),
+		EnableApiTLS:          enableApiTLS,
+		ApiCA:                 apiCA,
+		ApiCert:               apiCert,
+		ApiKey:                apiKey,
+		ApiToken:              apiToken,
+	}, nil
 }
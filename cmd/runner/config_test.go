@@ -46,12 +46,24 @@ func (suite *RunnerCmdTestSuite) TearDownTest() {
 	suite.cmd.Execute()
 
 	expectedConfig := &runner.Config{
-		ApiHost:       "some-api-host",
-		ApiPort:       1337,
-		Interval:      1 * time.Minute,
-		AnsibleFolder: "path/to/ansible",
+		ApiHost:               "some-api-host",
+		ApiPort:               1337,
+		Interval:              1 * time.Minute,
+		ExecutionPollInterval: 10 * time.Second,
+		AnsibleFolder:         "path/to/ansible",
+		Concurrency:           8,
+		JobTimeout:            20 * time.Minute,
+		RunnerID:              "runner1",
+		Tags:                  []string{"dc1", "prod"},
+		ClusterIDs:            []string{"cluster1"},
+		EnableApiTLS:          true,
+		ApiCA:                 "some-ca",
+		ApiCert:               "some-cert",
+		ApiKey:                "some-key",
+		ApiToken:              "some-token",
 	}
-	config := LoadConfig()
+	config, err := LoadConfig()
+	suite.NoError(err)
 
 	suite.EqualValues(expectedConfig, config)
 }
@@ -62,7 +74,18 @@ func (suite *RunnerCmdTestSuite) TestConfigFromFlags() {
 		"--api-host=some-api-host",
 		"--api-port=1337",
 		"--interval=1",
+		"--execution-poll-interval=10",
 		"--ansible-folder=path/to/ansible",
+		"--concurrency=8",
+		"--job-timeout=20",
+		"--runner-id=runner1",
+		"--tags=dc1,prod",
+		"--clusters=cluster1",
+		"--enable-api-tls",
+		"--api-ca=some-ca",
+		"--api-cert=some-cert",
+		"--api-key=some-key",
+		"--api-token=some-token",
 	})
 }
 
@@ -70,7 +93,18 @@ func (suite *RunnerCmdTestSuite) TestConfigFromEnv() {
 	os.Setenv("TRENTO_API_HOST", "some-api-host")
 	os.Setenv("TRENTO_API_PORT", "1337")
 	os.Setenv("TRENTO_INTERVAL", "1")
+	os.Setenv("TRENTO_EXECUTION_POLL_INTERVAL", "10")
 	os.Setenv("TRENTO_ANSIBLE_FOLDER", "path/to/ansible")
+	os.Setenv("TRENTO_CONCURRENCY", "8")
+	os.Setenv("TRENTO_JOB_TIMEOUT", "20")
+	os.Setenv("TRENTO_RUNNER_ID", "runner1")
+	os.Setenv("TRENTO_TAGS", "dc1 prod")
+	os.Setenv("TRENTO_CLUSTERS", "cluster1")
+	os.Setenv("TRENTO_ENABLE_API_TLS", "true")
+	os.Setenv("TRENTO_API_CA", "some-ca")
+	os.Setenv("TRENTO_API_CERT", "some-cert")
+	os.Setenv("TRENTO_API_KEY", "some-key")
+	os.Setenv("TRENTO_API_TOKEN", "some-token")
 }
 
 func (suite *RunnerCmdTestSuite) TestConfigFromFile() {
@@ -19,7 +19,18 @@ func NewRunnerCmd() *cobra.Command {
 	var apiHost string
 	var apiPort int
 	var interval int
+	var executionPollInterval int
 	var ansibleFolder string
+	var concurrency int
+	var jobTimeout int
+	var runnerID string
+	var tags []string
+	var clusterIDs []string
+	var enableApiTLS bool
+	var apiCA string
+	var apiCert string
+	var apiKey string
+	var apiToken string
 
 	runnerCmd := &cobra.Command{
 		Use:   "runner",
@@ -41,8 +52,21 @@ func NewRunnerCmd() *cobra.Command {
 
 	startCmd.Flags().StringVar(&apiHost, "api-host", "0.0.0.0", "Trento web server API host")
 	startCmd.Flags().IntVar(&apiPort, "api-port", 8080, "Trento web server API port")
+	startCmd.Flags().BoolVar(&enableApiTLS, "enable-api-tls", false, "Connect to the Trento web server API over TLS, verifying its certificate")
+	startCmd.Flags().StringVar(&apiCA, "api-ca", "", "Certificate Authority verifying the web server certificate, the system ones are used when empty")
+	startCmd.Flags().StringVar(&apiCert, "api-cert", "", "Client certificate authenticating the runner to the web server API")
+	startCmd.Flags().StringVar(&apiKey, "api-key", "", "Key of the runner client certificate, either a file path or a secret reference (env:, file: or vault:)")
+	startCmd.Flags().StringVar(&apiToken, "api-token", "", "Token authenticating the runner to the web server API, either the token or a secret reference (env:, file: or vault:)")
 	startCmd.Flags().IntVarP(&interval, "interval", "i", 5, "Interval in minutes to run the checks")
+	startCmd.Flags().IntVar(&executionPollInterval, "execution-poll-interval", 5, "Interval in seconds to look for on-demand checks executions")
 	startCmd.Flags().StringVar(&ansibleFolder, "ansible-folder", "/tmp/trento", "Folder where the ansible file structure will be created")
+	startCmd.Flags().IntVar(&concurrency, "concurrency", runner.DefaultConcurrency, "Maximum number of clusters checked at the same time")
+	startCmd.Flags().IntVar(&jobTimeout, "job-timeout", 10, "Timeout in minutes of the checks execution of a cluster, 0 to disable it")
+
+	hostname, _ := os.Hostname()
+	startCmd.Flags().StringVar(&runnerID, "runner-id", hostname, "Unique id of the runner, the clusters are leased to each runner by this id")
+	startCmd.Flags().StringSliceVar(&tags, "tags", nil, "Only check the clusters with any of these tags")
+	startCmd.Flags().StringSliceVar(&clusterIDs, "clusters", nil, "Only check the clusters with these ids")
 
 	runnerCmd.AddCommand(startCmd)
 
@@ -55,7 +79,10 @@ func start(*cobra.Command, []string) {
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 
-	config := LoadConfig()
+	config, err := LoadConfig()
+	if err != nil {
+		log.Fatal("Failed to configure the runner: ", err)
+	}
 
 	runner, err := runner.NewRunner(config)
 	if err != nil {
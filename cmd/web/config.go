@@ -2,19 +2,25 @@ package web
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	dbCmd "github.com/trento-project/trento/cmd/db"
+	"github.com/trento-project/trento/internal/secrets"
 	"github.com/trento-project/trento/web"
 )
 
 func LoadConfig() (*web.Config, error) {
 	enablemTLS := viper.GetBool("enable-mtls")
 	cert := viper.GetString("cert")
-	key := viper.GetString("key")
 	ca := viper.GetString("ca")
 
+	key, err := secrets.Get("key")
+	if err != nil {
+		return nil, err
+	}
+
 	if enablemTLS {
 		var err error
 
@@ -32,6 +38,41 @@ func LoadConfig() (*web.Config, error) {
 		}
 	}
 
+	enableWebTLS := viper.GetBool("enable-web-tls")
+	runnerCA := viper.GetString("runner-ca")
+
+	if enableWebTLS && (cert == "" || key == "") {
+		return nil, fmt.Errorf("you must provide a server ssl certificate and its key to enable the web TLS")
+	}
+	if runnerCA != "" && !enableWebTLS {
+		return nil, fmt.Errorf("the runners client certificates are only verified with the web TLS enabled")
+	}
+
+	runnerToken, err := secrets.Get("runner-token")
+	if err != nil {
+		return nil, err
+	}
+
+	adminToken, err := secrets.Get("admin-token")
+	if err != nil {
+		return nil, err
+	}
+
+	checksEngine := viper.GetString("checks-engine")
+	if checksEngine != web.ChecksEngineAnsible && checksEngine != web.ChecksEngineNative && checksEngine != web.ChecksEngineAgent {
+		return nil, fmt.Errorf("unknown checks engine %s, it must be one of %s, %s or %s", checksEngine, web.ChecksEngineAnsible, web.ChecksEngineNative, web.ChecksEngineAgent)
+	}
+	// the collector takes the checks results posted by the agents as is, only the agents authenticated with
+	// their client certificate can be trusted to post them
+	if checksEngine == web.ChecksEngineAgent && !enablemTLS {
+		return nil, fmt.Errorf("the %s checks engine requires the mTLS authentication of the agents", web.ChecksEngineAgent)
+	}
+
+	dbConfig, err := dbCmd.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return &web.Config{
 		Host:          viper.GetString("host"),
 		Port:          viper.GetInt("port"),
@@ -40,6 +81,13 @@ func LoadConfig() (*web.Config, error) {
 		Cert:          cert,
 		Key:           key,
 		CA:            ca,
-		DBConfig:      dbCmd.LoadConfig(),
+		EnableWebTLS:  enableWebTLS,
+		RunnerToken:   runnerToken,
+		RunnerCA:      runnerCA,
+		AdminToken:    adminToken,
+		DBConfig:      dbConfig,
+
+		ChecksEngine:   checksEngine,
+		ChecksInterval: time.Duration(viper.GetInt("checks-interval")) * time.Minute,
 	}, nil
 }
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/web"
@@ -50,13 +53,30 @@ func (suite *WebCmdTestSuite) TearDownTest() {
 		Cert:          "some-cert",
 		Key:           "some-key",
 		CA:            "some-ca",
+		EnableWebTLS:  true,
+		RunnerToken:   "some-runner-token",
+		RunnerCA:      "some-runner-ca",
+		AdminToken:    "some-admin-token",
 		DBConfig: &db.Config{
+			Driver:   "postgres",
 			Host:     "some-db-host",
 			Port:     6543,
 			User:     "postgres",
 			Password: "password",
 			DBName:   "trento",
+			Path:     "/var/lib/trento/trento.db",
+
+			MaxOpenConns:     20,
+			MaxIdleConns:     5,
+			ConnMaxLifetime:  30 * time.Minute,
+			StatementTimeout: 10 * time.Second,
+			SSLMode:          "verify-full",
+			SSLRootCert:      "some-db-ca",
+			ReplicaDSN:       "host=some-replica-host",
 		},
+
+		ChecksEngine:   "native",
+		ChecksInterval: 10 * time.Minute,
 	}
 	config, err := LoadConfig()
 	suite.NoError(err)
@@ -74,11 +94,24 @@ func (suite *WebCmdTestSuite) TestConfigFromFlags() {
 		"--cert=some-cert",
 		"--key=some-key",
 		"--ca=some-ca",
+		"--enable-web-tls",
+		"--runner-token=some-runner-token",
+		"--runner-ca=some-runner-ca",
+		"--admin-token=some-admin-token",
 		"--db-host=some-db-host",
 		"--db-port=6543",
 		"--db-user=postgres",
 		"--db-password=password",
 		"--db-name=trento",
+		"--db-max-open-conns=20",
+		"--db-max-idle-conns=5",
+		"--db-conn-max-lifetime=30m",
+		"--db-statement-timeout=10s",
+		"--db-sslmode=verify-full",
+		"--db-sslrootcert=some-db-ca",
+		"--db-replica-dsn=host=some-replica-host",
+		"--checks-engine=native",
+		"--checks-interval=10",
 	})
 }
 
@@ -90,13 +123,56 @@ func (suite *WebCmdTestSuite) TestConfigFromEnv() {
 	os.Setenv("TRENTO_CERT", "some-cert")
 	os.Setenv("TRENTO_KEY", "some-key")
 	os.Setenv("TRENTO_CA", "some-ca")
+	os.Setenv("TRENTO_ENABLE_WEB_TLS", "true")
+	os.Setenv("TRENTO_RUNNER_TOKEN", "some-runner-token")
+	os.Setenv("TRENTO_RUNNER_CA", "some-runner-ca")
+	os.Setenv("TRENTO_ADMIN_TOKEN", "some-admin-token")
 	os.Setenv("TRENTO_DB_HOST", "some-db-host")
 	os.Setenv("TRENTO_DB_PORT", "6543")
 	os.Setenv("TRENTO_DB_USER", "postgres")
 	os.Setenv("TRENTO_DB_PASSWORD", "password")
 	os.Setenv("TRENTO_DB_NAME", "trento")
+	os.Setenv("TRENTO_DB_MAX_OPEN_CONNS", "20")
+	os.Setenv("TRENTO_DB_MAX_IDLE_CONNS", "5")
+	os.Setenv("TRENTO_DB_CONN_MAX_LIFETIME", "30m")
+	os.Setenv("TRENTO_DB_STATEMENT_TIMEOUT", "10s")
+	os.Setenv("TRENTO_DB_SSLMODE", "verify-full")
+	os.Setenv("TRENTO_DB_SSLROOTCERT", "some-db-ca")
+	os.Setenv("TRENTO_DB_REPLICA_DSN", "host=some-replica-host")
+	os.Setenv("TRENTO_CHECKS_ENGINE", "native")
+	os.Setenv("TRENTO_CHECKS_INTERVAL", "10")
 }
 
 func (suite *WebCmdTestSuite) TestConfigFromFile() {
 	os.Setenv("TRENTO_CONFIG", "../../test/fixtures/config/web.yaml")
 }
+
+func TestConfigAgentChecksEngineRequiresmTLS(t *testing.T) {
+	os.Clearenv()
+
+	for _, tc := range []struct {
+		args  []string
+		valid bool
+	}{
+		{[]string{"serve", "--checks-engine=agent"}, false},
+		{[]string{"serve", "--checks-engine=agent", "--enable-mtls", "--cert=some-cert", "--key=some-key", "--ca=some-ca"}, true},
+	} {
+		// drop the configuration file loaded by the previous tests
+		viper.Reset()
+
+		cmd := NewWebCmd()
+		cmd.Commands()[0].Run = func(cmd *cobra.Command, args []string) {
+			// do nothing
+		}
+		cmd.SetArgs(tc.args)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.Execute()
+
+		_, err := LoadConfig()
+		if tc.valid {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, "the agent checks engine requires the mTLS authentication of the agents")
+		}
+	}
+}
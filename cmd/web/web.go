@@ -46,6 +46,14 @@ func addServeCmd(webCmd *cobra.Command) {
 	var key string
 	var ca string
 
+	var enableWebTLS bool
+	var runnerToken string
+	var runnerCA string
+	var adminToken string
+
+	var checksEngine string
+	var checksInterval int
+
 	serveCmd := &cobra.Command{
 		Use:   "serve",
 		Short: "Starts the web application",
@@ -58,9 +66,17 @@ func addServeCmd(webCmd *cobra.Command) {
 	serveCmd.Flags().IntVar(&collectorPort, "collector-port", 8081, "The port for the data collector service to listen on")
 	serveCmd.Flags().BoolVar(&enablemTLS, "enable-mtls", false, "Enable mTLS authentication between server and agents")
 	serveCmd.Flags().StringVar(&cert, "cert", "", "mTLS server certificate")
-	serveCmd.Flags().StringVar(&key, "key", "", "mTLS server key")
+	serveCmd.Flags().StringVar(&key, "key", "", "mTLS server key, either a file path or a secret reference (env:, file: or vault:)")
 	serveCmd.Flags().StringVar(&ca, "ca", "", "mTLS Certificate Authority")
 
+	serveCmd.Flags().BoolVar(&enableWebTLS, "enable-web-tls", false, "Serve the web UI and API over TLS, with the --cert and --key server certificate")
+	serveCmd.Flags().StringVar(&runnerToken, "runner-token", "", "Token authenticating the runners, either the token or a secret reference (env:, file: or vault:)")
+	serveCmd.Flags().StringVar(&adminToken, "admin-token", "", "Token authorizing the changes to the custom checks, the remediations and the waivers, either the token or a secret reference (env:, file: or vault:). The changes are disabled without it")
+	serveCmd.Flags().StringVar(&runnerCA, "runner-ca", "", "Certificate Authority verifying the client certificates of the runners, requires --enable-web-tls")
+
+	serveCmd.Flags().StringVar(&checksEngine, "checks-engine", web.ChecksEngineAnsible, "Engine running the checks: ansible, with the trento runner, native, evaluating the facts published by the agents in the web server, or agent, with every agent evaluating its own host, requires --enable-mtls")
+	serveCmd.Flags().IntVar(&checksInterval, "checks-interval", 5, "Interval in minutes to run the checks with the native engine")
+
 	webCmd.AddCommand(serveCmd)
 }
 
@@ -29,9 +29,13 @@ require (
 	github.com/vektra/mockery/v2 v2.12.3
 	github.com/yusufpapurcu/wmi v1.2.2 // indirect
 	golang.org/x/sync v0.0.0-20220722155255-886fb9371eb4
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/datatypes v1.0.2
 	gorm.io/driver/postgres v1.1.2
+	gorm.io/driver/sqlite v1.1.6
 	gorm.io/gorm v1.21.15
+	gorm.io/plugin/dbresolver v1.1.0
+	modernc.org/sqlite v1.17.3
 )
 
 replace github.com/trento-project/trento => ./
@@ -0,0 +1,60 @@
+package checks
+
+import (
+	"fmt"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+// Check is a check evaluated natively against the facts of each host, it passes when all its
+// expectations are met, otherwise its result is the check severity
+type Check struct {
+	ID          string   `yaml:"id" json:"id"`
+	Name        string   `yaml:"name" json:"name"`
+	Group       string   `yaml:"group" json:"group"`
+	Description string   `yaml:"description" json:"description"`
+	Remediation string   `yaml:"remediation" json:"remediation,omitempty"`
+	Labels      string   `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Severity    string   `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Supersedes  []string `yaml:"supersedes,omitempty" json:"supersedes,omitempty"`
+	// Applicability are the conditions a cluster must meet for the check to be evaluated
+	Applicability *models.CheckApplicability `yaml:"applicability,omitempty" json:"applicability,omitempty"`
+	Expect        []*Expectation             `yaml:"expect" json:"expect"`
+}
+
+func (c *Check) validate() error {
+	if c.ID == "" {
+		return fmt.Errorf("check %s: missing id", c.Name)
+	}
+
+	switch c.Severity {
+	case "":
+		c.Severity = models.CheckCritical
+	case models.CheckCritical, models.CheckWarning:
+	default:
+		return fmt.Errorf("check %s: unknown severity %s", c.ID, c.Severity)
+	}
+
+	if len(c.Expect) == 0 {
+		return fmt.Errorf("check %s: no expectation defined", c.ID)
+	}
+
+	for _, e := range c.Expect {
+		if err := e.validate(); err != nil {
+			return fmt.Errorf("check %s: %s", c.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Evaluate returns the result of the check on a host
+func (c *Check) Evaluate(facts Facts) *models.Check {
+	for _, e := range c.Expect {
+		if ok, msg := e.Evaluate(facts); !ok {
+			return &models.Check{Result: c.Severity, Msg: msg}
+		}
+	}
+
+	return &models.Check{Result: models.CheckPassing}
+}
@@ -0,0 +1,151 @@
+package checks
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:embed definitions/*.yaml
+var definitionsFS embed.FS
+
+// Engine evaluates the native checks against the facts published by the agents
+type Engine struct {
+	checks map[string]*Check
+}
+
+func NewEngine(checks []*Check) (*Engine, error) {
+	engine := &Engine{checks: make(map[string]*Check)}
+
+	for _, check := range checks {
+		if err := check.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := engine.checks[check.ID]; ok {
+			return nil, fmt.Errorf("check %s is defined twice", check.ID)
+		}
+		engine.checks[check.ID] = check
+	}
+
+	return engine, nil
+}
+
+// NewDefaultEngine returns an engine with the checks shipped with trento
+func NewDefaultEngine() (*Engine, error) {
+	checks, err := LoadChecks(definitionsFS, "definitions")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEngine(checks)
+}
+
+// LoadChecks reads the checks defined in the YAML files of a directory
+func LoadChecks(fsys fs.FS, dir string) ([]*Check, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []*Check
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		var fileChecks []*Check
+		if err := yaml.Unmarshal(data, &fileChecks); err != nil {
+			return nil, fmt.Errorf("could not parse the checks file %s: %s", file, err)
+		}
+		checks = append(checks, fileChecks...)
+	}
+
+	return checks, nil
+}
+
+// Has tells whether the engine can evaluate the check
+func (e *Engine) Has(checkID string) bool {
+	_, ok := e.checks[checkID]
+	return ok
+}
+
+// Select returns the given checks known by the engine, in the same order
+func (e *Engine) Select(checkIDs []string) []*Check {
+	selected := []*Check{}
+
+	for _, checkID := range checkIDs {
+		if check, ok := e.checks[checkID]; ok {
+			selected = append(selected, check)
+		}
+	}
+
+	return selected
+}
+
+// Catalog returns the checks of the engine in the catalog format, sorted by name
+func (e *Engine) Catalog() models.ChecksCatalog {
+	catalog := models.ChecksCatalog{}
+
+	for _, check := range e.checks {
+		implementation, _ := yaml.Marshal(check.Expect)
+		catalog = append(catalog, &models.Check{
+			ID:             check.ID,
+			Name:           check.Name,
+			Group:          check.Group,
+			Description:    check.Description,
+			Remediation:    check.Remediation,
+			Implementation: string(implementation),
+			Labels:         check.Labels,
+			Supersedes:     check.Supersedes,
+			Applicability:  check.Applicability,
+		})
+	}
+
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].Name < catalog[j].Name
+	})
+
+	return catalog
+}
+
+// Run evaluates the selected checks on every host, the hosts without facts are reported as unreachable.
+// The selected checks the engine doesn't know about are ignored
+func (e *Engine) Run(selected []string, hosts map[string]Facts) *models.ChecksResult {
+	result := &models.ChecksResult{
+		Hosts:  make(map[string]*models.HostState),
+		Checks: make(map[string]*models.ChecksByHost),
+	}
+
+	for host, facts := range hosts {
+		if len(facts) == 0 {
+			result.Hosts[host] = &models.HostState{Reachable: false, Msg: "No facts collected from the host"}
+			continue
+		}
+		result.Hosts[host] = &models.HostState{Reachable: true}
+	}
+
+	for _, checkID := range selected {
+		check, ok := e.checks[checkID]
+		if !ok {
+			continue
+		}
+
+		checkResult := &models.ChecksByHost{ID: check.ID, Hosts: make(map[string]*models.Check)}
+		for host, facts := range hosts {
+			if len(facts) == 0 {
+				continue
+			}
+			checkResult.Hosts[host] = check.Evaluate(facts)
+		}
+		result.Checks[check.ID] = checkResult
+	}
+
+	return result
+}
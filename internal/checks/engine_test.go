@@ -0,0 +1,164 @@
+package checks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestNewDefaultEngine(t *testing.T) {
+	engine, err := NewDefaultEngine()
+
+	assert.NoError(t, err)
+	assert.True(t, engine.Has("156F64"))
+	assert.True(t, engine.Has("205AF7"))
+	assert.False(t, engine.Has("53D035"))
+}
+
+func TestDefaultEngineRun(t *testing.T) {
+	engine, err := NewDefaultEngine()
+	assert.NoError(t, err)
+
+	published, err := ioutil.ReadFile("../../test/fixtures/discovery/cluster/expected_published_cluster_discovery.json")
+	assert.NoError(t, err)
+
+	var event struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	assert.NoError(t, json.Unmarshal(published, &event))
+
+	facts := Facts{}
+	assert.NoError(t, facts.Add("cluster", event.Payload))
+
+	result := engine.Run([]string{"156F64", "33403D", "205AF7", "49591F", "61451E", "B089BE", "9FEFB0"}, map[string]Facts{
+		"vmhana01": facts,
+	})
+
+	results := make(map[string]string)
+	for id, check := range result.Checks {
+		results[id] = check.Hosts["vmhana01"].Result
+	}
+
+	assert.Equal(t, map[string]string{
+		"156F64": models.CheckPassing,
+		"33403D": models.CheckPassing,
+		"205AF7": models.CheckPassing,
+		"49591F": models.CheckPassing,
+		"61451E": models.CheckCritical,
+		"B089BE": models.CheckCritical,
+		"9FEFB0": models.CheckCritical,
+	}, results)
+}
+
+func TestNewEngineErrors(t *testing.T) {
+	expect := []*Expectation{{"host.OSVersion", OpExists, ""}}
+
+	_, err := NewEngine([]*Check{{Name: "1.1.1", Expect: expect}})
+	assert.EqualError(t, err, "check 1.1.1: missing id")
+
+	_, err = NewEngine([]*Check{{ID: "A", Severity: "error", Expect: expect}})
+	assert.EqualError(t, err, "check A: unknown severity error")
+
+	_, err = NewEngine([]*Check{{ID: "A"}})
+	assert.EqualError(t, err, "check A: no expectation defined")
+
+	_, err = NewEngine([]*Check{{ID: "A", Expect: expect}, {ID: "A", Expect: expect}})
+	assert.EqualError(t, err, "check A is defined twice")
+}
+
+func TestLoadChecks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"custom/checks.yaml": &fstest.MapFile{Data: []byte(`
+- id: ABC123
+  name: custom
+  group: Custom
+  description: Token is set
+  severity: warning
+  expect:
+    - fact: cluster.Corosync.totem.token
+      op: eq
+      value: 30000
+`)},
+		"custom/README.md": &fstest.MapFile{Data: []byte("not a check")},
+	}
+
+	checks, err := LoadChecks(fsys, "custom")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Check{{
+		ID:          "ABC123",
+		Name:        "custom",
+		Group:       "Custom",
+		Description: "Token is set",
+		Severity:    models.CheckWarning,
+		Expect:      []*Expectation{{"cluster.Corosync.totem.token", OpEqual, "30000"}},
+	}}, checks)
+
+	_, err = LoadChecks(fstest.MapFS{"custom/bad.yaml": &fstest.MapFile{Data: []byte("id: [")}}, "custom")
+	assert.Error(t, err)
+}
+
+func TestEngineRun(t *testing.T) {
+	engine, err := NewEngine([]*Check{
+		{ID: "A", Expect: []*Expectation{{"cluster.Corosync.totem.token", OpEqual, "30000"}}},
+		{ID: "B", Severity: models.CheckWarning, Expect: []*Expectation{{"cluster.SBD.Devices.#", OpGreaterOrEqual, "3"}}},
+		{ID: "C", Expect: []*Expectation{{"cluster.SBD.Config.SBD_STARTMODE", OpEqual, "clean"}}},
+	})
+	assert.NoError(t, err)
+
+	result := engine.Run([]string{"A", "B", "unknown"}, map[string]Facts{
+		"node1": testFacts(t),
+		"node2": {},
+	})
+
+	assert.Equal(t, &models.ChecksResult{
+		Hosts: map[string]*models.HostState{
+			"node1": {Reachable: true},
+			"node2": {Reachable: false, Msg: "No facts collected from the host"},
+		},
+		Checks: map[string]*models.ChecksByHost{
+			"A": {
+				ID:    "A",
+				Hosts: map[string]*models.Check{"node1": {Result: models.CheckPassing}},
+			},
+			"B": {
+				ID: "B",
+				Hosts: map[string]*models.Check{"node1": {
+					Result: models.CheckWarning,
+					Msg:    "cluster.SBD.Devices.# is 2, expected ge 3",
+				}},
+			},
+		},
+	}, result)
+}
+
+func TestEngineSelect(t *testing.T) {
+	a := &Check{ID: "A", Expect: []*Expectation{{"cluster.SBD", OpExists, ""}}}
+	b := &Check{ID: "B", Expect: []*Expectation{{"cluster.SBD", OpAbsent, ""}}}
+	engine, err := NewEngine([]*Check{a, b})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Check{b, a}, engine.Select([]string{"B", "unknown", "A"}))
+	assert.Equal(t, []*Check{}, engine.Select(nil))
+}
+
+func TestEngineCatalog(t *testing.T) {
+	engine, err := NewEngine([]*Check{
+		{ID: "B", Name: "1.2", Group: "Corosync", Expect: []*Expectation{{"cluster.Corosync.totem.token", OpEqual, "30000"}}},
+		{ID: "A", Name: "1.1", Group: "SBD", Expect: []*Expectation{{"cluster.SBD", OpExists, ""}}},
+	})
+	assert.NoError(t, err)
+
+	catalog := engine.Catalog()
+
+	assert.Len(t, catalog, 2)
+	assert.Equal(t, "A", catalog[0].ID)
+	assert.Equal(t, "SBD", catalog[0].Group)
+	assert.Equal(t, "- fact: cluster.SBD\n  op: exists\n", catalog[0].Implementation)
+	assert.Equal(t, "B", catalog[1].ID)
+}
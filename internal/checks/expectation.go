@@ -0,0 +1,180 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	OpEqual          = "eq"
+	OpNotEqual       = "ne"
+	OpGreater        = "gt"
+	OpGreaterOrEqual = "ge"
+	OpLess           = "lt"
+	OpLessOrEqual    = "le"
+	OpContains       = "contains"
+	OpMatches        = "matches"
+	OpVersionAtLeast = "version_ge"
+	OpVersionBelow   = "version_lt"
+	OpExists         = "exists"
+	OpAbsent         = "absent"
+)
+
+var operators = map[string]bool{
+	OpEqual: true, OpNotEqual: true, OpGreater: true, OpGreaterOrEqual: true, OpLess: true, OpLessOrEqual: true,
+	OpContains: true, OpMatches: true, OpVersionAtLeast: true, OpVersionBelow: true, OpExists: true, OpAbsent: true,
+}
+
+// Expectation is a condition on a fact, it is met when every value found at the fact path satisfies it
+type Expectation struct {
+	Fact     string `yaml:"fact" json:"fact"`
+	Operator string `yaml:"op" json:"op"`
+	Value    string `yaml:"value,omitempty" json:"value,omitempty"`
+}
+
+func (e *Expectation) validate() error {
+	if e.Fact == "" {
+		return fmt.Errorf("missing fact")
+	}
+
+	if _, err := parsePath(e.Fact); err != nil {
+		return err
+	}
+
+	if !operators[e.Operator] {
+		return fmt.Errorf("unknown operator %s", e.Operator)
+	}
+
+	if e.Operator == OpMatches {
+		if _, err := regexp.Compile(e.Value); err != nil {
+			return fmt.Errorf("invalid regular expression %s: %s", e.Value, err)
+		}
+	}
+
+	return nil
+}
+
+// Evaluate tells whether the facts meet the expectation, with a message describing why when they don't
+func (e *Expectation) Evaluate(facts Facts) (bool, string) {
+	values, err := facts.Lookup(e.Fact)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	switch e.Operator {
+	case OpExists:
+		if len(values) == 0 {
+			return false, fmt.Sprintf("%s not found", e.Fact)
+		}
+		return true, ""
+	case OpAbsent:
+		if len(values) != 0 {
+			return false, fmt.Sprintf("%s is %s, expected to be absent", e.Fact, stringify(values[0]))
+		}
+		return true, ""
+	}
+
+	if len(values) == 0 {
+		return false, fmt.Sprintf("%s not found", e.Fact)
+	}
+
+	for _, value := range values {
+		actual := stringify(value)
+		if !e.compare(actual) {
+			return false, fmt.Sprintf("%s is %s, expected %s %s", e.Fact, actual, e.Operator, e.Value)
+		}
+	}
+
+	return true, ""
+}
+
+func (e *Expectation) compare(actual string) bool {
+	switch e.Operator {
+	case OpEqual:
+		return actual == e.Value || numbersEqual(actual, e.Value)
+	case OpNotEqual:
+		return actual != e.Value && !numbersEqual(actual, e.Value)
+	case OpGreater, OpGreaterOrEqual, OpLess, OpLessOrEqual:
+		a, errA := strconv.ParseFloat(actual, 64)
+		b, errB := strconv.ParseFloat(e.Value, 64)
+		if errA != nil || errB != nil {
+			return false
+		}
+		return compareOrdered(e.Operator, a-b)
+	case OpContains:
+		return strings.Contains(actual, e.Value)
+	case OpMatches:
+		matched, err := regexp.MatchString(e.Value, actual)
+		return err == nil && matched
+	case OpVersionAtLeast:
+		return compareVersions(actual, e.Value) >= 0
+	case OpVersionBelow:
+		return compareVersions(actual, e.Value) < 0
+	default:
+		return false
+	}
+}
+
+func numbersEqual(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+
+	return errA == nil && errB == nil && x == y
+}
+
+func compareOrdered(operator string, difference float64) bool {
+	switch operator {
+	case OpGreater:
+		return difference > 0
+	case OpGreaterOrEqual:
+		return difference >= 0
+	case OpLess:
+		return difference < 0
+	default:
+		return difference <= 0
+	}
+}
+
+// compareVersions compares the dot separated numeric parts of two versions, ignoring any build
+// metadata after a + or - sign, e.g. 2.0.5+20201202.ba59be712 is greater than 2.0.3
+func compareVersions(a, b string) int {
+	partsA := versionParts(a)
+	partsB := versionParts(b)
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var x, y int
+		if i < len(partsA) {
+			x = partsA[i]
+		}
+		if i < len(partsB) {
+			y = partsB[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func versionParts(version string) []int {
+	if end := strings.IndexAny(version, "+-"); end != -1 {
+		version = version[:end]
+	}
+
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		number, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			break
+		}
+		parts = append(parts, number)
+	}
+
+	return parts
+}
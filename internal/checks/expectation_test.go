@@ -0,0 +1,58 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectationEvaluate(t *testing.T) {
+	facts := testFacts(t)
+
+	cases := []struct {
+		expectation *Expectation
+		ok          bool
+		msg         string
+	}{
+		{&Expectation{"cluster.Corosync.totem.token", OpEqual, "30000"}, true, ""},
+		{&Expectation{"cluster.Corosync.totem.token", OpEqual, "30000.0"}, true, ""},
+		{&Expectation{"cluster.Corosync.totem.token", OpNotEqual, "5000"}, true, ""},
+		{&Expectation{"cluster.Corosync.totem.token", OpGreaterOrEqual, "20000"}, true, ""},
+		{&Expectation{"cluster.Corosync.totem.token", OpLess, "20000"}, false, "cluster.Corosync.totem.token is 30000, expected lt 20000"},
+		{&Expectation{"cluster.SBD.Config.SBD_STARTMODE", OpGreater, "1"}, false, "cluster.SBD.Config.SBD_STARTMODE is always, expected gt 1"},
+		{&Expectation{"cluster.SBD.Config.SBD_STARTMODE", OpContains, "way"}, true, ""},
+		{&Expectation{"cluster.SBD.Config.SBD_STARTMODE", OpMatches, "^(always|clean)$"}, true, ""},
+		{&Expectation{"cluster.SBD.Devices.*.Dump.TimeoutWatchdog", OpEqual, "60"}, false, "cluster.SBD.Devices.*.Dump.TimeoutWatchdog is 5, expected eq 60"},
+		{&Expectation{"cluster.SBD.Devices.*.Dump.TimeoutWatchdog", OpLessOrEqual, "60"}, true, ""},
+		{&Expectation{"cluster.Cib.Configuration.CrmConfig.ClusterProperties[Name=dc-version].Value", OpVersionAtLeast, "2.0.3"}, true, ""},
+		{&Expectation{"cluster.Cib.Configuration.CrmConfig.ClusterProperties[Name=dc-version].Value", OpVersionBelow, "2.0.3"}, false, "cluster.Cib.Configuration.CrmConfig.ClusterProperties[Name=dc-version].Value is 2.0.5+20201202.ba59be712-4.13.1-2.0.5+20201202.ba59be712, expected version_lt 2.0.3"},
+		{&Expectation{"subscriptions[identifier=SLES_SAP]", OpExists, ""}, true, ""},
+		{&Expectation{"subscriptions[identifier=SLES]", OpExists, ""}, false, "subscriptions[identifier=SLES] not found"},
+		{&Expectation{"cluster.Corosync.quorum", OpAbsent, ""}, true, ""},
+		{&Expectation{"cluster.Corosync.totem.token", OpAbsent, ""}, false, "cluster.Corosync.totem.token is 30000, expected to be absent"},
+		{&Expectation{"cluster.Corosync.quorum.two_node", OpEqual, "1"}, false, "cluster.Corosync.quorum.two_node not found"},
+	}
+
+	for _, c := range cases {
+		ok, msg := c.expectation.Evaluate(facts)
+		assert.Equal(t, c.ok, ok, c.expectation)
+		assert.Equal(t, c.msg, msg, c.expectation)
+	}
+}
+
+func TestExpectationValidate(t *testing.T) {
+	assert.NoError(t, (&Expectation{"host.OSVersion", OpExists, ""}).validate())
+	assert.EqualError(t, (&Expectation{"", OpExists, ""}).validate(), "missing fact")
+	assert.EqualError(t, (&Expectation{"host.OSVersion", "like", ""}).validate(), "unknown operator like")
+	assert.Error(t, (&Expectation{"host.OSVersion", OpMatches, "("}).validate())
+	assert.Error(t, (&Expectation{"host..OSVersion", OpExists, ""}).validate())
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("15.1", "15.1"))
+	assert.Equal(t, 0, compareVersions("2.0.3", "2.0.3+20200511.2b248d828"))
+	assert.Equal(t, 1, compareVersions("15.2", "15.1"))
+	assert.Equal(t, 1, compareVersions("2.0.10", "2.0.9"))
+	assert.Equal(t, -1, compareVersions("1.1.18+20180430.b12c320f5-3.15.1-b12c320f5", "2.0.3"))
+	assert.Equal(t, -1, compareVersions("15", "15.1"))
+}
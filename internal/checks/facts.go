@@ -0,0 +1,201 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The facts sources, each one is the payload of a discovery published by the agents
+const (
+	ClusterFacts       = "cluster"
+	HostFacts          = "host"
+	SAPSystemsFacts    = "sap_systems"
+	SubscriptionsFacts = "subscriptions"
+	CloudFacts         = "cloud"
+)
+
+// Facts is the data gathered from a host, keyed by source (cluster, host, subscriptions...)
+//
+// The values are looked up with paths made of dot separated segments:
+//   - key: the value of a map key, or the element at a list index
+//   - key[field=value]: the elements of the list key with the given field value
+//   - *: all the elements of a list or map
+//   - #: the number of elements of a list or map
+//
+// e.g. cluster.Cib.Configuration.CrmConfig.ClusterProperties[Name=stonith-enabled].Value
+type Facts map[string]interface{}
+
+// Add stores the JSON payload of a source
+func (f Facts) Add(source string, payload []byte) error {
+	var data interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return fmt.Errorf("invalid %s facts: %s", source, err)
+	}
+
+	f[source] = data
+	return nil
+}
+
+// Lookup returns the values found at the given path, no value is returned if the path does not exist
+func (f Facts) Lookup(path string) ([]interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{map[string]interface{}(f)}
+	for _, s := range segments {
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, s.apply(value)...)
+		}
+		values = next
+	}
+
+	return values, nil
+}
+
+type pathSegment struct {
+	key         string
+	filterField string
+	filterValue string
+	hasFilter   bool
+}
+
+func parsePath(path string) ([]*pathSegment, error) {
+	var segments []*pathSegment
+
+	for _, part := range splitPath(path) {
+		s := &pathSegment{key: part}
+
+		if open := strings.Index(part, "["); open != -1 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, fmt.Errorf("invalid fact path %s: unclosed filter", path)
+			}
+			filter := strings.SplitN(part[open+1:len(part)-1], "=", 2)
+			if len(filter) != 2 || filter[0] == "" {
+				return nil, fmt.Errorf("invalid fact path %s: filters must be field=value", path)
+			}
+			s.key = part[:open]
+			s.filterField, s.filterValue, s.hasFilter = filter[0], filter[1], true
+		}
+
+		if s.key == "" && !s.hasFilter {
+			return nil, fmt.Errorf("invalid fact path %s: empty segment", path)
+		}
+		segments = append(segments, s)
+	}
+
+	return segments, nil
+}
+
+// splitPath splits the path on the dots that are not part of a filter value
+func splitPath(path string) []string {
+	var parts []string
+	var depth, start int
+
+	for i, c := range path {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				parts = append(parts, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, path[start:])
+}
+
+func (s *pathSegment) apply(value interface{}) []interface{} {
+	var values []interface{}
+
+	switch s.key {
+	case "":
+		values = []interface{}{value}
+	case "*":
+		values = elements(value)
+	case "#":
+		if v, ok := value.([]interface{}); ok {
+			return []interface{}{float64(len(v))}
+		}
+		if v, ok := value.(map[string]interface{}); ok {
+			return []interface{}{float64(len(v))}
+		}
+		return nil
+	default:
+		child, ok := lookupKey(value, s.key)
+		if !ok {
+			return nil
+		}
+		values = []interface{}{child}
+	}
+
+	if !s.hasFilter {
+		return values
+	}
+
+	var filtered []interface{}
+	for _, v := range values {
+		for _, element := range elements(v) {
+			if field, ok := lookupKey(element, s.filterField); ok && stringify(field) == s.filterValue {
+				filtered = append(filtered, element)
+			}
+		}
+	}
+
+	return filtered
+}
+
+func lookupKey(value interface{}, key string) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		child, ok := v[key]
+		return child, ok && child != nil
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil, false
+		}
+		return v[index], v[index] != nil
+	default:
+		return nil, false
+	}
+}
+
+func elements(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		var values []interface{}
+		for _, child := range v {
+			values = append(values, child)
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
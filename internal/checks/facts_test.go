@@ -0,0 +1,69 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFacts(t *testing.T) Facts {
+	facts := Facts{}
+
+	err := facts.Add("cluster", []byte(`{
+		"Cib": {"Configuration": {"CrmConfig": {"ClusterProperties": [
+			{"Id": "cib-bootstrap-options-stonith-enabled", "Name": "stonith-enabled", "Value": "true"},
+			{"Id": "cib-bootstrap-options-dc-version", "Name": "dc-version", "Value": "2.0.5+20201202.ba59be712-4.13.1-2.0.5+20201202.ba59be712"}
+		]}}},
+		"SBD": {
+			"Devices": [{"Dump": {"TimeoutWatchdog": 60}}, {"Dump": {"TimeoutWatchdog": 5}}],
+			"Config": {"SBD_STARTMODE": "always"}
+		},
+		"Corosync": {"totem": {"token": "30000"}}
+	}`))
+	assert.NoError(t, err)
+
+	err = facts.Add("subscriptions", []byte(`[{"identifier": "SLES_SAP", "version": "15.2"}, {"identifier": "sle-ha", "version": "15.2"}]`))
+	assert.NoError(t, err)
+
+	return facts
+}
+
+func TestFactsLookup(t *testing.T) {
+	facts := testFacts(t)
+
+	cases := map[string][]interface{}{
+		"cluster.Corosync.totem.token": {"30000"},
+		"cluster.Cib.Configuration.CrmConfig.ClusterProperties[Name=stonith-enabled].Value": {"true"},
+		"cluster.SBD.Devices.#":                      {float64(2)},
+		"cluster.SBD.Devices.1.Dump.TimeoutWatchdog": {float64(5)},
+		"cluster.SBD.Devices.*.Dump.TimeoutWatchdog": {float64(60), float64(5)},
+		"subscriptions[identifier=sle-ha].version":   {"15.2"},
+		"subscriptions.#":                            {float64(2)},
+		"cluster.Corosync.quorum.two_node":           nil,
+		"cluster.SBD.Devices.2.Dump":                 nil,
+		"subscriptions[identifier=SLES].version":     nil,
+		"cluster.Corosync.totem.token.something":     nil,
+		"host.OSVersion":                             nil,
+	}
+
+	for path, expected := range cases {
+		values, err := facts.Lookup(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, values, path)
+	}
+}
+
+func TestFactsLookupInvalidPath(t *testing.T) {
+	facts := testFacts(t)
+
+	for _, path := range []string{"cluster..token", "subscriptions[identifier", "subscriptions[identifier].version", ""} {
+		_, err := facts.Lookup(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestFactsAddInvalidPayload(t *testing.T) {
+	facts := Facts{}
+
+	assert.EqualError(t, facts.Add("host", []byte("{")), "invalid host facts: unexpected end of JSON input")
+}
@@ -26,29 +26,32 @@ const (
 )
 
 type DiscoveryTools struct {
-	CibAdmPath      string
-	CrmmonAdmPath   string
-	CorosyncKeyPath string
-	SBDPath         string
-	SBDConfigPath   string
+	CibAdmPath       string
+	CrmmonAdmPath    string
+	CorosyncKeyPath  string
+	CorosyncConfPath string
+	SBDPath          string
+	SBDConfigPath    string
 }
 
 type Cluster struct {
-	Cib    cib.Root    `mapstructure:"cib,omitempty"`
-	Crmmon crmmon.Root `mapstructure:"crmmon,omitempty"`
-	SBD    SBD         `mapstructure:"sbd,omitempty"`
-	Id     string      `mapstructure:"id"`
-	Name   string      `mapstructure:"name"`
-	DC     bool        `mapstructure:"dc"`
+	Cib      cib.Root               `mapstructure:"cib,omitempty"`
+	Crmmon   crmmon.Root            `mapstructure:"crmmon,omitempty"`
+	SBD      SBD                    `mapstructure:"sbd,omitempty"`
+	Corosync map[string]interface{} `mapstructure:"corosync,omitempty"`
+	Id       string                 `mapstructure:"id"`
+	Name     string                 `mapstructure:"name"`
+	DC       bool                   `mapstructure:"dc"`
 }
 
 func NewCluster() (Cluster, error) {
 	return NewClusterWithDiscoveryTools(&DiscoveryTools{
-		CibAdmPath:      cibAdmPath,
-		CrmmonAdmPath:   crmmonAdmPath,
-		CorosyncKeyPath: corosyncKeyPath,
-		SBDPath:         SBDPath,
-		SBDConfigPath:   SBDConfigPath,
+		CibAdmPath:       cibAdmPath,
+		CrmmonAdmPath:    crmmonAdmPath,
+		CorosyncKeyPath:  corosyncKeyPath,
+		CorosyncConfPath: CorosyncConfPath,
+		SBDPath:          SBDPath,
+		SBDConfigPath:    SBDConfigPath,
 	})
 }
 
@@ -81,6 +84,11 @@ func NewClusterWithDiscoveryTools(discoveryTools *DiscoveryTools) (Cluster, erro
 
 	cluster.Name = getName(cluster)
 
+	cluster.Corosync, err = NewCorosyncConfig(discoveryTools.CorosyncConfPath)
+	if err != nil {
+		return cluster, err
+	}
+
 	if cluster.IsFencingSBD() {
 		sbdData, err := NewSBD(cluster.Id, discoveryTools.SBDPath, discoveryTools.SBDConfigPath)
 		if err != nil {
@@ -0,0 +1,77 @@
+package cluster
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	CorosyncConfPath = "/etc/corosync/corosync.conf"
+)
+
+// corosync.conf sections that can appear more than once, they are always stored as lists
+var corosyncRepeatedSections = map[string]bool{
+	"interface": true,
+	"node":      true,
+}
+
+// NewCorosyncConfig parses the corosync configuration file in a map of sections and values, e.g.
+// the token timeout is found in config["totem"]["token"]
+func NewCorosyncConfig(corosyncConfPath string) (map[string]interface{}, error) {
+	corosyncConfFile, err := os.Open(corosyncConfPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open corosync config file %s", err)
+	}
+
+	defer corosyncConfFile.Close()
+
+	return parseCorosyncConfig(bufio.NewScanner(corosyncConfFile))
+}
+
+func parseCorosyncConfig(scanner *bufio.Scanner) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	sections := []map[string]interface{}{config}
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		current := sections[len(sections)-1]
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasSuffix(line, "{"):
+			name := strings.TrimSpace(strings.TrimSuffix(line, "{"))
+			section := make(map[string]interface{})
+			if corosyncRepeatedSections[name] {
+				list, _ := current[name].([]interface{})
+				current[name] = append(list, section)
+			} else {
+				current[name] = section
+			}
+			sections = append(sections, section)
+		case line == "}":
+			if len(sections) == 1 {
+				return nil, fmt.Errorf("unexpected closing bracket in corosync config at line %d", lineNumber)
+			}
+			sections = sections[:len(sections)-1]
+		default:
+			keyValue := strings.SplitN(line, ":", 2)
+			if len(keyValue) != 2 {
+				return nil, fmt.Errorf("invalid corosync config entry at line %d: %s", lineNumber, line)
+			}
+			current[strings.TrimSpace(keyValue[0])] = strings.TrimSpace(keyValue[1])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read corosync config file %s", err)
+	}
+
+	if len(sections) != 1 {
+		return nil, fmt.Errorf("unclosed section in corosync config")
+	}
+
+	return config, nil
+}
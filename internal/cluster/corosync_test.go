@@ -0,0 +1,49 @@
+package cluster
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCorosyncConfig(t *testing.T) {
+	config, err := NewCorosyncConfig("../../test/corosync.conf")
+
+	assert.NoError(t, err)
+
+	totem := config["totem"].(map[string]interface{})
+	assert.Equal(t, "30000", totem["token"])
+	assert.Equal(t, "udpu", totem["transport"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"ringnumber": "0", "mcastport": "5405", "ttl": "1"},
+	}, totem["interface"])
+
+	nodelist := config["nodelist"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"ring0_addr": "10.80.1.11", "nodeid": "1"},
+		map[string]interface{}{"ring0_addr": "10.80.1.12", "nodeid": "2"},
+	}, nodelist["node"])
+
+	quorum := config["quorum"].(map[string]interface{})
+	assert.Equal(t, "2", quorum["expected_votes"])
+	assert.Equal(t, "1", quorum["two_node"])
+}
+
+func TestNewCorosyncConfigMissingFile(t *testing.T) {
+	_, err := NewCorosyncConfig("../../test/not_found.conf")
+
+	assert.Error(t, err)
+}
+
+func TestParseCorosyncConfigErrors(t *testing.T) {
+	for _, content := range []string{
+		"totem {\n\ttoken: 30000\n",
+		"totem {\n}\n}\n",
+		"totem {\n\ttoken\n}\n",
+	} {
+		_, err := parseCorosyncConfig(bufio.NewScanner(strings.NewReader(content)))
+		assert.Error(t, err)
+	}
+}
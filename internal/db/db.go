@@ -2,36 +2,142 @@ package db
 
 import (
 	"fmt"
+	"time"
 
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	// pure Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	PostgresDriver = "postgres"
+	SQLiteDriver   = "sqlite"
 )
 
 type Config struct {
+	Driver   string
 	Host     string
 	Port     int
 	User     string
 	Password string
 	DBName   string
+	// Path is the database file used by the SQLite driver
+	Path string
+
+	MaxOpenConns     int
+	MaxIdleConns     int
+	ConnMaxLifetime  time.Duration
+	StatementTimeout time.Duration
+	// SSLMode and SSLRootCert configure the TLS connection to PostgreSQL,
+	// verify-ca and verify-full modes check the server certificate against the root CA
+	SSLMode     string
+	SSLRootCert string
+	// ReplicaDSN is the optional PostgreSQL read-only replica connection string
+	ReplicaDSN string
 }
 
 func InitDB(config *Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		config.Host,
-		config.Port,
-		config.User,
-		config.Password,
-		config.DBName)
+	dialector, err := newDialector(config)
+	if err != nil {
+		return nil, err
+	}
 
 	// TODO: since we are dealing with eventual consistency, we can't enforce foreign key constraints in our projected models.
 	// This disables foreign key constraints enforcement at global level.
 	// In a future we will enable this on a per-model basis via dedicated migrations and disabling the automigration feature.
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	db, err := gorm.Open(dialector, &gorm.Config{
 		DisableForeignKeyConstraintWhenMigrating: true,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	// zero values keep the database/sql defaults
+	if config.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+	}
+	if config.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+	}
+	if config.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
+	}
+
 	return db, nil
 }
+
+// UseReadReplica routes the read queries on the given tables to the read-only replica, when configured.
+// Writes, and any query executed in a transaction, keep using the primary database.
+func UseReadReplica(db *gorm.DB, config *Config, tables ...interface{}) error {
+	if config.ReplicaDSN == "" {
+		return nil
+	}
+
+	if db.Dialector.Name() != PostgresDriver {
+		return fmt.Errorf("read replicas are not supported by the %s driver", db.Dialector.Name())
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{postgres.Open(config.ReplicaDSN)},
+	}, tables...)
+
+	if config.MaxOpenConns > 0 {
+		resolver.SetMaxOpenConns(config.MaxOpenConns)
+	}
+	if config.MaxIdleConns > 0 {
+		resolver.SetMaxIdleConns(config.MaxIdleConns)
+	}
+	if config.ConnMaxLifetime > 0 {
+		resolver.SetConnMaxLifetime(config.ConnMaxLifetime)
+	}
+
+	return db.Use(resolver)
+}
+
+func newDialector(config *Config) (gorm.Dialector, error) {
+	switch config.Driver {
+	case PostgresDriver, "":
+		sslMode := config.SSLMode
+		if sslMode == "" {
+			sslMode = "disable"
+		}
+
+		dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			config.Host,
+			config.Port,
+			config.User,
+			config.Password,
+			config.DBName,
+			sslMode)
+
+		if config.SSLRootCert != "" {
+			dsn += fmt.Sprintf(" sslrootcert=%s", config.SSLRootCert)
+		}
+
+		if config.StatementTimeout > 0 {
+			dsn += fmt.Sprintf(" statement_timeout=%d", config.StatementTimeout.Milliseconds())
+		}
+
+		return postgres.Open(dsn), nil
+	case SQLiteDriver:
+		if config.Path == "" {
+			return nil, fmt.Errorf("a database path is required by the %s driver", SQLiteDriver)
+		}
+
+		// WAL mode and a busy timeout let the projectors write concurrently with the readers,
+		// since SQLite allows a single writer at a time
+		dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", config.Path)
+
+		return sqlite.Dialector{DriverName: SQLiteDriver, DSN: dsn}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", config.Driver)
+	}
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+)
+
+func TestNewDialector(t *testing.T) {
+	dialector, err := newDialector(&Config{Driver: SQLiteDriver, Path: "/tmp/trento.db"})
+	assert.NoError(t, err)
+	assert.Equal(t, SQLiteDriver, dialector.Name())
+
+	dialector, err = newDialector(&Config{Host: "localhost", Port: 5432, User: "postgres", Password: "postgres", DBName: "trento"})
+	assert.NoError(t, err)
+	assert.Equal(t, PostgresDriver, dialector.Name())
+	assert.Equal(t, "host=localhost port=5432 user=postgres password=postgres dbname=trento sslmode=disable",
+		dialector.(*postgres.Dialector).DSN)
+
+	_, err = newDialector(&Config{Driver: SQLiteDriver})
+	assert.EqualError(t, err, "a database path is required by the sqlite driver")
+
+	_, err = newDialector(&Config{Driver: "mysql"})
+	assert.EqualError(t, err, "unsupported database driver: mysql")
+}
+
+func TestNewDialectorTLSAndTimeout(t *testing.T) {
+	dialector, err := newDialector(&Config{
+		Driver:           PostgresDriver,
+		Host:             "localhost",
+		Port:             5432,
+		User:             "postgres",
+		Password:         "postgres",
+		DBName:           "trento",
+		SSLMode:          "verify-full",
+		SSLRootCert:      "/etc/trento/db-ca.pem",
+		StatementTimeout: 30 * time.Second,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"host=localhost port=5432 user=postgres password=postgres dbname=trento sslmode=verify-full "+
+			"sslrootcert=/etc/trento/db-ca.pem statement_timeout=30000",
+		dialector.(*postgres.Dialector).DSN)
+}
+
+func TestInitDBPoolSettings(t *testing.T) {
+	db, err := InitDB(&Config{
+		Driver:       SQLiteDriver,
+		Path:         filepath.Join(t.TempDir(), "trento.db"),
+		MaxOpenConns: 7,
+	})
+	assert.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, sqlDB.Stats().MaxOpenConnections)
+}
+
+func TestUseReadReplica(t *testing.T) {
+	config := &Config{
+		Driver: SQLiteDriver,
+		Path:   filepath.Join(t.TempDir(), "trento.db"),
+	}
+	db, err := InitDB(config)
+	assert.NoError(t, err)
+
+	assert.NoError(t, UseReadReplica(db, config, "hosts"))
+
+	config.ReplicaDSN = "host=replica"
+	assert.EqualError(t, UseReadReplica(db, config, "hosts"), "read replicas are not supported by the sqlite driver")
+}
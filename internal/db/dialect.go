@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// StringArray is a list of strings stored as a native text array in PostgreSQL
+// and as a JSON encoded list in SQLite
+type StringArray []string
+
+func (StringArray) GormDataType() string {
+	return "string_array"
+}
+
+func (StringArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == SQLiteDriver {
+		return "text"
+	}
+
+	return "text[]"
+}
+
+func (a StringArray) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if db.Dialector.Name() != SQLiteDriver {
+		return clause.Expr{SQL: "?", Vars: []interface{}{pq.StringArray(a)}}
+	}
+
+	if a == nil {
+		return clause.Expr{SQL: "NULL"}
+	}
+
+	value, err := json.Marshal([]string(a))
+	if err != nil {
+		db.AddError(err)
+	}
+
+	return clause.Expr{SQL: "?", Vars: []interface{}{string(value)}}
+}
+
+// Value implements driver.Valuer, using the PostgreSQL array format
+func (a StringArray) Value() (driver.Value, error) {
+	return pq.StringArray(a).Value()
+}
+
+// Scan implements sql.Scanner, accepting both the PostgreSQL array and the JSON list formats
+func (a *StringArray) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot convert %T to StringArray", src)
+	}
+
+	if len(data) > 0 && data[0] == '[' {
+		var values []string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		*a = values
+		return nil
+	}
+
+	var values pq.StringArray
+	if err := values.Scan(data); err != nil {
+		return err
+	}
+	*a = StringArray(values)
+
+	return nil
+}
+
+type arrayOverlapsExpression struct {
+	column string
+	values []string
+}
+
+// ArrayOverlaps builds a condition matching the rows where the StringArray column
+// contains at least one of the given values
+func ArrayOverlaps(column string, values []string) clause.Expression {
+	return arrayOverlapsExpression{column: column, values: values}
+}
+
+// Build implements clause.Expression
+func (e arrayOverlapsExpression) Build(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		return
+	}
+
+	if stmt.Dialector.Name() == SQLiteDriver {
+		stmt.WriteString(fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) WHERE json_each.value IN ", e.column))
+		stmt.AddVar(stmt, e.values)
+		stmt.WriteString(")")
+		return
+	}
+
+	stmt.WriteString(e.column + " && ")
+	stmt.AddVar(stmt, pq.Array(e.values))
+}
+
+type jsonTextExpression struct {
+	column string
+	key    string
+}
+
+// JSONText builds an expression extracting the value of a top level key of a JSON column as text
+func JSONText(column string, key string) clause.Expression {
+	return jsonTextExpression{column: column, key: key}
+}
+
+// Build implements clause.Expression
+func (e jsonTextExpression) Build(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		return
+	}
+
+	if stmt.Dialector.Name() == SQLiteDriver {
+		stmt.WriteString(fmt.Sprintf("JSON_EXTRACT(%s, ", e.column))
+		stmt.AddVar(stmt, "$."+e.key)
+		stmt.WriteString(")")
+		return
+	}
+
+	stmt.WriteString(e.column + "->>")
+	stmt.AddVar(stmt, e.key)
+}
+
+// TruncateTable removes all the rows of a table
+func TruncateTable(tx *gorm.DB, table string) error {
+	if tx.Dialector.Name() == SQLiteDriver {
+		return tx.Exec(fmt.Sprintf("DELETE FROM %s", table)).Error
+	}
+
+	return tx.Exec(fmt.Sprintf("TRUNCATE TABLE %s", table)).Error
+}
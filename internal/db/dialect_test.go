@@ -0,0 +1,24 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringArrayScan(t *testing.T) {
+	var postgresArray StringArray
+	assert.NoError(t, postgresArray.Scan([]byte(`{10.74.1.5,"with space"}`)))
+	assert.Equal(t, StringArray{"10.74.1.5", "with space"}, postgresArray)
+
+	var jsonArray StringArray
+	assert.NoError(t, jsonArray.Scan(`["10.74.1.5","with space"]`))
+	assert.Equal(t, StringArray{"10.74.1.5", "with space"}, jsonArray)
+
+	nullArray := StringArray{"value"}
+	assert.NoError(t, nullArray.Scan(nil))
+	assert.Nil(t, nullArray)
+
+	var invalid StringArray
+	assert.EqualError(t, invalid.Scan(1), "cannot convert int to StringArray")
+}
@@ -0,0 +1,243 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ErrSchemaTooNew is returned when the database schema has been migrated by a newer
+// version of Trento than the one currently running
+var ErrSchemaTooNew = errors.New("database schema is newer than the one supported by this version")
+
+// Migration is a versioned and reversible change to the database schema.
+// Versions must be unique and are applied in ascending order.
+type Migration struct {
+	Version     uint
+	Description string
+	Up          func(tx *gorm.DB) error
+	Down        func(tx *gorm.DB) error
+}
+
+// SchemaMigration tracks an applied migration in the schema_migrations table
+type SchemaMigration struct {
+	Version     uint `gorm:"primaryKey;autoIncrement:false"`
+	Description string
+	AppliedAt   time.Time
+}
+
+type MigrationStatus struct {
+	Version     uint
+	Description string
+	Applied     bool
+	AppliedAt   time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+func NewMigrator(db *gorm.DB, migrations []*Migration) (*Migrator, error) {
+	sorted := make([]*Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	for i, m := range sorted {
+		if m.Version == 0 {
+			return nil, fmt.Errorf("migration %q has an invalid version 0", m.Description)
+		}
+		if m.Up == nil || m.Down == nil {
+			return nil, fmt.Errorf("migration %d must define both up and down steps", m.Version)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicated migration version %d", m.Version)
+		}
+	}
+
+	return &Migrator{db: db, migrations: sorted}, nil
+}
+
+// LatestVersion returns the highest schema version known by this binary
+func (m *Migrator) LatestVersion() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// CurrentVersion returns the highest schema version applied to the database
+func (m *Migrator) CurrentVersion() (uint, error) {
+	if err := m.ensureMigrationsTable(); err != nil {
+		return 0, err
+	}
+
+	var version uint
+	err := m.db.Model(&SchemaMigration{}).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&version).
+		Error
+
+	return version, err
+}
+
+// CheckCompatibility makes sure the database schema is not ahead of this binary
+func (m *Migrator) CheckCompatibility() error {
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+
+	if current > m.LatestVersion() {
+		return fmt.Errorf("%w: database is at version %d, latest supported version is %d",
+			ErrSchemaTooNew, current, m.LatestVersion())
+	}
+
+	return nil
+}
+
+// Migrate applies all the pending migrations, each one in its own transaction
+func (m *Migrator) Migrate() ([]*Migration, error) {
+	if err := m.CheckCompatibility(); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var migrated []*Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		log.Infof("Applying migration %d: %s", migration.Version, migration.Description)
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+
+			return tx.Create(&SchemaMigration{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return migrated, fmt.Errorf("migration %d failed: %w", migration.Version, err)
+		}
+
+		migrated = append(migrated, migration)
+	}
+
+	return migrated, nil
+}
+
+// Rollback reverts the last applied migrations, up to the given number of steps
+func (m *Migrator) Rollback(steps int) ([]*Migration, error) {
+	if err := m.CheckCompatibility(); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var rolledBack []*Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		log.Infof("Rolling back migration %d: %s", migration.Version, migration.Description)
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return rolledBack, fmt.Errorf("rollback of migration %d failed: %w", migration.Version, err)
+		}
+
+		rolledBack = append(rolledBack, migration)
+	}
+
+	return rolledBack, nil
+}
+
+// Status returns the state of every known migration, plus the ones applied
+// to the database which are unknown to this binary
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var status []*MigrationStatus
+	for _, migration := range m.migrations {
+		s := &MigrationStatus{
+			Version:     migration.Version,
+			Description: migration.Description,
+		}
+		if a, ok := applied[migration.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = a.AppliedAt
+			delete(applied, migration.Version)
+		}
+		status = append(status, s)
+	}
+
+	for _, a := range applied {
+		status = append(status, &MigrationStatus{
+			Version:     a.Version,
+			Description: a.Description,
+			Applied:     true,
+			AppliedAt:   a.AppliedAt,
+		})
+	}
+
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Version < status[j].Version
+	})
+
+	return status, nil
+}
+
+func (m *Migrator) appliedVersions() (map[uint]*SchemaMigration, error) {
+	if err := m.ensureMigrationsTable(); err != nil {
+		return nil, err
+	}
+
+	var schemaMigrations []*SchemaMigration
+	if err := m.db.Order("version").Find(&schemaMigrations).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint]*SchemaMigration)
+	for _, s := range schemaMigrations {
+		applied[s.Version] = s
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) ensureMigrationsTable() error {
+	if m.db.Migrator().HasTable(&SchemaMigration{}) {
+		return nil
+	}
+
+	return m.db.Migrator().CreateTable(&SchemaMigration{})
+}
@@ -0,0 +1,161 @@
+package db_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/test/helpers"
+)
+
+type MigrateTestItem struct {
+	ID   int
+	Name string
+}
+
+func migrateTestMigrations() []*db.Migration {
+	return []*db.Migration{
+		{
+			Version:     2,
+			Description: "add migrate test items name index",
+			Up: func(tx *gorm.DB) error {
+				return tx.Exec("CREATE INDEX idx_migrate_test_items_name ON migrate_test_items (name)").Error
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Exec("DROP INDEX idx_migrate_test_items_name").Error
+			},
+		},
+		{
+			Version:     1,
+			Description: "create migrate test items",
+			Up: func(tx *gorm.DB) error {
+				return tx.Migrator().CreateTable(&MigrateTestItem{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&MigrateTestItem{})
+			},
+		},
+	}
+}
+
+func TestNewMigratorValidation(t *testing.T) {
+	noop := func(*gorm.DB) error { return nil }
+
+	_, err := db.NewMigrator(nil, []*db.Migration{
+		{Version: 1, Up: noop, Down: noop},
+		{Version: 1, Up: noop, Down: noop},
+	})
+	assert.EqualError(t, err, "duplicated migration version 1")
+
+	_, err = db.NewMigrator(nil, []*db.Migration{
+		{Version: 0, Description: "zero", Up: noop, Down: noop},
+	})
+	assert.EqualError(t, err, "migration \"zero\" has an invalid version 0")
+
+	_, err = db.NewMigrator(nil, []*db.Migration{
+		{Version: 1, Up: noop},
+	})
+	assert.EqualError(t, err, "migration 1 must define both up and down steps")
+
+	migrator, err := db.NewMigrator(nil, migrateTestMigrations())
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), migrator.LatestVersion())
+}
+
+type MigrateTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+	tx *gorm.DB
+}
+
+func TestMigrateTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateTestSuite))
+}
+
+func (suite *MigrateTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+}
+
+func (suite *MigrateTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+}
+
+func (suite *MigrateTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *MigrateTestSuite) TestMigrateAndRollback() {
+	migrator, err := db.NewMigrator(suite.tx, migrateTestMigrations())
+	suite.NoError(err)
+
+	version, err := migrator.CurrentVersion()
+	suite.NoError(err)
+	suite.Equal(uint(0), version)
+
+	migrated, err := migrator.Migrate()
+	suite.NoError(err)
+	suite.Len(migrated, 2)
+	suite.Equal(uint(1), migrated[0].Version)
+	suite.Equal(uint(2), migrated[1].Version)
+	suite.True(suite.tx.Migrator().HasTable(&MigrateTestItem{}))
+
+	migrated, err = migrator.Migrate()
+	suite.NoError(err)
+	suite.Len(migrated, 0)
+
+	rolledBack, err := migrator.Rollback(1)
+	suite.NoError(err)
+	suite.Len(rolledBack, 1)
+	suite.Equal(uint(2), rolledBack[0].Version)
+
+	version, err = migrator.CurrentVersion()
+	suite.NoError(err)
+	suite.Equal(uint(1), version)
+	suite.True(suite.tx.Migrator().HasTable(&MigrateTestItem{}))
+
+	rolledBack, err = migrator.Rollback(5)
+	suite.NoError(err)
+	suite.Len(rolledBack, 1)
+	suite.False(suite.tx.Migrator().HasTable(&MigrateTestItem{}))
+}
+
+func (suite *MigrateTestSuite) TestStatus() {
+	migrator, err := db.NewMigrator(suite.tx, migrateTestMigrations()[1:])
+	suite.NoError(err)
+
+	_, err = migrator.Migrate()
+	suite.NoError(err)
+
+	migrator, err = db.NewMigrator(suite.tx, migrateTestMigrations())
+	suite.NoError(err)
+
+	status, err := migrator.Status()
+	suite.NoError(err)
+	suite.Len(status, 2)
+	suite.Equal(uint(1), status[0].Version)
+	suite.True(status[0].Applied)
+	suite.Equal(uint(2), status[1].Version)
+	suite.False(status[1].Applied)
+}
+
+func (suite *MigrateTestSuite) TestSchemaTooNew() {
+	migrator, err := db.NewMigrator(suite.tx, migrateTestMigrations())
+	suite.NoError(err)
+
+	_, err = migrator.Migrate()
+	suite.NoError(err)
+
+	olderMigrator, err := db.NewMigrator(suite.tx, migrateTestMigrations()[1:])
+	suite.NoError(err)
+
+	err = olderMigrator.CheckCompatibility()
+	suite.ErrorIs(err, db.ErrSchemaTooNew)
+
+	_, err = olderMigrator.Migrate()
+	suite.ErrorIs(err, db.ErrSchemaTooNew)
+	suite.Equal(fmt.Sprintf("%s: database is at version 2, latest supported version is 1", db.ErrSchemaTooNew), err.Error())
+}
@@ -0,0 +1,67 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// FileSuffix is appended to a secret setting name to read its value from a file,
+// e.g. db-password-file, or TRENTO_DB_PASSWORD_FILE as environment variable
+const FileSuffix = "-file"
+
+// NewResolverFromConfig returns a resolver supporting the env and file references,
+// plus the vault ones when the vault-addr setting is provided
+func NewResolverFromConfig() (*Resolver, error) {
+	resolver := NewResolver()
+
+	address := viper.GetString("vault-addr")
+	if address == "" {
+		return resolver, nil
+	}
+
+	token, err := getSecret(resolver, "vault-token")
+	if err != nil {
+		return nil, err
+	}
+	resolver.Register("vault", NewVaultProvider(address, token))
+
+	return resolver, nil
+}
+
+// Get returns the secret configured by the given setting.
+// The <name>-file setting takes precedence, otherwise the <name> value is resolved as a secret reference.
+func Get(name string) (string, error) {
+	resolver, err := NewResolverFromConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return getSecret(resolver, name)
+}
+
+func getSecret(resolver *Resolver, name string) (string, error) {
+	if path := viper.GetString(name + FileSuffix); path != "" {
+		secret, err := ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("could not read %s from file: %w", name, err)
+		}
+		Track(secret)
+
+		return secret, nil
+	}
+
+	value := viper.GetString(name)
+	secret, err := resolver.Resolve(value)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve %s: %w", name, err)
+	}
+
+	// the plain values are only tracked when explicitly set for a sensitive setting,
+	// the defaults, like the postgres password, would mask unrelated words in the logs
+	if !resolver.IsReference(value) && IsSensitiveName(name) && viper.IsSet(name) {
+		Track(secret)
+	}
+
+	return secret, nil
+}
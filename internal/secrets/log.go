@@ -0,0 +1,33 @@
+package secrets
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RedactHook is a logrus hook masking the tracked secrets in the log messages and fields
+type RedactHook struct{}
+
+func (h *RedactHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *RedactHook) Fire(entry *log.Entry) error {
+	entry.Message = Redact(entry.Message)
+
+	for key, value := range entry.Data {
+		if IsSensitiveName(key) {
+			entry.Data[key] = redacted
+			continue
+		}
+		if _, ok := value.(error); ok {
+			continue
+		}
+		if s := fmt.Sprint(value); Redact(s) != s {
+			entry.Data[key] = Redact(s)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactHook(t *testing.T) {
+	var out bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&log.TextFormatter{DisableTimestamp: true})
+	logger.AddHook(&RedactHook{})
+
+	Track("hook-secret")
+
+	logger.WithField("db-password", "plain").
+		WithField("dsn", "host=db password=hook-secret").
+		WithField("host", "db").
+		WithError(errors.New("failure")).
+		Infof("connecting with hook-secret")
+
+	assert.Equal(t, "level=info msg=\"connecting with ******\" db-password=\"******\" dsn=\"******\" error=failure host=db\n", out.String())
+}
@@ -0,0 +1,150 @@
+package secrets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const redacted = "******"
+
+// Provider fetches a secret from a store, given a store specific reference
+type Provider interface {
+	Get(ref string) (string, error)
+}
+
+// Resolver resolves secret references in the form <scheme>:<reference>,
+// e.g. env:DB_PASSWORD, file:/run/secrets/db_password or vault:secret/trento#db_password.
+// Values without a registered scheme are plain secrets and are returned as they are.
+type Resolver struct {
+	providers map[string]Provider
+}
+
+func NewResolver() *Resolver {
+	r := &Resolver{providers: make(map[string]Provider)}
+	r.Register("env", &EnvProvider{})
+	r.Register("file", &FileProvider{})
+
+	return r
+}
+
+// Register adds a provider for the given reference scheme
+func (r *Resolver) Register(scheme string, provider Provider) {
+	r.providers[scheme] = provider
+}
+
+// Resolve returns the secret referenced by value, which is tracked so that it never ends up in the logs.
+// Plain values are returned untracked, as they are not necessarily secrets, e.g. file paths
+func (r *Resolver) Resolve(value string) (string, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return value, nil
+	}
+
+	provider, ok := r.providers[parts[0]]
+	if !ok {
+		return value, nil
+	}
+
+	secret, err := provider.Get(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("could not resolve %s secret: %w", parts[0], err)
+	}
+
+	Track(secret)
+
+	return secret, nil
+}
+
+// IsReference tells whether value is a reference to a secret of a registered store
+func (r *Resolver) IsReference(value string) bool {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	_, ok := r.providers[parts[0]]
+	return ok
+}
+
+// EnvProvider reads secrets from environment variables
+type EnvProvider struct{}
+
+func (p *EnvProvider) Get(ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+
+	return value, nil
+}
+
+// FileProvider reads secrets from files, e.g. docker or kubernetes mounted secrets
+type FileProvider struct{}
+
+func (p *FileProvider) Get(ref string) (string, error) {
+	return ReadFile(ref)
+}
+
+// ReadFile reads a secret from a file, trailing new lines are removed
+func ReadFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+var tracked = struct {
+	sync.RWMutex
+	values []string
+}{}
+
+// Track registers a secret value, which is then masked by Redact
+func Track(secret string) {
+	if secret == "" {
+		return
+	}
+
+	tracked.Lock()
+	defer tracked.Unlock()
+
+	for _, v := range tracked.values {
+		if v == secret {
+			return
+		}
+	}
+	tracked.values = append(tracked.values, secret)
+	// replace longer secrets first, in case a secret contains another one
+	sort.Slice(tracked.values, func(i, j int) bool {
+		return len(tracked.values[i]) > len(tracked.values[j])
+	})
+}
+
+// Redact masks all the tracked secrets in the given text
+func Redact(text string) string {
+	tracked.RLock()
+	defer tracked.RUnlock()
+
+	for _, secret := range tracked.values {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+
+	return text
+}
+
+// IsSensitiveName tells whether a variable or setting name likely holds a secret
+func IsSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, marker := range []string{"password", "passwd", "secret", "token", "dsn", "credential", "private_key", "private-key"} {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+
+	return false
+}
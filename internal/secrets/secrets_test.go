@@ -0,0 +1,113 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolverPlainValue(t *testing.T) {
+	secret, err := NewResolver().Resolve("plain:password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "plain:password", secret)
+}
+
+func TestResolverEnv(t *testing.T) {
+	os.Setenv("TRENTO_TEST_SECRET", "env-secret")
+	defer os.Unsetenv("TRENTO_TEST_SECRET")
+
+	secret, err := NewResolver().Resolve("env:TRENTO_TEST_SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, "env-secret", secret)
+
+	_, err = NewResolver().Resolve("env:TRENTO_TEST_MISSING_SECRET")
+	assert.EqualError(t, err, "could not resolve env secret: environment variable TRENTO_TEST_MISSING_SECRET is not set")
+}
+
+func TestResolverFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	ioutil.WriteFile(path, []byte("file-secret\n"), 0600)
+
+	secret, err := NewResolver().Resolve("file:" + path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "file-secret", secret)
+}
+
+func TestRedact(t *testing.T) {
+	Track("tracked-secret")
+	Track("tracked-secret-longer")
+
+	assert.Equal(t, "password=****** dsn=******", Redact("password=tracked-secret dsn=tracked-secret-longer"))
+	assert.Equal(t, "nothing to hide", Redact("nothing to hide"))
+}
+
+func TestIsSensitiveName(t *testing.T) {
+	assert.True(t, IsSensitiveName("db-password"))
+	assert.True(t, IsSensitiveName("TRENTO_VAULT_TOKEN"))
+	assert.True(t, IsSensitiveName("db-replica-dsn"))
+	assert.False(t, IsSensitiveName("TRENTO_WEB_API_HOST"))
+}
+
+func TestGet(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set("some-password", "flag-secret")
+	secret, err := Get("some-password")
+	assert.NoError(t, err)
+	assert.Equal(t, "flag-secret", secret)
+
+	path := filepath.Join(t.TempDir(), "secret")
+	ioutil.WriteFile(path, []byte("file-secret"), 0600)
+	viper.Set("some-password-file", path)
+
+	secret, err = Get("some-password")
+	assert.NoError(t, err)
+	assert.Equal(t, "file-secret", secret)
+	assert.Equal(t, "******", Redact("file-secret"))
+}
+
+func TestGetTracksOnlySecrets(t *testing.T) {
+	defer viper.Reset()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("default-password", "default-secret", "")
+	viper.BindPFlags(flags)
+	viper.Set("explicit-password", "explicit-secret")
+	viper.Set("key", "/etc/trento/plain-key-path.pem")
+	os.Setenv("TRENTO_TEST_REFERENCED_SECRET", "referenced-secret")
+	defer os.Unsetenv("TRENTO_TEST_REFERENCED_SECRET")
+	viper.Set("referenced-key", "env:TRENTO_TEST_REFERENCED_SECRET")
+
+	for _, name := range []string{"default-password", "explicit-password", "key", "referenced-key"} {
+		_, err := Get(name)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, "default-secret", Redact("default-secret"))
+	assert.Equal(t, "/etc/trento/plain-key-path.pem", Redact("/etc/trento/plain-key-path.pem"))
+	assert.Equal(t, "******", Redact("explicit-secret"))
+	assert.Equal(t, "******", Redact("referenced-secret"))
+}
+
+func TestGetFromVault(t *testing.T) {
+	defer viper.Reset()
+
+	server := newTestVaultServer(t)
+	defer server.Close()
+
+	viper.Set("vault-addr", server.URL)
+	viper.Set("vault-token", "test-token")
+	viper.Set("some-password", "vault:secret/trento#db_password")
+
+	secret, err := Get("some-password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "vault-secret", secret)
+}
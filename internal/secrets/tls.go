@@ -0,0 +1,22 @@
+package secrets
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"strings"
+)
+
+// LoadX509KeyPair loads a certificate from a file, along with its private key.
+// The key is either a file path or the PEM encoded key itself, as returned by a secret store.
+func LoadX509KeyPair(certFile string, key string) (tls.Certificate, error) {
+	if !strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+		return tls.LoadX509KeyPair(certFile, key)
+	}
+
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.X509KeyPair(certPEM, []byte(key))
+}
@@ -0,0 +1,81 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultProvider reads secrets from a HashiCorp Vault KV version 2 secrets engine.
+// References are in the form <mount>/<path>#<key>, e.g. secret/trento#db_password
+type VaultProvider struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+func NewVaultProvider(address string, token string) *VaultProvider {
+	return &VaultProvider{
+		address: strings.TrimRight(address, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (p *VaultProvider) Get(ref string) (string, error) {
+	parts := strings.SplitN(ref, "#", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", fmt.Errorf("invalid vault reference %s, expected <mount>/<path>#<key>", ref)
+	}
+	secretPath, key := parts[0], parts[1]
+
+	pathParts := strings.SplitN(secretPath, "/", 2)
+	if len(pathParts) != 2 || pathParts[1] == "" {
+		return "", fmt.Errorf("invalid vault reference %s, expected <mount>/<path>#<key>", ref)
+	}
+	mount, path := pathParts[0], pathParts[1]
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s/data/%s", p.address, mount, path), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var kvResponse vaultKVResponse
+	decodeErr := json.NewDecoder(resp.Body).Decode(&kvResponse)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded with status %d: %s", resp.StatusCode, strings.Join(kvResponse.Errors, ", "))
+	}
+
+	if decodeErr != nil {
+		return "", fmt.Errorf("could not decode the vault response: %w", decodeErr)
+	}
+
+	value, ok := kvResponse.Data.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in vault secret %s", key, secretPath)
+	}
+
+	secret, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %s of vault secret %s is not a string", key, secretPath)
+	}
+
+	return secret, nil
+}
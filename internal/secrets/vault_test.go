@@ -0,0 +1,72 @@
+package secrets
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestVaultServer emulates the KV version 2 API of a vault dev server
+func newTestVaultServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{"permission denied"}})
+			return
+		}
+
+		if r.URL.Path != "/v1/secret/data/trento" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{}})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]interface{}{
+					"db_password": "vault-secret",
+					"port":        5432,
+				},
+				"metadata": map[string]interface{}{"version": 1},
+			},
+		})
+	}))
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := newTestVaultServer(t)
+	defer server.Close()
+
+	secret, err := NewVaultProvider(server.URL+"/", "test-token").Get("secret/trento#db_password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "vault-secret", secret)
+}
+
+func TestVaultProviderErrors(t *testing.T) {
+	server := newTestVaultServer(t)
+	defer server.Close()
+
+	provider := NewVaultProvider(server.URL, "test-token")
+
+	_, err := provider.Get("secret/trento")
+	assert.EqualError(t, err, "invalid vault reference secret/trento, expected <mount>/<path>#<key>")
+
+	_, err = provider.Get("secret#db_password")
+	assert.EqualError(t, err, "invalid vault reference secret#db_password, expected <mount>/<path>#<key>")
+
+	_, err = provider.Get("secret/other#db_password")
+	assert.EqualError(t, err, "vault responded with status 404: ")
+
+	_, err = provider.Get("secret/trento#missing")
+	assert.EqualError(t, err, "key missing not found in vault secret secret/trento")
+
+	_, err = provider.Get("secret/trento#port")
+	assert.EqualError(t, err, "key port of vault secret secret/trento is not a string")
+
+	_, err = NewVaultProvider(server.URL, "wrong-token").Get("secret/trento#db_password")
+	assert.EqualError(t, err, "vault responded with status 403: permission denied")
+}
@@ -8,6 +8,7 @@ Trento Callback executor.
 :since: 2021-09-16
 """
 
+import json
 import os
 import yaml
 import requests
@@ -16,9 +17,13 @@ from ansible.plugins.callback import CallbackBase
 
 TRENTO_TEST_LABEL_KEY = "trento_labels"
 TRENTO_TEST_LABEL = "test"
+TRENTO_REMEDIATION_LABEL = "remediation"
+REMEDIATION_OUTPUT_ENV = "TRENTO_REMEDIATION_OUTPUT"
 TEST_RESULT_TASK_NAME = "set_test_result"
 TEST_INCLUDE_TASK_NAME = "run_checks"
 CHECK_ID = "id"
+PROGRESS_TIMEOUT = 2
+NOT_APPLICABLE_CHECKS_KEY = "cluster_not_applicable_checks_list"
 
 
 class Results(object):
@@ -124,9 +129,15 @@ class CallbackModule(CallbackBase):
         self.playbook = None
         self.play = None
         self.results = Results()
+        self.test_execution = False
+        self.remediation_execution = False
+        # Changes of the remediation tasks, by host and check id
+        self.diffs = {}
         host = os.getenv('TRENTO_WEB_API_HOST')
         port = os.getenv('TRENTO_WEB_API_PORT')
-        self._trento_api_url = "http://{}:{}".format(host, port)
+        scheme = os.getenv('TRENTO_WEB_API_SCHEME', 'http')
+        self._trento_api_url = "{}://{}:{}".format(scheme, host, port)
+        self._trento_api_options = self._get_api_options()
 
     def v2_playbook_on_start(self, playbook):
         """
@@ -142,6 +153,31 @@ class CallbackModule(CallbackBase):
         self.play = play
         self._initialize_results()
 
+        self.test_execution = self._is_test_execution()
+        self.remediation_execution = self._has_label(TRENTO_REMEDIATION_LABEL)
+        if self.test_execution:
+            for group in self.results.results["results"]:
+                self._post_progress(group, {"type": "started"})
+
+    def v2_runner_on_start(self, host, task):
+        """
+        On task start, publish the check running on the host
+        """
+        if not self.test_execution:
+            return
+
+        task_vars = self._all_vars(host=host, task=task)
+        if CHECK_ID not in task_vars:
+            return
+
+        for group in task_vars["group_names"]:
+            self._post_progress(group, {
+                "type": "task",
+                "host": host.get_name(),
+                "check_id": str(task_vars[CHECK_ID]),
+                "task": task.get_name()
+            })
+
     def v2_runner_on_ok(self, result):
         """
         On task Ok
@@ -162,6 +198,12 @@ class CallbackModule(CallbackBase):
             if self.results.result_exist(group, task_vars[CHECK_ID], host):
                 continue
             self.results.add_result(group, task_vars[CHECK_ID], host, test_result)
+            self._post_progress(group, {
+                "type": "result",
+                "host": host,
+                "check_id": str(task_vars[CHECK_ID]),
+                "result": test_result
+            })
 
     def v2_runner_on_failed(self, result, ignore_errors):
         """
@@ -178,6 +220,13 @@ class CallbackModule(CallbackBase):
         for group in task_vars["group_names"]:
             self.results.set_host_state(group, host, True)
             self.results.add_result(group, task_vars[CHECK_ID], host, "warning", msg)
+            self._post_progress(group, {
+                "type": "result",
+                "host": host,
+                "check_id": str(task_vars[CHECK_ID]),
+                "result": "warning",
+                "msg": msg
+            })
 
     def v2_runner_on_skipped(self, result):
         """
@@ -196,17 +245,44 @@ class CallbackModule(CallbackBase):
 
         for group in task_vars["group_names"]:
             self.results.set_host_state(group, host, False, msg)
+            self._post_progress(group, {"type": "unreachable", "host": host, "msg": msg})
+
+    def v2_on_file_diff(self, result):
+        """
+        On task diff, store the changes done by the remediation tasks, or the ones they would do in check mode
+        """
+        if not self.remediation_execution or "diff" not in result._result:
+            return
+
+        task_vars = self._all_vars(host=result._host, task=result._task)
+        if CHECK_ID not in task_vars:
+            return
+
+        diff = self._get_diff(result._result["diff"])
+        if not diff:
+            return
+
+        host_diffs = self.diffs.setdefault(result._host.get_name(), {})
+        check_id = str(task_vars[CHECK_ID])
+        host_diffs[check_id] = host_diffs.get(check_id, "") + diff
 
     def v2_playbook_on_stats(self, _stats):
         """
         Post results at the end of the execution
         """
+        if self.remediation_execution:
+            self._store_diffs()
+            return
+
         if not self._is_test_execution():
             return
 
         self._display.banner("Publishing Trento results")
         self._post_results(self.results.results)
 
+        for group in self.results.results["results"]:
+            self._post_progress(group, {"type": "completed"})
+
     def _all_vars(self, host=None, task=None):
         """
         Get task vars
@@ -240,6 +316,26 @@ class CallbackModule(CallbackBase):
             return False
         return True
 
+    def _has_label(self, label):
+        """
+        Check if the current execution has the trento label
+        """
+        play_vars = self._all_vars()
+        return label in play_vars.get(TRENTO_TEST_LABEL_KEY, [])
+
+    def _store_diffs(self):
+        """
+        Write the changes of the remediation to the file read by the trento runner
+        """
+        output = os.getenv(REMEDIATION_OUTPUT_ENV)
+        if not output:
+            self._display.warning("No remediation output file set, the changes are not reported")
+            return
+
+        with open(output, "w") as file_ptr:
+            json.dump(self.diffs, file_ptr)
+        self._display.banner("Remediation changes stored in {}".format(output))
+
     def _is_test_result(self, result):
         """
         Check if the current task is a test result
@@ -260,10 +356,11 @@ class CallbackModule(CallbackBase):
 
     def _store_skipped(self, result):
         """
-        Store skipped checks
+        Store skipped checks, the selected ones not applying to the environment as not applicable
         """
         task_vars = self._all_vars(host=result._host, task=result._task)
         host = result._host.get_name()
+        not_applicable_checks = task_vars.get(NOT_APPLICABLE_CHECKS_KEY, [])
 
         for check_result in result._result["results"]:
             skipped = check_result.get("skipped", False)
@@ -274,9 +371,35 @@ class CallbackModule(CallbackBase):
                     data = yaml.load(file_ptr, Loader=yaml.Loader)
                     check_id = data[CHECK_ID]
 
+                state = "skipped"
+                if str(check_id) in not_applicable_checks:
+                    state = "not_applicable"
+
                 for group in task_vars["group_names"]:
                     self.results.set_host_state(group, host, True)
-                    self.results.add_result(group, check_id, host, "skipped")
+                    self.results.add_result(group, check_id, host, state)
+
+    @staticmethod
+    def _get_api_options():
+        """
+        Get the requests options authenticating the runner to the trento web api server,
+        with the runner token or with the client certificate, and verifying the server certificate
+        """
+        options = {}
+
+        token = os.getenv('TRENTO_WEB_API_TOKEN')
+        if token:
+            options["headers"] = {"Authorization": "Bearer {}".format(token)}
+
+        ca_path = os.getenv('TRENTO_WEB_API_CA')
+        if ca_path:
+            options["verify"] = ca_path
+
+        cert = os.getenv('TRENTO_WEB_API_CERT')
+        if cert:
+            options["cert"] = (cert, os.getenv('TRENTO_WEB_API_KEY'))
+
+        return options
 
     def _post_results(self, results):
         """
@@ -284,6 +407,22 @@ class CallbackModule(CallbackBase):
         """
         for key, group in results["results"].items():
             url = "{}/api/checks/{}/results".format(self._trento_api_url, key)
-            response = requests.post(url, json=group)
+            response = requests.post(url, json=group, **self._trento_api_options)
             self._display.banner(
                 "Results of {} published. Return code is: {}".format(key, response.status_code))
+
+    def _post_progress(self, group, event):
+        """
+        Post a progress event of the group checks run to the trento web api server.
+        The progress is informative only, so the errors don't stop the execution
+        """
+        if not self.test_execution:
+            return
+
+        url = "{}/api/checks/{}/progress".format(self._trento_api_url, group)
+        try:
+            response = requests.post(
+                url, json=event, timeout=PROGRESS_TIMEOUT, **self._trento_api_options)
+            response.raise_for_status()
+        except requests.exceptions.RequestException as err:
+            self._display.vvv("Error publishing the progress of {}: {}".format(group, err))
@@ -2,16 +2,29 @@ package runner
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/trento-project/trento/internal/secrets"
 )
 
 const (
 	TrentoWebApiHost     = "TRENTO_WEB_API_HOST"
 	TrentoWebApiPort     = "TRENTO_WEB_API_PORT"
+	TrentoWebApiScheme   = "TRENTO_WEB_API_SCHEME"
+	TrentoWebApiCA       = "TRENTO_WEB_API_CA"
+	TrentoWebApiCert     = "TRENTO_WEB_API_CERT"
+	TrentoWebApiKey      = "TRENTO_WEB_API_KEY"
+	TrentoWebApiToken    = "TRENTO_WEB_API_TOKEN"
 	AnsibleConfigFileEnv = "ANSIBLE_CONFIG"
 )
 
@@ -26,6 +39,8 @@ type AnsibleRunner struct {
 	Inventory string
 	Envs      map[string]string
 	Check     bool
+	// Diff reports the changes done, or that would be done in check mode, by the tasks supporting it
+	Diff bool
 }
 
 func DefaultAnsibleRunner() *AnsibleRunner {
@@ -69,7 +84,44 @@ func (a *AnsibleRunner) SetTrentoApiData(host string, port int) {
 	a.setEnv(TrentoWebApiPort, fmt.Sprintf("%d", port))
 }
 
-func (a *AnsibleRunner) RunPlaybook() error {
+// SetTrentoApiAuth passes the TLS settings and the credentials of the runner to the trento callback
+// and to the roles calling the web server API
+func (a *AnsibleRunner) SetTrentoApiAuth(config *Config) {
+	if config.EnableApiTLS {
+		a.setEnv(TrentoWebApiScheme, "https")
+		if config.ApiCA != "" {
+			a.setEnv(TrentoWebApiCA, config.ApiCA)
+		}
+		if config.ApiCert != "" {
+			a.setEnv(TrentoWebApiCert, config.ApiCert)
+			a.setEnv(TrentoWebApiKey, apiKeyFile(config))
+		}
+	}
+
+	if config.ApiToken != "" {
+		a.setEnv(TrentoWebApiToken, config.ApiToken)
+	}
+}
+
+// PlaybookResult describes how an ansible-playbook execution went
+type PlaybookResult struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// ExitCode is -1 when the playbook could not be started
+	ExitCode int
+	// Stderr is an excerpt of the last lines written to the standard error
+	Stderr string
+	// HostErrors contains the unreachable hosts and the ones with failed tasks, taken from the play recap
+	HostErrors map[string]string
+}
+
+func (a *AnsibleRunner) RunPlaybook() (*PlaybookResult, error) {
+	return a.RunPlaybookContext(context.Background())
+}
+
+// RunPlaybookContext runs the playbook, killing it along with the processes it started when the context is done.
+// The returned error wraps the context error in that case
+func (a *AnsibleRunner) RunPlaybookContext(ctx context.Context) (*PlaybookResult, error) {
 	var cmdItems []string
 
 	log.Infof("Ansible playbook %s", a.Playbook)
@@ -85,47 +137,165 @@ func (a *AnsibleRunner) RunPlaybook() error {
 		cmdItems = append(cmdItems, "--check")
 	}
 
+	if a.Diff {
+		cmdItems = append(cmdItems, "--diff")
+	}
+
 	cmd := customExecCommand("ansible-playbook", cmdItems...)
+	// the playbook gets its own process group, so that its ssh connections can be killed along with it
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
 	cmd.Env = os.Environ()
 	for key, value := range a.Envs {
 		newEnv := fmt.Sprintf("%s=%s", key, value)
-		log.Debugf("New environment variable: %s", newEnv)
+		log.Debugf("New environment variable: %s", redactEnv(key, value))
 		cmd.Env = append(cmd.Env, newEnv)
 	}
 
-	logCommand(cmd)
-	err := cmd.Run()
+	result := &PlaybookResult{StartedAt: time.Now(), ExitCode: -1}
+
+	output, err := logCommand(cmd)
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err == nil {
+		stopKiller := killOnDone(ctx, cmd)
+		// the output must be fully read before waiting for the command
+		output.wait()
+		err = cmd.Wait()
+		stopKiller()
+
+		if err != nil && ctx.Err() != nil {
+			err = fmt.Errorf("%w: %s", ctx.Err(), err)
+		}
+	}
+
+	result.FinishedAt = time.Now()
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	if output != nil {
+		result.Stderr = output.stderrExcerpt()
+		result.HostErrors = output.hostErrors
+	}
 
 	if err != nil {
 		log.Errorf("An error occurred while running ansible: %s", err)
-		return err
+		if result.Stderr == "" {
+			result.Stderr = err.Error()
+		}
+		return result, err
 	}
 
 	log.Info("Ansible playbook execution finished successfully")
 
-	return nil
+	return result, nil
+}
+
+const (
+	stderrExcerptLines = 20
+	stderrExcerptBytes = 4096
+)
+
+// ansible-playbook prints a recap line per host at the end of the run, e.g.
+// vmhana01 : ok=3 changed=0 unreachable=1 failed=0 skipped=0 rescued=0 ignored=0
+var playRecapRegexp = regexp.MustCompile(`^(\S+)\s+:\s+ok=\d+\s+changed=\d+\s+unreachable=(\d+)\s+failed=(\d+)`)
+
+type commandOutput struct {
+	readers    sync.WaitGroup
+	stderr     []string
+	hostErrors map[string]string
+}
+
+func (o *commandOutput) wait() {
+	o.readers.Wait()
+}
+
+func (o *commandOutput) stderrExcerpt() string {
+	excerpt := strings.Join(o.stderr, "\n")
+	if len(excerpt) > stderrExcerptBytes {
+		excerpt = excerpt[len(excerpt)-stderrExcerptBytes:]
+	}
+
+	return excerpt
 }
 
-func logCommand(cmd *exec.Cmd) {
+func (o *commandOutput) parseRecap(line string) {
+	match := playRecapRegexp.FindStringSubmatch(line)
+	if match == nil {
+		return
+	}
+
+	switch {
+	case match[2] != "0":
+		o.hostErrors[match[1]] = "unreachable"
+	case match[3] != "0":
+		o.hostErrors[match[1]] = "failed"
+	}
+}
+
+// logCommand streams the command output into the logs, keeping what is needed to describe the execution
+func logCommand(cmd *exec.Cmd) (*commandOutput, error) {
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return
+		return nil, err
 	}
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
-		return
+		return nil, err
 	}
+
+	output := &commandOutput{hostErrors: make(map[string]string)}
+	output.readers.Add(2)
+
 	go func() {
+		defer output.readers.Done()
 		in := bufio.NewScanner(stdout)
 		for in.Scan() {
-			log.Infof(in.Text())
+			line := secrets.Redact(in.Text())
+			log.Info(line)
+			output.parseRecap(line)
 		}
 	}()
 	go func() {
+		defer output.readers.Done()
 		in := bufio.NewScanner(stderr)
 		for in.Scan() {
-			log.Debugf(in.Text())
+			line := secrets.Redact(in.Text())
+			log.Debug(line)
+			output.stderr = append(output.stderr, line)
+			if len(output.stderr) > stderrExcerptLines {
+				output.stderr = output.stderr[1:]
+			}
 		}
 	}()
+
+	return output, nil
+}
+
+// killOnDone kills the process group of the started command when the context is done,
+// until the returned function is called
+func killOnDone(ctx context.Context, cmd *exec.Cmd) func() {
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			log.Warnf("Killing the ansible playbook: %s", ctx.Err())
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
+
+func redactEnv(key, value string) string {
+	if secrets.IsSensitiveName(key) {
+		value = "******"
+	}
+
+	return secrets.Redact(fmt.Sprintf("%s=%s", key, value))
 }
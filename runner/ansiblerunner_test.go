@@ -1,11 +1,14 @@
 package runner
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/internal/secrets"
 	"github.com/trento-project/trento/runner/mocks"
 )
 
@@ -23,7 +26,7 @@ func TestRunPlaybookSimple(t *testing.T) {
 		cmd,
 	)
 
-	err := runnerInst.RunPlaybook()
+	_, err := runnerInst.RunPlaybook()
 
 	assert.Equal(t, os.Environ(), cmd.Env)
 	assert.NoError(t, err)
@@ -45,7 +48,7 @@ func TestRunPlaybookError(t *testing.T) {
 		cmd,
 	)
 
-	err := runnerInst.RunPlaybook()
+	_, err := runnerInst.RunPlaybook()
 
 	assert.Equal(t, os.Environ(), cmd.Env)
 	assert.EqualError(t, err, "exec: \"error\": executable file not found in $PATH")
@@ -77,7 +80,7 @@ func TestRunPlaybookComplex(t *testing.T) {
 
 	runnerInst.SetConfigFile("/path/myconfig.conf")
 
-	err := runnerInst.RunPlaybook()
+	_, err := runnerInst.RunPlaybook()
 
 	assert.Contains(t, cmd.Env, "env1=value1")
 	assert.Contains(t, cmd.Env, "env2=value2")
@@ -86,3 +89,88 @@ func TestRunPlaybookComplex(t *testing.T) {
 
 	mockCommand.AssertExpectations(t)
 }
+
+func TestRunPlaybookResult(t *testing.T) {
+
+	runnerInst := &AnsibleRunner{
+		Playbook: "superplay.yml",
+	}
+
+	script := `echo "PLAY RECAP *****"
+echo "node1 : ok=3 changed=0 unreachable=0 failed=0 skipped=0"
+echo "node2 : ok=0 changed=0 unreachable=1 failed=0 skipped=0"
+echo "node3 : ok=2 changed=0 unreachable=0 failed=1 skipped=0"
+echo "fatal: something went wrong" >&2
+exit 2`
+	cmd := exec.Command("sh", "-c", script)
+
+	mockCommand := new(mocks.CustomCommand)
+	customExecCommand = mockCommand.Execute
+	mockCommand.On("Execute", "ansible-playbook", "superplay.yml").Return(
+		cmd,
+	)
+
+	result, err := runnerInst.RunPlaybook()
+
+	assert.EqualError(t, err, "exit status 2")
+	assert.Equal(t, 2, result.ExitCode)
+	assert.Equal(t, "fatal: something went wrong", result.Stderr)
+	assert.Equal(t, map[string]string{"node2": "unreachable", "node3": "failed"}, result.HostErrors)
+	assert.False(t, result.FinishedAt.Before(result.StartedAt))
+
+	mockCommand.AssertExpectations(t)
+}
+
+func TestRunPlaybookNotStarted(t *testing.T) {
+
+	runnerInst := &AnsibleRunner{
+		Playbook: "superplay.yml",
+	}
+
+	mockCommand := new(mocks.CustomCommand)
+	customExecCommand = mockCommand.Execute
+	mockCommand.On("Execute", "ansible-playbook", "superplay.yml").Return(
+		exec.Command("error"),
+	)
+
+	result, err := runnerInst.RunPlaybook()
+
+	assert.Error(t, err)
+	assert.Equal(t, -1, result.ExitCode)
+	assert.Equal(t, "exec: \"error\": executable file not found in $PATH", result.Stderr)
+
+	mockCommand.AssertExpectations(t)
+}
+
+func TestRunPlaybookContextTimeout(t *testing.T) {
+
+	runnerInst := &AnsibleRunner{
+		Playbook: "superplay.yml",
+	}
+
+	// the background process keeps the output open, it must be killed along with the playbook
+	mockCommand := new(mocks.CustomCommand)
+	customExecCommand = mockCommand.Execute
+	mockCommand.On("Execute", "ansible-playbook", "superplay.yml").Return(
+		exec.Command("sh", "-c", "sleep 30 & wait"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	result, err := runnerInst.RunPlaybookContext(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, -1, result.ExitCode)
+	assert.Less(t, result.FinishedAt.Sub(result.StartedAt), 10*time.Second)
+
+	mockCommand.AssertExpectations(t)
+}
+
+func TestRedactEnv(t *testing.T) {
+	secrets.Track("tracked-secret")
+
+	assert.Equal(t, "TRENTO_WEB_API_HOST=localhost", redactEnv("TRENTO_WEB_API_HOST", "localhost"))
+	assert.Equal(t, "TRENTO_API_TOKEN=******", redactEnv("TRENTO_API_TOKEN", "plain-token"))
+	assert.Equal(t, "SOME_VAR=prefix-******", redactEnv("SOME_VAR", "prefix-tracked-secret"))
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// AnsibleCustomChecksFolder stores the roles of the custom checks. They are kept apart from the
+// embedded checks, so the meta playbook never publishes them as part of the built-in catalog
+const AnsibleCustomChecksFolder = "ansible/roles/custom_checks"
+
+// customCheckDefaults is the metadata of a custom check role, read by the checks playbook
+// and by the trento callback
+type customCheckDefaults struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Group       string `yaml:"group"`
+	Labels      string `yaml:"labels,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Remediation string `yaml:"remediation,omitempty"`
+}
+
+// syncCustomChecks replaces the custom checks roles with the ones currently defined in the server,
+// once the running playbooks finish. If the server can't be reached, the previous roles are kept
+func (c *Runner) syncCustomChecks() {
+	customChecks, err := c.trentoApi.GetCustomChecks()
+	if err != nil {
+		log.Errorf("Error getting the custom checks, running the last known ones: %s", err)
+		return
+	}
+
+	c.customChecksLock.Lock()
+	defer c.customChecksLock.Unlock()
+
+	if err := createCustomChecksRoles(c.config.AnsibleFolder, customChecks); err != nil {
+		log.Errorf("Error creating the custom checks roles: %s", err)
+	}
+}
+
+func createCustomChecksRoles(folder string, customChecks []*models.CustomCheck) error {
+	rolesFolder := path.Join(folder, AnsibleCustomChecksFolder)
+	if err := os.RemoveAll(rolesFolder); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(rolesFolder, 0755); err != nil {
+		return err
+	}
+
+	for _, customCheck := range customChecks {
+		// the checks stored before the implementations were restricted are not run anymore
+		if err := services.ValidateCustomCheckImplementation(customCheck.Implementation); err != nil {
+			log.Errorf("Skipping the custom check %s: %s", customCheck.ID, err)
+			continue
+		}
+
+		if err := createCustomCheckRole(path.Join(rolesFolder, customCheck.ID), customCheck); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createCustomCheckRole(roleFolder string, customCheck *models.CustomCheck) error {
+	defaults, err := yaml.Marshal(&customCheckDefaults{
+		ID:          customCheck.ID,
+		Name:        customCheck.Name,
+		Group:       customCheck.Group,
+		Labels:      customCheck.Labels,
+		Description: customCheck.Description,
+		Remediation: customCheck.Remediation,
+	})
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{
+		"defaults/main.yml": defaults,
+		"tasks/main.yml":    []byte(customCheck.Implementation),
+	}
+
+	for name, content := range files {
+		fileName := path.Join(roleFolder, name)
+		if err := os.MkdirAll(path.Dir(fileName), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(fileName, content, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	apiMocks "github.com/trento-project/trento/api/mocks"
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestSyncCustomChecks(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir(os.TempDir(), "trentotest")
+	defer os.RemoveAll(tmpDir)
+	createAnsibleFiles(tmpDir)
+
+	implementation := "- name: \"{{ name }}.check\"\n  debug:\n    msg: custom\n"
+
+	apiInst := new(apiMocks.TrentoApiService)
+	apiInst.On("GetCustomChecks").Return([]*models.CustomCheck{
+		{
+			ID:             "SITE01",
+			Version:        3,
+			Name:           "9.1.1",
+			Group:          "Site",
+			Description:    "max_connections is configured",
+			Implementation: implementation,
+		},
+		{
+			ID:             "SITE02",
+			Version:        1,
+			Name:           "9.1.2",
+			Group:          "Site",
+			Description:    "Stored before the implementations were restricted",
+			Implementation: "- name: run\n  shell: id\n",
+		},
+	}, nil).Once()
+	apiInst.On("GetCustomChecks").Return([]*models.CustomCheck{}, nil).Once()
+
+	r := &Runner{
+		config:    &Config{AnsibleFolder: tmpDir},
+		trentoApi: apiInst,
+	}
+
+	r.syncCustomChecks()
+
+	roleFolder := path.Join(tmpDir, AnsibleCustomChecksFolder, "SITE01")
+
+	tasks, err := ioutil.ReadFile(path.Join(roleFolder, "tasks/main.yml"))
+	assert.NoError(t, err)
+	assert.Equal(t, implementation, string(tasks))
+
+	content, err := ioutil.ReadFile(path.Join(roleFolder, "defaults/main.yml"))
+	assert.NoError(t, err)
+
+	var defaults map[string]string
+	assert.NoError(t, yaml.Unmarshal(content, &defaults))
+	assert.Equal(t, map[string]string{
+		"id":          "SITE01",
+		"name":        "9.1.1",
+		"group":       "Site",
+		"description": "max_connections is configured",
+	}, defaults)
+
+	assert.NoDirExists(t, path.Join(tmpDir, AnsibleCustomChecksFolder, "SITE02"))
+
+	// the built-in checks are untouched
+	assert.DirExists(t, path.Join(tmpDir, "ansible/roles/checks/1.1.1"))
+
+	r.syncCustomChecks()
+
+	assert.NoDirExists(t, roleFolder)
+	assert.DirExists(t, path.Join(tmpDir, "ansible/roles/checks/1.1.1"))
+}
@@ -2,12 +2,15 @@ package runner
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/trento-project/trento/api"
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/models"
 )
 
 type InventoryContent struct {
@@ -40,6 +43,15 @@ const (
 `
 	DefaultUser           string = "root"
 	clusterSelectedChecks string = "cluster_selected_checks"
+	// clusterNotApplicableChecks are the selected checks reported as not applicable instead of being run
+	clusterNotApplicableChecks string = "cluster_not_applicable_checks"
+	clusterCheckParameters     string = "cluster_check_parameters"
+	checkTargetType            string = "check_target_type"
+	ansiblePort                string = "ansible_port"
+	ansiblePrivateKeyFile      string = "ansible_ssh_private_key_file"
+	ansibleSSHCommonArgs       string = "ansible_ssh_common_args"
+	ansibleBecomeMethod        string = "ansible_become_method"
+	ansibleBecomeUser          string = "ansible_become_user"
 )
 
 func CreateInventory(destination string, content *InventoryContent) error {
@@ -58,15 +70,21 @@ func CreateInventory(destination string, content *InventoryContent) error {
 	return nil
 }
 
-func NewClusterInventoryContent(trentoApi api.TrentoApiService) (*InventoryContent, error) {
+// NewClusterInventoryContent builds the inventory of all the checks targets, or only of the given ones.
+// Each target is a group: a cluster, a standalone host or a SAP system with the hosts running its instances
+func NewClusterInventoryContent(trentoApi api.TrentoApiService, clusterIDs ...string) (*InventoryContent, error) {
 	content := &InventoryContent{}
 
-	clustersSettings, err := trentoApi.GetClustersSettings()
+	clustersSettings, err := trentoApi.GetChecksTargetsSettings()
 	if err != nil {
 		return nil, err
 	}
 
 	for _, cluster := range clustersSettings {
+		if len(clusterIDs) > 0 && !internal.Contains(clusterIDs, cluster.ID) {
+			continue
+		}
+
 		nodes := []*Node{}
 
 		jsonSelectedChecks, err := json.Marshal(cluster.SelectedChecks)
@@ -75,6 +93,26 @@ func NewClusterInventoryContent(trentoApi api.TrentoApiService) (*InventoryConte
 			continue
 		}
 
+		var jsonNotApplicableChecks []byte
+		if len(cluster.NotApplicableChecks) > 0 {
+			jsonNotApplicableChecks, err = json.Marshal(cluster.NotApplicableChecks)
+			if err != nil {
+				log.Errorf("error marshalling the cluster %s not applicable checks: %s", cluster.ID, err)
+				continue
+			}
+		}
+
+		// the parameters are quoted, as the inventory splits the variables on blanks
+		var quotedCheckParameters string
+		if len(cluster.CheckParameters) > 0 {
+			jsonCheckParameters, err := json.Marshal(cluster.CheckParameters)
+			if err != nil {
+				log.Errorf("error marshalling the cluster %s check parameters: %s", cluster.ID, err)
+				continue
+			}
+			quotedCheckParameters = "'" + string(jsonCheckParameters) + "'"
+		}
+
 		for _, host := range cluster.Hosts {
 			node := &Node{
 				Name:        host.Name,
@@ -84,6 +122,16 @@ func NewClusterInventoryContent(trentoApi api.TrentoApiService) (*InventoryConte
 			}
 
 			node.Variables[clusterSelectedChecks] = string(jsonSelectedChecks)
+			if jsonNotApplicableChecks != nil {
+				node.Variables[clusterNotApplicableChecks] = string(jsonNotApplicableChecks)
+			}
+			if quotedCheckParameters != "" {
+				node.Variables[clusterCheckParameters] = quotedCheckParameters
+			}
+			if cluster.TargetType != "" {
+				node.Variables[checkTargetType] = cluster.TargetType
+			}
+			addConnectionVariables(node, host)
 
 			nodes = append(nodes, node)
 		}
@@ -94,3 +142,22 @@ func NewClusterInventoryContent(trentoApi api.TrentoApiService) (*InventoryConte
 
 	return content, nil
 }
+
+// addConnectionVariables adds the connection profile of the host to the node variables
+func addConnectionVariables(node *Node, host *models.HostConnection) {
+	if host.Port != 0 {
+		node.Variables[ansiblePort] = host.Port
+	}
+	if host.PrivateKey != "" {
+		node.Variables[ansiblePrivateKeyFile] = host.PrivateKey
+	}
+	if host.ProxyJump != "" {
+		node.Variables[ansibleSSHCommonArgs] = fmt.Sprintf("'-o ProxyJump=%s'", host.ProxyJump)
+	}
+	if host.BecomeMethod != "" {
+		node.Variables[ansibleBecomeMethod] = host.BecomeMethod
+	}
+	if host.BecomeUser != "" {
+		node.Variables[ansibleBecomeUser] = host.BecomeUser
+	}
+}
@@ -97,7 +97,7 @@ func (suite *InventoryTestSuite) Test_CreateInventory() {
 func (suite *InventoryTestSuite) Test_NewClusterInventoryContent() {
 	apiInst := new(apiMocks.TrentoApiService)
 
-	apiInst.On("GetClustersSettings").Return(mockedClustersSettings(), nil)
+	apiInst.On("GetChecksTargetsSettings").Return(mockedClustersSettings(), nil)
 
 	content, err := NewClusterInventoryContent(apiInst)
 
@@ -130,7 +130,8 @@ func (suite *InventoryTestSuite) Test_NewClusterInventoryContent() {
 					&Node{
 						Name: "node3",
 						Variables: map[string]interface{}{
-							"cluster_selected_checks": "[\"check3\",\"check4\"]",
+							"cluster_selected_checks":  "[\"check3\",\"check4\"]",
+							"cluster_check_parameters": "'{\"1.1.1\":\"5000\",\"1.1.6\":\"udp ucast\"}'",
 						},
 						AnsibleHost: "192.168.10.3",
 						AnsibleUser: "clouduser",
@@ -138,7 +139,13 @@ func (suite *InventoryTestSuite) Test_NewClusterInventoryContent() {
 					&Node{
 						Name: "node4",
 						Variables: map[string]interface{}{
-							"cluster_selected_checks": "[\"check3\",\"check4\"]",
+							"cluster_selected_checks":      "[\"check3\",\"check4\"]",
+							"cluster_check_parameters":     "'{\"1.1.1\":\"5000\",\"1.1.6\":\"udp ucast\"}'",
+							"ansible_port":                 2222,
+							"ansible_ssh_private_key_file": "/etc/trento/keys/node4",
+							"ansible_ssh_common_args":      "'-o ProxyJump=admin@bastion:22'",
+							"ansible_become_method":        "sudo",
+							"ansible_become_user":          "root",
 						},
 						AnsibleHost: "",
 						AnsibleUser: "root",
@@ -153,6 +160,60 @@ func (suite *InventoryTestSuite) Test_NewClusterInventoryContent() {
 	apiInst.AssertExpectations(suite.T())
 }
 
+func (suite *InventoryTestSuite) Test_NewClusterInventoryContentFiltered() {
+	apiInst := new(apiMocks.TrentoApiService)
+
+	apiInst.On("GetChecksTargetsSettings").Return(mockedClustersSettings(), nil)
+
+	content, err := NewClusterInventoryContent(apiInst, "cluster2")
+
+	suite.NoError(err)
+	suite.Len(content.Groups, 1)
+	suite.Equal("cluster2", content.Groups[0].Name)
+	suite.Len(content.Groups[0].Nodes, 2)
+	apiInst.AssertExpectations(suite.T())
+}
+
+func (suite *InventoryTestSuite) Test_NewClusterInventoryContentCheckTargets() {
+	apiInst := new(apiMocks.TrentoApiService)
+
+	apiInst.On("GetChecksTargetsSettings").Return(webApi.ClustersSettingsResponse{
+		{
+			ID:                  "host1",
+			TargetType:          models.CheckTargetHost,
+			SelectedChecks:      []string{"check1"},
+			NotApplicableChecks: []string{"check2"},
+			Hosts: []*models.HostConnection{
+				{Name: "host1", Address: "192.168.10.5", User: "root"},
+			},
+		},
+	}, nil)
+
+	content, err := NewClusterInventoryContent(apiInst)
+
+	suite.NoError(err)
+	suite.Equal(&InventoryContent{
+		Groups: []*Group{
+			{
+				Name: "host1",
+				Nodes: []*Node{
+					{
+						Name:        "host1",
+						AnsibleHost: "192.168.10.5",
+						AnsibleUser: "root",
+						Variables: map[string]interface{}{
+							"cluster_selected_checks":       "[\"check1\"]",
+							"cluster_not_applicable_checks": "[\"check2\"]",
+							"check_target_type":             "host",
+						},
+					},
+				},
+			},
+		},
+	}, content)
+	apiInst.AssertExpectations(suite.T())
+}
+
 func mockedClustersSettings() webApi.ClustersSettingsResponse {
 	return webApi.ClustersSettingsResponse{
 		{
@@ -172,8 +233,9 @@ func mockedClustersSettings() webApi.ClustersSettingsResponse {
 			},
 		},
 		{
-			ID:             "cluster2",
-			SelectedChecks: []string{"check3", "check4"},
+			ID:              "cluster2",
+			SelectedChecks:  []string{"check3", "check4"},
+			CheckParameters: map[string]string{"1.1.1": "5000", "1.1.6": "udp ucast"},
 			Hosts: []*models.HostConnection{
 				{
 					Name:    "node3",
@@ -181,9 +243,14 @@ func mockedClustersSettings() webApi.ClustersSettingsResponse {
 					User:    "clouduser",
 				},
 				{
-					Name:    "node4",
-					Address: "",
-					User:    "root",
+					Name:         "node4",
+					Address:      "",
+					User:         "root",
+					Port:         2222,
+					PrivateKey:   "/etc/trento/keys/node4",
+					ProxyJump:    "admin@bastion:22",
+					BecomeMethod: "sudo",
+					BecomeUser:   "root",
 				},
 			},
 		},
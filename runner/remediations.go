@@ -0,0 +1,168 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/secrets"
+	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
+)
+
+const (
+	AnsibleRemediate = "ansible/remediate.yml"
+	// AnsibleRemediationsFolder stores the diffs written by the trento callback during the remediations
+	AnsibleRemediationsFolder = "ansible/remediations"
+	TrentoRemediationOutput   = "TRENTO_REMEDIATION_OUTPUT"
+)
+
+// NewAnsibleRemediationRunner runs the tasks of the checks to remediate, reporting their changes in the output file.
+// A preview runs them in check mode, so nothing is changed
+func NewAnsibleRemediationRunner(config *Config, preview bool, outputFile string) (*AnsibleRunner, error) {
+	playbookPath := path.Join(config.AnsibleFolder, AnsibleRemediate)
+
+	ansibleRunner := DefaultAnsibleRunner()
+
+	if err := ansibleRunner.SetPlaybook(playbookPath); err != nil {
+		return ansibleRunner, err
+	}
+
+	ansibleRunner.Check = preview
+	ansibleRunner.Diff = true
+	configFile := path.Join(config.AnsibleFolder, AnsibleConfigFile)
+	ansibleRunner.SetConfigFile(configFile)
+	ansibleRunner.SetTrentoApiData(config.ApiHost, config.ApiPort)
+	ansibleRunner.SetTrentoApiAuth(config)
+	ansibleRunner.setEnv(TrentoRemediationOutput, outputFile)
+
+	return ansibleRunner, nil
+}
+
+// startRemediationsPoller looks for the remediations to preview or to apply, requested from the web UI or API
+func (c *Runner) startRemediationsPoller() {
+	interval := c.config.ExecutionPollInterval
+	internal.Repeat("runner.remediations", c.runQueuedRemediations, interval, c.ctx)
+}
+
+// runQueuedRemediations drains the remediations queue, reporting the outcome of each one
+func (c *Runner) runQueuedRemediations() {
+	for c.ctx.Err() == nil {
+		remediation, err := c.trentoApi.ClaimRemediation(c.config.RunnerID)
+		if err != nil {
+			log.Errorf("Error claiming a remediation: %s", err)
+			return
+		}
+
+		if remediation == nil {
+			return
+		}
+
+		if remediation.IsPreview() {
+			log.Infof("Previewing remediation %d of checks %v on cluster %s", remediation.ID, remediation.Checks, remediation.ClusterID)
+		} else {
+			log.Infof("Applying remediation %d of checks %v on cluster %s", remediation.ID, remediation.Checks, remediation.ClusterID)
+		}
+
+		diff, result, err := c.runRemediation(remediation)
+		c.reportRemediation(remediation.ID, diff, result, err)
+	}
+}
+
+// runRemediation runs the remediation playbook on the cluster of the remediation, with the checks to remediate
+// as the only selected ones, and returns the changes reported by the tasks
+func (c *Runner) runRemediation(remediation *models.Remediation) (models.RemediationDiff, *PlaybookResult, error) {
+	content, err := NewClusterInventoryContent(c.trentoApi, remediation.ClusterID)
+	if err == nil && len(content.Groups) == 0 {
+		err = fmt.Errorf("no settings found for the cluster %s", remediation.ClusterID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := content.Groups[0]
+	if err := selectRemediationChecks(group, remediation.Checks); err != nil {
+		return nil, nil, err
+	}
+
+	outputFile := path.Join(c.config.AnsibleFolder, AnsibleRemediationsFolder, fmt.Sprintf("%d.json", remediation.ID))
+	if err := os.MkdirAll(path.Dir(outputFile), 0755); err != nil {
+		return nil, nil, err
+	}
+	defer os.Remove(outputFile)
+
+	remediationRunner, err := NewAnsibleRemediationRunner(c.config, remediation.IsPreview(), outputFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := c.runPlaybookJob(group, remediationRunner, "remediation")
+
+	diff, diffErr := readRemediationDiff(outputFile)
+	if diffErr != nil {
+		log.Errorf("Error reading the changes of remediation %d: %s", remediation.ID, diffErr)
+	}
+
+	return diff, result, err
+}
+
+// selectRemediationChecks replaces the selected checks of the cluster with the ones to remediate
+func selectRemediationChecks(group *Group, checks []string) error {
+	jsonChecks, err := json.Marshal(checks)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range group.Nodes {
+		node.Variables[clusterSelectedChecks] = string(jsonChecks)
+		delete(node.Variables, clusterNotApplicableChecks)
+	}
+
+	return nil
+}
+
+// readRemediationDiff reads the changes written by the trento callback, nothing changed when there is no file
+func readRemediationDiff(outputFile string) (models.RemediationDiff, error) {
+	content, err := ioutil.ReadFile(outputFile)
+	if os.IsNotExist(err) {
+		return models.RemediationDiff{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff := models.RemediationDiff{}
+	if err := json.Unmarshal(content, &diff); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// reportRemediation sends the outcome of a remediation run to the server
+func (c *Runner) reportRemediation(id int64, diff models.RemediationDiff, result *PlaybookResult, runErr error) {
+	report := &webApi.JSONRemediationResult{Diff: diff}
+
+	if runErr != nil {
+		log.Errorf("Remediation %d failed: %s", id, runErr)
+		report.Failed = true
+		report.Stderr = secrets.Redact(runErr.Error())
+	}
+
+	if result != nil {
+		exitCode := result.ExitCode
+		report.ExitCode = &exitCode
+		if result.Stderr != "" {
+			report.Stderr = result.Stderr
+		}
+	}
+
+	if err := c.trentoApi.UpdateRemediation(id, report); err != nil {
+		log.Errorf("Error updating the remediation %d: %s", id, err)
+	}
+}
@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apiMocks "github.com/trento-project/trento/api/mocks"
+	"github.com/trento-project/trento/runner/mocks"
+	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestNewAnsibleRemediationRunner(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir(os.TempDir(), "trentotest")
+	defer os.RemoveAll(tmpDir)
+	createAnsibleFiles(tmpDir)
+
+	cfg := &Config{
+		ApiHost:       "127.0.0.1",
+		ApiPort:       8000,
+		AnsibleFolder: tmpDir,
+	}
+
+	a, err := NewAnsibleRemediationRunner(cfg, true, "/tmp/remediation.json")
+
+	expectedRemediationRunner := &AnsibleRunner{
+		Playbook: path.Join(tmpDir, "ansible/remediate.yml"),
+		Envs: map[string]string{
+			"ANSIBLE_CONFIG":            path.Join(tmpDir, "ansible/ansible.cfg"),
+			"TRENTO_WEB_API_HOST":       "127.0.0.1",
+			"TRENTO_WEB_API_PORT":       "8000",
+			"TRENTO_REMEDIATION_OUTPUT": "/tmp/remediation.json",
+		},
+		Check: true,
+		Diff:  true,
+	}
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRemediationRunner, a)
+}
+
+func TestRunQueuedRemediations(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir(os.TempDir(), "trentotest")
+	defer os.RemoveAll(tmpDir)
+	createAnsibleFiles(tmpDir)
+
+	apiInst := new(apiMocks.TrentoApiService)
+	apiInst.On("ClaimRemediation", "").Return(&models.Remediation{
+		ID: 1, ClusterID: "cluster1", Checks: []string{"156F64"}, Status: models.RemediationPreviewing,
+	}, nil).Once()
+	apiInst.On("ClaimRemediation", "").Return(&models.Remediation{
+		ID: 2, ClusterID: "cluster2", Checks: []string{"156F64"}, Status: models.RemediationApplying,
+	}, nil).Once()
+	apiInst.On("ClaimRemediation", "").Return(&models.Remediation{
+		ID: 3, ClusterID: "unknown", Checks: []string{"156F64"}, Status: models.RemediationApplying,
+	}, nil).Once()
+	apiInst.On("ClaimRemediation", "").Return(nil, nil).Once()
+	apiInst.On("GetChecksTargetsSettings").Return(mockedClustersSettings(), nil)
+
+	exitCode := 0
+	apiInst.On("UpdateRemediation", int64(1), &webApi.JSONRemediationResult{
+		Diff:     models.RemediationDiff{"node1": {"156F64": "+token: 30000\n"}},
+		ExitCode: &exitCode,
+	}).Return(nil)
+	apiInst.On("UpdateRemediation", int64(2), mock.MatchedBy(func(r *webApi.JSONRemediationResult) bool {
+		return r.Failed && *r.ExitCode == 2 && len(r.Diff) == 0
+	})).Return(nil)
+	apiInst.On("UpdateRemediation", int64(3), &webApi.JSONRemediationResult{
+		Failed: true,
+		Stderr: "no settings found for the cluster unknown",
+	}).Return(nil)
+
+	// only the checks to remediate are selected, the preview runs in check mode
+	inventoryFile := clusterInventoryFile(tmpDir, "cluster1")
+	mockCommand := new(mocks.CustomCommand)
+	customExecCommand = mockCommand.Execute
+	mockCommand.On("Execute", "ansible-playbook", path.Join(tmpDir, AnsibleRemediate),
+		"--inventory="+inventoryFile, "--check", "--diff").Return(
+		exec.Command("sh", "-c", "grep -q 'cluster_selected_checks=\\[\"156F64\"\\]' "+inventoryFile+
+			" && printf '%s' '{\"node1\":{\"156F64\":\"+token: 30000\\n\"}}' > $TRENTO_REMEDIATION_OUTPUT")).Once()
+	mockCommand.On("Execute", "ansible-playbook", path.Join(tmpDir, AnsibleRemediate),
+		"--inventory="+clusterInventoryFile(tmpDir, "cluster2"), "--diff").Return(
+		exec.Command("sh", "-c", "exit 2")).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := &Runner{
+		config:    &Config{ApiHost: "127.0.0.1", ApiPort: 8000, AnsibleFolder: tmpDir},
+		ctx:       ctx,
+		trentoApi: apiInst,
+	}
+
+	r.runQueuedRemediations()
+
+	assert.NoFileExists(t, inventoryFile)
+	assert.NoFileExists(t, path.Join(tmpDir, AnsibleRemediationsFolder, "1.json"))
+
+	apiInst.AssertExpectations(t)
+	mockCommand.AssertExpectations(t)
+}
@@ -3,10 +3,14 @@ package runner
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
+	"io/ioutil"
+	"net/url"
 	"os"
 	"path"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,6 +19,9 @@ import (
 
 	"github.com/trento-project/trento/api"
 	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/secrets"
+	webApi "github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/models"
 )
 
 //go:embed ansible
@@ -24,7 +31,14 @@ const (
 	AnsibleMain       = "ansible/check.yml"
 	AnsibleMeta       = "ansible/meta.yml"
 	AnsibleConfigFile = "ansible/ansible.cfg"
-	AnsibleHostFile   = "ansible/ansible_hosts"
+	// AnsibleInventoriesFolder stores the inventory of each cluster being checked
+	AnsibleInventoriesFolder = "ansible/inventories"
+	// AnsibleApiKeyFile stores the key of the runner client certificate when it comes from a secret store,
+	// ansible only reading it from a file
+	AnsibleApiKeyFile  = "ansible/api-client.key"
+	DefaultConcurrency = 4
+	// HeartbeatInterval is how often the runner renews its leases
+	HeartbeatInterval = 30 * time.Second
 )
 
 type Runner struct {
@@ -32,13 +46,40 @@ type Runner struct {
 	ctx       context.Context
 	ctxCancel context.CancelFunc
 	trentoApi api.TrentoApiService
+	// customChecksLock keeps the custom checks roles from being replaced while the playbooks run
+	customChecksLock sync.RWMutex
+	// clusterLocks serializes the executions of each cluster, it maps the cluster ids to mutexes
+	clusterLocks sync.Map
+	// leasesLock guards the clusters leased to the runner and the time the leases expire
+	leasesLock     sync.RWMutex
+	leasedClusters []string
+	leasesExpireAt time.Time
 }
 
 type Config struct {
-	ApiHost       string
-	ApiPort       int
-	Interval      time.Duration
-	AnsibleFolder string
+	ApiHost               string
+	ApiPort               int
+	Interval              time.Duration
+	ExecutionPollInterval time.Duration
+	AnsibleFolder         string
+	// Concurrency is the maximum number of clusters checked at the same time by the periodic runs
+	Concurrency int
+	// JobTimeout is the maximum duration of the checks execution of a cluster, there is no limit when zero
+	JobTimeout time.Duration
+	// RunnerID identifies the runner in the server, which leases it the clusters to check.
+	// Without id the runner checks all the clusters, as the only runner of the installation
+	RunnerID string
+	// Tags and ClusterIDs restrict the clusters leased to the runner, any cluster is leased when both are empty
+	Tags       []string
+	ClusterIDs []string
+	// EnableApiTLS connects to the web server API over TLS, verifying its certificate with ApiCA,
+	// or with the system certificates when empty
+	EnableApiTLS bool
+	ApiCA        string
+	// ApiToken or the ApiCert client certificate, with its ApiKey, authenticate the runner to the web server API
+	ApiCert  string
+	ApiKey   string
+	ApiToken string
 }
 
 func NewRunner(config *Config) (*Runner, error) {
@@ -60,10 +101,25 @@ func (c *Runner) Start() error {
 		return err
 	}
 
-	var trentoApi api.TrentoApiService
-	err := retryGo.Do(
+	if err := writeApiKeyFile(c.config); err != nil {
+		return err
+	}
+
+	trentoApi, err := api.NewTrentoApiServiceWithConfig(&api.Config{
+		Host:      c.config.ApiHost,
+		Port:      c.config.ApiPort,
+		EnableTLS: c.config.EnableApiTLS,
+		CA:        c.config.ApiCA,
+		Cert:      c.config.ApiCert,
+		Key:       c.config.ApiKey,
+		Token:     c.config.ApiToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = retryGo.Do(
 		func() error {
-			trentoApi = api.NewTrentoApiService(c.config.ApiHost, c.config.ApiPort)
 			if !trentoApi.IsWebServerUp() {
 				return fmt.Errorf("Trento server api not available")
 			}
@@ -89,10 +145,23 @@ func (c *Runner) Start() error {
 		return err
 	}
 
-	if err = metaRunner.RunPlaybook(); err != nil {
+	if _, err = metaRunner.RunPlaybook(); err != nil {
 		return err
 	}
 
+	if c.config.RunnerID != "" {
+		// the leases are acquired before the first run, otherwise it would have nothing to check
+		c.heartbeat()
+
+		wg.Add(1)
+		go func(wg *sync.WaitGroup) {
+			log.Infof("Starting the heartbeat loop of runner %s...", c.config.RunnerID)
+			defer wg.Done()
+			c.startHeartbeat()
+			log.Println("Heartbeat loop stopped.")
+		}(&wg)
+	}
+
 	wg.Add(1)
 	go func(wg *sync.WaitGroup) {
 		log.Println("Starting the runner loop...")
@@ -101,8 +170,30 @@ func (c *Runner) Start() error {
 		log.Println("Runner loop stopped.")
 	}(&wg)
 
+	wg.Add(1)
+	go func(wg *sync.WaitGroup) {
+		log.Println("Starting the on-demand executions loop...")
+		defer wg.Done()
+		c.startExecutionsPoller()
+		log.Println("On-demand executions loop stopped.")
+	}(&wg)
+
+	wg.Add(1)
+	go func(wg *sync.WaitGroup) {
+		log.Println("Starting the remediations loop...")
+		defer wg.Done()
+		c.startRemediationsPoller()
+		log.Println("Remediations loop stopped.")
+	}(&wg)
+
 	wg.Wait()
 
+	if c.config.RunnerID != "" {
+		if err := c.trentoApi.ReleaseRunnerLeases(c.config.RunnerID); err != nil {
+			log.Errorf("Error releasing the leases of runner %s: %s", c.config.RunnerID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -159,6 +250,29 @@ func createAnsibleFiles(folder string) error {
 	return nil
 }
 
+// writeApiKeyFile writes the key of the client certificate to a file readable by the runner only,
+// when it is not a file already
+func writeApiKeyFile(config *Config) error {
+	if !isPEMKey(config.ApiKey) {
+		return nil
+	}
+
+	return ioutil.WriteFile(apiKeyFile(config), []byte(config.ApiKey), 0600)
+}
+
+// apiKeyFile returns the path of the client certificate key
+func apiKeyFile(config *Config) string {
+	if !isPEMKey(config.ApiKey) {
+		return config.ApiKey
+	}
+
+	return path.Join(config.AnsibleFolder, AnsibleApiKeyFile)
+}
+
+func isPEMKey(key string) bool {
+	return strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN")
+}
+
 func NewAnsibleMetaRunner(config *Config) (*AnsibleRunner, error) {
 	playbookPath := path.Join(config.AnsibleFolder, AnsibleMeta)
 	ansibleRunner := DefaultAnsibleRunner()
@@ -170,6 +284,7 @@ func NewAnsibleMetaRunner(config *Config) (*AnsibleRunner, error) {
 	configFile := path.Join(config.AnsibleFolder, AnsibleConfigFile)
 	ansibleRunner.SetConfigFile(configFile)
 	ansibleRunner.SetTrentoApiData(config.ApiHost, config.ApiPort)
+	ansibleRunner.SetTrentoApiAuth(config)
 
 	return ansibleRunner, nil
 }
@@ -187,37 +302,268 @@ func NewAnsibleCheckRunner(config *Config) (*AnsibleRunner, error) {
 	configFile := path.Join(config.AnsibleFolder, AnsibleConfigFile)
 	ansibleRunner.SetConfigFile(configFile)
 	ansibleRunner.SetTrentoApiData(config.ApiHost, config.ApiPort)
+	ansibleRunner.SetTrentoApiAuth(config)
 
 	return ansibleRunner, nil
 }
 
 func (c *Runner) startCheckRunnerTicker() {
-	checkRunner, err := NewAnsibleCheckRunner(c.config)
+	tick := func() {
+		c.runScheduledChecks()
+	}
+
+	interval := c.config.Interval
+	internal.Repeat("runner.ansible_playbook", tick, interval, c.ctx)
+}
+
+// startExecutionsPoller looks for on-demand checks executions requested from the web UI or API,
+// running them right away instead of waiting for the next tick
+func (c *Runner) startExecutionsPoller() {
+	interval := c.config.ExecutionPollInterval
+	internal.Repeat("runner.checks_executions", c.runQueuedExecutions, interval, c.ctx)
+}
+
+func (c *Runner) startHeartbeat() {
+	ticker := time.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.heartbeat()
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// heartbeat renews the leases of the runner, storing the clusters it is allowed to check.
+// When the server is not reachable the current leases are kept until they expire
+func (c *Runner) heartbeat() {
+	leases, err := c.trentoApi.RunnerHeartbeat(c.config.RunnerID, &webApi.JSONRunnerHeartbeat{
+		Tags:       c.config.Tags,
+		ClusterIDs: c.config.ClusterIDs,
+	})
 	if err != nil {
+		log.Errorf("Error sending the heartbeat of runner %s: %s", c.config.RunnerID, err)
 		return
 	}
 
-	tick := func() {
-		content, err := NewClusterInventoryContent(c.trentoApi)
-		if err != nil {
-			log.Errorf("Error creating the ansible inventory content: %s", err)
+	clusterIDs := []string{}
+	for _, lease := range leases.Leases {
+		clusterIDs = append(clusterIDs, lease.ClusterID)
+	}
+
+	c.leasesLock.Lock()
+	defer c.leasesLock.Unlock()
+
+	c.leasedClusters = clusterIDs
+	c.leasesExpireAt = time.Now().Add(time.Duration(leases.LeaseDuration) * time.Second)
+	log.Debugf("Clusters leased to runner %s: %v", c.config.RunnerID, clusterIDs)
+}
+
+// getLeasedClusters returns the ids of the clusters leased to the runner, none once the leases expired
+func (c *Runner) getLeasedClusters() []string {
+	c.leasesLock.RLock()
+	defer c.leasesLock.RUnlock()
+
+	if time.Now().After(c.leasesExpireAt) {
+		return nil
+	}
+
+	return c.leasedClusters
+}
+
+// runScheduledChecks runs the checks of all the clusters, or of the clusters leased to the runner,
+// as independent jobs recording an execution each.
+// Up to Concurrency clusters are checked at the same time, and each one is reported as soon as it finishes
+func (c *Runner) runScheduledChecks() {
+	var clusterIDs []string
+	if c.config.RunnerID != "" {
+		clusterIDs = c.getLeasedClusters()
+		if len(clusterIDs) == 0 {
+			log.Infof("No clusters leased to runner %s, there are no checks to run", c.config.RunnerID)
 			return
 		}
+	}
+
+	content, err := NewClusterInventoryContent(c.trentoApi, clusterIDs...)
+	if err != nil {
+		log.Errorf("Error creating the ansible inventory content: %s", err)
+		return
+	}
+
+	c.syncCustomChecks()
+
+	concurrency := c.config.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	jobs := make(chan *Group)
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range jobs {
+				c.runScheduledClusterChecks(group)
+			}
+		}()
+	}
+
+schedule:
+	for _, group := range content.Groups {
+		select {
+		case jobs <- group:
+		case <-c.ctx.Done():
+			log.Infof("Runner stopped, the checks of the remaining clusters are not run")
+			break schedule
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+}
+
+func (c *Runner) runScheduledClusterChecks(group *Group) {
+	execution, err := c.trentoApi.StartChecksExecution(group.Name, models.ChecksExecutionScheduled)
+	if err != nil {
+		log.Errorf("Error recording the checks execution of cluster %s: %s", group.Name, err)
+	}
+
+	result, runErr := c.runClusterJob(group)
 
-		inventoryFile := path.Join(c.config.AnsibleFolder, AnsibleHostFile)
-		err = CreateInventory(inventoryFile, content)
+	if execution != nil {
+		c.reportExecution(execution.ID, group, result, runErr)
+	}
+}
+
+// runQueuedExecutions drains the on-demand executions queue, reporting the outcome of each one
+func (c *Runner) runQueuedExecutions() {
+	for c.ctx.Err() == nil {
+		execution, err := c.trentoApi.ClaimChecksExecution(c.config.RunnerID)
 		if err != nil {
-			log.Errorf("Error creating the ansible inventory file")
+			log.Errorf("Error claiming an on-demand checks execution: %s", err)
 			return
 		}
 
-		if err = checkRunner.SetInventory(inventoryFile); err != nil {
+		if execution == nil {
 			return
 		}
 
-		checkRunner.RunPlaybook()
+		log.Infof("Running on-demand checks execution %d for cluster %s", execution.ID, execution.ClusterID)
+		c.runClusterChecks(execution)
 	}
+}
 
-	interval := c.config.Interval
-	internal.Repeat("runner.ansible_playbook", tick, interval, c.ctx)
+func (c *Runner) runClusterChecks(execution *webApi.JSONChecksExecution) {
+	content, err := NewClusterInventoryContent(c.trentoApi, execution.ClusterID)
+	if err == nil && len(content.Groups) == 0 {
+		err = fmt.Errorf("no settings found for the cluster %s", execution.ClusterID)
+	}
+	if err != nil {
+		c.reportExecution(execution.ID, nil, nil, err)
+		return
+	}
+
+	c.syncCustomChecks()
+
+	result, err := c.runClusterJob(content.Groups[0])
+	c.reportExecution(execution.ID, content.Groups[0], result, err)
+}
+
+// runClusterJob runs the checks playbook on a single cluster
+func (c *Runner) runClusterJob(group *Group) (*PlaybookResult, error) {
+	checkRunner, err := NewAnsibleCheckRunner(c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.runPlaybookJob(group, checkRunner, "checks execution")
+}
+
+// runPlaybookJob runs a playbook on a single cluster, with its own inventory. The playbook is killed
+// when it lasts more than JobTimeout or when the runner is stopped
+func (c *Runner) runPlaybookJob(group *Group, playbookRunner *AnsibleRunner, job string) (*PlaybookResult, error) {
+	lock, _ := c.clusterLocks.LoadOrStore(group.Name, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	c.customChecksLock.RLock()
+	defer c.customChecksLock.RUnlock()
+
+	ctx := c.ctx
+	if c.config.JobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.JobTimeout)
+		defer cancel()
+	}
+
+	inventoryFile := clusterInventoryFile(c.config.AnsibleFolder, group.Name)
+	if err := os.MkdirAll(path.Dir(inventoryFile), 0755); err != nil {
+		return nil, err
+	}
+	defer os.Remove(inventoryFile)
+
+	err := CreateInventory(inventoryFile, &InventoryContent{Groups: []*Group{group}})
+	if err != nil {
+		log.Errorf("Error creating the ansible inventory file of cluster %s", group.Name)
+		return nil, err
+	}
+
+	if err = playbookRunner.SetInventory(inventoryFile); err != nil {
+		return nil, err
+	}
+
+	result, err := playbookRunner.RunPlaybookContext(ctx)
+	if err != nil && ctx.Err() != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("the %s of cluster %s timed out after %s", job, group.Name, c.config.JobTimeout)
+		}
+		// why the playbook was killed matters more than what it wrote until then
+		result.Stderr = err.Error()
+	}
+
+	return result, err
+}
+
+func clusterInventoryFile(folder string, clusterID string) string {
+	return path.Join(folder, AnsibleInventoriesFolder, url.PathEscape(clusterID))
+}
+
+// reportExecution sends the outcome of a checks execution to the server,
+// only the errors of the hosts belonging to the execution cluster are reported
+func (c *Runner) reportExecution(id int64, group *Group, result *PlaybookResult, runErr error) {
+	report := &webApi.JSONChecksExecutionResult{Status: models.ChecksExecutionCompleted}
+
+	if runErr != nil {
+		log.Errorf("Checks execution %d failed: %s", id, runErr)
+		report.Status = models.ChecksExecutionFailed
+		report.Stderr = secrets.Redact(runErr.Error())
+	}
+
+	if result != nil {
+		exitCode := result.ExitCode
+		report.ExitCode = &exitCode
+		if result.Stderr != "" {
+			report.Stderr = result.Stderr
+		}
+	}
+
+	if result != nil && group != nil {
+		for _, node := range group.Nodes {
+			if hostError, ok := result.HostErrors[node.Name]; ok {
+				if report.HostErrors == nil {
+					report.HostErrors = make(map[string]string)
+				}
+				report.HostErrors[node.Name] = hostError
+			}
+		}
+	}
+
+	if err := c.trentoApi.UpdateChecksExecution(id, report); err != nil {
+		log.Errorf("Error updating the checks execution %d: %s", id, err)
+	}
 }
@@ -8,6 +8,7 @@ import (
 
 func init() {
 	viper.SetDefault("db-integration-tests", true)
+	viper.SetDefault("db-driver", "postgres")
 	viper.SetDefault("db-host", "localhost")
 	viper.SetDefault("db-port", "5432")
 	viper.SetDefault("db-user", "postgres")
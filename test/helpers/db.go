@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -8,6 +9,8 @@ import (
 	"gorm.io/gorm"
 )
 
+// SetupTestDatabase opens a connection to the test database.
+// The engine is selected with the db-driver setting, so the same suites run against PostgreSQL and SQLite
 func SetupTestDatabase(t *testing.T) *gorm.DB {
 	testEnabled := viper.GetBool("db-integration-tests")
 	if !testEnabled {
@@ -15,6 +18,7 @@ func SetupTestDatabase(t *testing.T) *gorm.DB {
 	}
 
 	dbConfig := &db.Config{
+		Driver:   viper.GetString("db-driver"),
 		Host:     viper.GetString("db-host"),
 		Port:     viper.GetInt("db-port"),
 		User:     viper.GetString("db-user"),
@@ -22,6 +26,10 @@ func SetupTestDatabase(t *testing.T) *gorm.DB {
 		DBName:   viper.GetString("db-name"),
 	}
 
+	if dbConfig.Driver == db.SQLiteDriver {
+		dbConfig.Path = filepath.Join(t.TempDir(), "trento_test.db")
+	}
+
 	db, err := db.InitDB(dbConfig)
 	if err != nil {
 		t.Fatal("could not open test database connection")
@@ -2,6 +2,9 @@ package web
 
 import (
 	"encoding/gob"
+	"fmt"
+	"strings"
+
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
@@ -41,6 +44,16 @@ var NoCheckSelected = func() Alert {
 	}
 }
 
+var SelectedChecksRemoved = func(checkIDs []string) Alert {
+	return Alert{
+		Type:  "warning",
+		Title: "Some selected checks were removed from the catalog",
+		Text: fmt.Sprintf(
+			"The checks %s are not in the catalog anymore and are not executed. Review the checks selection in the settings modal",
+			strings.Join(checkIDs, ", ")),
+	}
+}
+
 type Alert struct {
 	Type  string
 	Title string
@@ -19,11 +19,16 @@ import (
 	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
 
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/checks"
 	trentoDB "github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/internal/secrets"
 	"github.com/trento-project/trento/version"
 	"github.com/trento-project/trento/web/datapipeline"
 	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/migrations"
 	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/nativechecks"
 	"github.com/trento-project/trento/web/services"
 	"github.com/trento-project/trento/web/telemetry"
 
@@ -44,11 +49,23 @@ var DBTables = []interface{}{
 	&entities.Check{}, &datapipeline.DataCollectedEvent{}, &datapipeline.Subscription{},
 	&entities.HostTelemetry{}, &entities.Cluster{}, &entities.Host{}, &entities.HostHeartbeat{},
 	&entities.SlesSubscription{}, &entities.SAPSystemInstance{}, &entities.ChecksResult{},
+	&entities.ChecksExecution{}, &entities.HostChecksResult{}, &entities.CustomCheck{},
+	&entities.CustomCheckVersion{}, &entities.ChecksCatalogVersion{},
+	&entities.Waiver{}, &entities.AuditLogEntry{}, &entities.CheckParameters{},
+	&entities.Runner{}, &entities.RunnerLease{}, &entities.Remediation{},
+}
+
+// ReplicaTables are read by the hosts, clusters and SAP systems listings,
+// their queries are routed to the read-only replica when one is configured
+var ReplicaTables = []interface{}{
+	&entities.Cluster{}, &entities.Host{}, &entities.HostHeartbeat{},
+	&entities.SlesSubscription{}, &entities.SAPSystemInstance{},
 }
 
 type App struct {
 	InstallationID uuid.UUID
 	config         *Config
+	checksEngine   *checks.Engine
 	Dependencies
 }
 
@@ -60,24 +77,66 @@ type Config struct {
 	Cert          string
 	Key           string
 	CA            string
-	DBConfig      *trentoDB.Config
+	// EnableWebTLS serves the web UI and API over TLS, with the Cert and Key of the collector server
+	EnableWebTLS bool
+	// RunnerToken and RunnerCA authenticate the runners, with a bearer token or with a client certificate
+	// signed by the CA. The runners endpoints are open to anyone when none of them is configured
+	RunnerToken string
+	RunnerCA    string
+	// AdminToken authorizes the changes to the custom checks and the remediations, which run ansible tasks as root
+	// on the cluster nodes, and to the waivers hiding failing checks results. The changes are refused when it is
+	// not configured
+	AdminToken string
+	DBConfig   *trentoDB.Config
+	// ChecksEngine selects who runs the checks: the ansible based runner, the web server itself
+	// evaluating the native checks against the facts published by the agents, or the agents
+	// evaluating the native checks locally and posting their results
+	ChecksEngine   string
+	ChecksInterval time.Duration
 }
+
+// RunnerAuthEnabled tells whether the runners have to authenticate, with the token or with a client certificate
+func (c *Config) RunnerAuthEnabled() bool {
+	return c.RunnerToken != "" || (c.EnableWebTLS && c.RunnerCA != "")
+}
+
+const (
+	ChecksEngineAnsible = "ansible"
+	ChecksEngineNative  = "native"
+	ChecksEngineAgent   = "agent"
+
+	nativeChecksPollInterval  = 5 * time.Second
+	waiversExpirationInterval = 1 * time.Minute
+	webServerWriteTimeout     = 10 * time.Second
+)
+
 type Dependencies struct {
-	webEngine               *gin.Engine
-	collectorEngine         *gin.Engine
-	store                   cookie.Store
-	projectorWorkersPool    *datapipeline.ProjectorsWorkerPool
-	checksService           services.ChecksService
-	subscriptionsService    services.SubscriptionsService
-	tagsService             services.TagsService
-	collectorService        services.CollectorService
-	sapSystemsService       services.SAPSystemsService
-	clustersService         services.ClustersService
-	hostsService            services.HostsService
-	settingsService         services.SettingsService
-	telemetryRegistry       *telemetry.TelemetryRegistry
-	telemetryPublisher      telemetry.Publisher
-	premiumDetectionService services.PremiumDetectionService
+	webEngine                *gin.Engine
+	collectorEngine          *gin.Engine
+	store                    cookie.Store
+	projectorWorkersPool     *datapipeline.ProjectorsWorkerPool
+	checksService            services.ChecksService
+	subscriptionsService     services.SubscriptionsService
+	tagsService              services.TagsService
+	collectorService         services.CollectorService
+	sapSystemsService        services.SAPSystemsService
+	clustersService          services.ClustersService
+	hostsService             services.HostsService
+	settingsService          services.SettingsService
+	telemetryRegistry        *telemetry.TelemetryRegistry
+	telemetryPublisher       telemetry.Publisher
+	premiumDetectionService  services.PremiumDetectionService
+	checksExecutionsService  services.ChecksExecutionsService
+	factsService             services.FactsService
+	hostChecksResultsService services.HostChecksResultsService
+	customChecksService      services.CustomChecksService
+	waiversService           services.WaiversService
+	auditLogService          services.AuditLogService
+	reportsService           services.ReportsService
+	runnersService           services.RunnersService
+	checkTargetsService      services.CheckTargetsService
+	checksProgressService    services.ChecksProgressService
+	remediationsService      services.RemediationsService
 }
 
 func DefaultDependencies(config *Config) Dependencies {
@@ -111,6 +170,10 @@ func DefaultDependencies(config *Config) Dependencies {
 		log.Fatalf("failed to migrate database: %s", err)
 	}
 
+	if err := trentoDB.UseReadReplica(db, config.DBConfig, ReplicaTables...); err != nil {
+		log.Fatalf("failed to connect database replica: %s", err)
+	}
+
 	projectorRegistry := datapipeline.InitProjectorsRegistry(db)
 	projectorWorkersPool := datapipeline.NewProjectorsWorkerPool(projectorRegistry)
 
@@ -125,12 +188,25 @@ func DefaultDependencies(config *Config) Dependencies {
 	collectorService := services.NewCollectorService(db, projectorWorkersPool.GetChannel())
 	telemetryRegistry := telemetry.NewTelemetryRegistry(db)
 	telemetryPublisher := telemetry.NewTelemetryPublisher()
+	checksExecutionsService := services.NewChecksExecutionsService(db)
+	factsService := services.NewFactsService(db)
+	hostChecksResultsService := services.NewHostChecksResultsService(db)
+	customChecksService := services.NewCustomChecksService(db)
+	waiversService := services.NewWaiversService(db)
+	auditLogService := services.NewAuditLogService(db)
+	reportsService := services.NewReportsService(db, checksService)
+	runnersService := services.NewRunnersService(db)
+	checkTargetsService := services.NewCheckTargetsService(db, checksService, clustersService)
+	checksProgressService := services.NewChecksProgressService()
+	remediationsService := services.NewRemediationsService(db, checksService, checksExecutionsService)
 
 	return Dependencies{
 		webEngine, collectorEngine, store, projectorWorkersPool,
 		checksService, subscriptionsService, tagsService,
 		collectorService, sapSystemsService, clustersService, hostsService, settingsService,
-		telemetryRegistry, telemetryPublisher, premiumDetection,
+		telemetryRegistry, telemetryPublisher, premiumDetection, checksExecutionsService,
+		factsService, hostChecksResultsService, customChecksService, waiversService, auditLogService,
+		reportsService, runnersService, checkTargetsService, checksProgressService, remediationsService,
 	}
 }
 
@@ -141,14 +217,17 @@ func NewNamedEngine(instance string) *gin.Engine {
 	return engine
 }
 
+// MigrateDB applies the pending schema migrations.
+// It refuses to run against a database migrated by a newer version of Trento.
 func MigrateDB(db *gorm.DB) error {
-	err := db.AutoMigrate(DBTables...)
-
+	migrator, err := trentoDB.NewMigrator(db, migrations.Migrations)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = migrator.Migrate()
+
+	return err
 }
 
 // shortcut to use default dependencies
@@ -170,6 +249,20 @@ func NewAppWithDeps(config *Config, deps Dependencies) (*App, error) {
 
 	app.InstallationID = installationID
 
+	if config.ChecksEngine == ChecksEngineNative || config.ChecksEngine == ChecksEngineAgent {
+		app.checksEngine, err = checks.NewDefaultEngine()
+		if err != nil {
+			log.Errorf("failed to load the native checks: %s", err)
+			return nil, err
+		}
+	}
+
+	// the checks definitions are only served to the agents when they are the ones running them
+	var agentChecksEngine *checks.Engine
+	if config.ChecksEngine == ChecksEngineAgent {
+		agentChecksEngine = app.checksEngine
+	}
+
 	InitAlerts()
 	webEngine := deps.webEngine
 	webEngine.HTMLRender = NewLayoutRender(templatesFS, "templates/*.tmpl")
@@ -186,6 +279,7 @@ func NewAppWithDeps(config *Config, deps Dependencies) (*App, error) {
 	webEngine.GET("/catalog", NewChecksCatalogHandler(deps.checksService))
 	webEngine.GET("/clusters", NewClusterListHandler(deps.clustersService))
 	webEngine.GET("/clusters/:id", NewClusterHandler(deps.clustersService))
+	webEngine.GET("/clusters/:id/checks/history", NewClusterChecksHistoryHandler(deps.clustersService))
 	webEngine.GET("/sapsystems", NewSAPSystemListHandler(deps.sapSystemsService))
 	webEngine.GET("/sapsystems/:id", NewSAPResourceHandler(deps.hostsService, deps.sapSystemsService))
 	webEngine.GET("/databases", NewHANADatabaseListHandler(deps.sapSystemsService))
@@ -196,26 +290,96 @@ func NewAppWithDeps(config *Config, deps Dependencies) (*App, error) {
 		apiGroup.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 		apiGroup.GET("/ping", ApiPingHandler)
 		apiGroup.GET("/tags", ApiListTag(deps.tagsService))
+		apiGroup.GET("/hosts", ApiListHostsHandler(deps.hostsService))
+		apiGroup.GET("/hosts/:id", ApiGetHostHandler(deps.hostsService))
 		apiGroup.POST("/hosts/:id/tags", ApiHostCreateTagHandler(deps.hostsService, deps.tagsService))
 		apiGroup.DELETE("/hosts/:id/tags/:tag", ApiHostDeleteTagHandler(deps.hostsService, deps.tagsService))
+		apiGroup.GET("/hosts/:id/results", ApiCheckTargetResultsHandler(deps.checksService))
+		apiGroup.POST("/hosts/:id/checks/execute", ApiCheckTargetChecksExecuteHandler(models.CheckTargetHost, deps.checkTargetsService, deps.checksExecutionsService))
+		apiGroup.GET("/hosts/:id/checks/executions/last", ApiCheckTargetLastChecksExecutionHandler(deps.checksExecutionsService))
+		apiGroup.GET("/clusters", ApiListClustersHandler(deps.clustersService))
+		apiGroup.GET("/clusters/:cluster_id", ApiGetClusterHandler(deps.clustersService))
 		apiGroup.POST("/clusters/:id/tags", ApiClusterCreateTagHandler(deps.clustersService, deps.tagsService))
 		apiGroup.DELETE("/clusters/:id/tags/:tag", ApiClusterDeleteTagHandler(deps.clustersService, deps.tagsService))
 		apiGroup.GET("/clusters/:cluster_id/results", ApiClusterCheckResultsHandler(deps.checksService))
+		apiGroup.GET("/clusters/:cluster_id/results/history", ApiClusterChecksResultsHistoryHandler(deps.checksService))
+		apiGroup.GET("/clusters/:cluster_id/results/diff", ApiClusterChecksResultsDiffHandler(deps.checksService))
 		apiGroup.GET("/clusters/settings", ApiGetClustersSettingsHandler(deps.clustersService))
+		apiGroup.POST("/clusters/:id/checks/execute", ApiClusterChecksExecuteHandler(deps.clustersService, deps.checksExecutionsService))
+		apiGroup.GET("/clusters/:cluster_id/checks/executions", ApiClusterChecksExecutionsHandler(deps.checksExecutionsService))
+		apiGroup.GET("/clusters/:cluster_id/checks/executions/last", ApiClusterLastChecksExecutionHandler(deps.checksExecutionsService))
+		apiGroup.GET("/clusters/:cluster_id/remediations", ApiClusterRemediationsHandler(deps.remediationsService))
+		apiGroup.GET("/sapsystems", ApiListSAPSystemsHandler(deps.sapSystemsService))
+		apiGroup.GET("/sapsystems/:id", ApiGetSAPSystemHandler(deps.sapSystemsService))
 		apiGroup.POST("/sapsystems/:id/tags", ApiSAPSystemCreateTagHandler(deps.sapSystemsService, deps.tagsService))
 		apiGroup.DELETE("/sapsystems/:id/tags/:tag", ApiSAPSystemDeleteTagHandler(deps.sapSystemsService, deps.tagsService))
+		apiGroup.GET("/sapsystems/:id/results", ApiCheckTargetResultsHandler(deps.checksService))
+		apiGroup.POST("/sapsystems/:id/checks/execute", ApiCheckTargetChecksExecuteHandler(models.CheckTargetSAPSystem, deps.checkTargetsService, deps.checksExecutionsService))
+		apiGroup.GET("/sapsystems/:id/checks/executions/last", ApiCheckTargetLastChecksExecutionHandler(deps.checksExecutionsService))
+		apiGroup.GET("/databases", ApiListDatabasesHandler(deps.sapSystemsService))
+		apiGroup.GET("/databases/:id", ApiGetDatabaseHandler(deps.sapSystemsService))
 		apiGroup.POST("/databases/:id/tags", ApiDatabaseCreateTagHandler(deps.sapSystemsService, deps.tagsService))
 		apiGroup.DELETE("/databases/:id/tags/:tag", ApiDatabaseDeleteTagHandler(deps.sapSystemsService, deps.tagsService))
-		apiGroup.GET("/checks/:id/settings", ApiCheckGetSettingsByIdHandler(deps.clustersService))
-		apiGroup.POST("/checks/:id/settings", ApiCheckCreateSettingsByIdHandler(deps.checksService))
-		apiGroup.PUT("/checks/catalog", ApiCreateChecksCatalogHandler(deps.checksService))
+		apiGroup.GET("/checks/targets/settings", ApiGetChecksTargetsSettingsHandler(deps.checkTargetsService))
+		apiGroup.GET("/checks/:id/settings", ApiCheckGetSettingsByIdHandler(deps.checkTargetsService, deps.checksService))
+		apiGroup.POST("/checks/:id/settings", ApiCheckCreateSettingsByIdHandler(deps.checksService, deps.checkTargetsService))
 		apiGroup.GET("/checks/catalog", ApiChecksCatalogHandler(deps.checksService))
-		apiGroup.POST("/checks/:id/results", ApiCreateChecksResultHandler(deps.checksService))
+		apiGroup.GET("/checks/catalog/versions", ApiChecksCatalogVersionsHandler(deps.checksService))
+		apiGroup.GET("/checks/catalog/versions/:id", ApiChecksCatalogVersionHandler(deps.checksService))
+		apiGroup.GET("/checks/custom", ApiListCustomChecksHandler(deps.customChecksService))
+		apiGroup.GET("/checks/custom/:id", ApiGetCustomCheckHandler(deps.customChecksService))
+		apiGroup.GET("/checks/custom/:id/versions", ApiGetCustomCheckVersionsHandler(deps.customChecksService))
+		apiGroup.GET("/checks/parameters/tags/:tag", ApiGetTagCheckParametersHandler(deps.checksService))
+		apiGroup.PUT("/checks/parameters/tags/:tag", ApiUpdateTagCheckParametersHandler(deps.checksService))
+		apiGroup.GET("/checks/waivers", ApiListWaiversHandler(deps.waiversService))
+		apiGroup.GET("/checks/waivers/:id", ApiGetWaiverHandler(deps.waiversService))
+		apiGroup.GET("/audit", ApiAuditLogHandler(deps.auditLogService))
+		apiGroup.GET("/reports", ApiReportHandler(deps.reportsService))
+		apiGroup.GET("/checks/:id/progress", ApiGetChecksProgressHandler(deps.checksProgressService))
+		apiGroup.GET("/checks/:id/progress/stream", ApiChecksProgressStreamHandler(deps.checksProgressService))
+		apiGroup.GET("/remediations/:id", ApiGetRemediationHandler(deps.remediationsService))
+		apiGroup.GET("/runners", ApiListRunnersHandler(deps.runnersService))
+	}
+
+	// the endpoints used by the runners to publish the catalog and the checks results
+	runnersGroup := apiGroup.Group("")
+	if config.RunnerAuthEnabled() {
+		runnersGroup.Use(RunnerAuthMiddleware(config.RunnerToken))
+	}
+	{
+		runnersGroup.PUT("/checks/catalog", ApiCreateChecksCatalogHandler(deps.checksService))
+		runnersGroup.POST("/checks/:id/results", ApiCreateChecksResultHandler(deps.checksService))
+		runnersGroup.POST("/checks/:id/progress", ApiCreateChecksProgressEventHandler(deps.checksProgressService))
+		runnersGroup.POST("/checks/executions", ApiStartChecksExecutionHandler(deps.checksExecutionsService))
+		runnersGroup.POST("/checks/executions/claim", ApiClaimChecksExecutionHandler(deps.checksExecutionsService))
+		runnersGroup.PUT("/checks/executions/:id", ApiUpdateChecksExecutionHandler(deps.checksExecutionsService))
+		runnersGroup.POST("/remediations/claim", ApiClaimRemediationHandler(deps.remediationsService))
+		runnersGroup.PUT("/remediations/:id", ApiUpdateRemediationHandler(deps.remediationsService))
+		runnersGroup.POST("/runners/:id/heartbeat", ApiRunnerHeartbeatHandler(deps.runnersService))
+		runnersGroup.DELETE("/runners/:id/leases", ApiReleaseRunnerLeasesHandler(deps.runnersService))
+	}
+
+	// the endpoints changing the ansible tasks run by the runners or the reported checks results,
+	// restricted to the administrators
+	adminGroup := apiGroup.Group("")
+	adminGroup.Use(AdminAuthMiddleware(config.AdminToken))
+	{
+		adminGroup.POST("/checks/custom", ApiCreateCustomCheckHandler(deps.customChecksService))
+		adminGroup.PUT("/checks/custom/:id", ApiUpdateCustomCheckHandler(deps.customChecksService))
+		adminGroup.DELETE("/checks/custom/:id", ApiDeleteCustomCheckHandler(deps.customChecksService))
+		adminGroup.POST("/clusters/:id/remediations", ApiCreateClusterRemediationHandler(deps.remediationsService))
+		adminGroup.POST("/remediations/:id/approve", ApiApproveRemediationHandler(deps.remediationsService))
+		adminGroup.POST("/remediations/:id/reject", ApiRejectRemediationHandler(deps.remediationsService))
+		adminGroup.POST("/checks/waivers", ApiCreateWaiverHandler(deps.waiversService))
+		adminGroup.DELETE("/checks/waivers/:id", ApiDeleteWaiverHandler(deps.waiversService))
 	}
 
 	collectorEngine := deps.collectorEngine
+	collectorEngine.Use(ErrorHandler)
 	collectorEngine.POST("/api/collect", ApiCollectDataHandler(deps.collectorService))
 	collectorEngine.POST("/api/hosts/:id/heartbeat", ApiHostHeartbeatHandler(deps.hostsService))
+	collectorEngine.GET("/api/hosts/:id/checks", ApiGetHostChecksHandler(deps.hostsService, deps.checksService, agentChecksEngine))
+	collectorEngine.POST("/api/hosts/:id/checks/results", ApiPostHostChecksResultsHandler(deps.hostChecksResultsService))
 	collectorEngine.GET("/api/ping", ApiPingHandler)
 
 	return app, nil
@@ -226,10 +390,27 @@ func (a *App) Start(ctx context.Context) error {
 		Addr:           fmt.Sprintf("%s:%d", a.config.Host, a.config.Port),
 		Handler:        a.webEngine,
 		ReadTimeout:    10 * time.Second,
-		WriteTimeout:   10 * time.Second,
+		WriteTimeout:   webServerWriteTimeout,
 		MaxHeaderBytes: 1 << 20,
 	}
 
+	if a.config.EnableWebTLS {
+		webTLSConfig, err := getWebTLSConfig(a.config.Cert, a.config.Key, a.config.RunnerCA)
+		if err != nil {
+			return err
+		}
+		webServer.TLSConfig = webTLSConfig
+	}
+
+	if !a.config.RunnerAuthEnabled() {
+		log.Warn("The runners are not authenticated, anyone reaching the web server can publish checks results. " +
+			"Configure a runner token, or a CA verifying the runners client certificates")
+	}
+
+	if a.config.AdminToken == "" {
+		log.Info("No admin token is configured, the custom checks and the waivers can't be changed and the remediations can't be requested")
+	}
+
 	var tlsConfig *tls.Config
 	var err error
 
@@ -253,7 +434,12 @@ func (a *App) Start(ctx context.Context) error {
 
 	log.Info("Starting web server")
 	g.Go(func() error {
-		err := webServer.ListenAndServe()
+		var err error
+		if webServer.TLSConfig == nil {
+			err = webServer.ListenAndServe()
+		} else {
+			err = webServer.ListenAndServeTLS("", "")
+		}
 		if err != nil && err != http.ErrServerClosed {
 			return err
 		}
@@ -291,6 +477,34 @@ func (a *App) Start(ctx context.Context) error {
 		return nil
 	})
 
+	g.Go(func() error {
+		internal.Repeat("web.waivers", a.expireWaivers, waiversExpirationInterval, ctx)
+		return nil
+	})
+
+	if a.config.ChecksEngine == ChecksEngineAgent {
+		if err := a.checksService.CreateChecksCatalog(a.checksEngine.Catalog()); err != nil {
+			log.Errorf("Error storing the native checks catalog: %s", err)
+		}
+	}
+
+	if a.config.ChecksEngine == ChecksEngineNative {
+		checksRunner := nativechecks.NewRunner(
+			a.checksEngine,
+			a.Dependencies.checksService,
+			a.Dependencies.checksExecutionsService,
+			a.Dependencies.clustersService,
+			a.Dependencies.factsService,
+			a.config.ChecksInterval,
+			nativeChecksPollInterval,
+		)
+
+		g.Go(func() error {
+			checksRunner.Start(ctx)
+			return nil
+		})
+	}
+
 	go func() {
 		<-ctx.Done()
 		log.Info("Web server is shutting down.")
@@ -302,6 +516,13 @@ func (a *App) Start(ctx context.Context) error {
 	return g.Wait()
 }
 
+// expireWaivers records the waivers that expired, their results are already reported again
+func (a *App) expireWaivers() {
+	if _, err := a.waiversService.Expire(); err != nil {
+		log.Errorf("Error expiring the checks waivers: %s", err)
+	}
+}
+
 func getTLSConfig(cert string, key string, ca string) (*tls.Config, error) {
 	caCert, err := ioutil.ReadFile(ca)
 	if err != nil {
@@ -310,7 +531,7 @@ func getTLSConfig(cert string, key string, ca string) (*tls.Config, error) {
 	caCertPool := x509.NewCertPool()
 	caCertPool.AppendCertsFromPEM(caCert)
 
-	certificate, err := tls.LoadX509KeyPair(cert, key)
+	certificate, err := secrets.LoadX509KeyPair(cert, key)
 	if err != nil {
 		return nil, err
 	}
@@ -321,3 +542,30 @@ func getTLSConfig(cert string, key string, ca string) (*tls.Config, error) {
 		Certificates: []tls.Certificate{certificate},
 	}, nil
 }
+
+// getWebTLSConfig serves the web server with the certificate, the runners client certificates are verified
+// against the runners CA when they present one, the browsers are not asked for any
+func getWebTLSConfig(cert string, key string, runnerCA string) (*tls.Config, error) {
+	certificate, err := secrets.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	if runnerCA != "" {
+		caCert, err := ioutil.ReadFile(runnerCA)
+		if err != nil {
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM(caCert)
+
+		tlsConfig.ClientCAs = caCertPool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
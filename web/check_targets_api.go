@@ -0,0 +1,120 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiGetChecksTargetsSettingsHandler godoc
+// @Summary Retrieve the settings of all the checks targets: the clusters, and the standalone hosts and SAP systems with selected checks
+// @Accept json
+// @Produce json
+// @Success 200 {object} ClustersSettingsResponse
+// @Failure 500 {object} map[string]string
+// @Router /checks/targets/settings [get]
+func ApiGetChecksTargetsSettingsHandler(targets services.CheckTargetsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		settings, err := targets.GetAllSettings()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, settings)
+	}
+}
+
+// ApiCheckTargetResultsHandler godoc
+// @Summary Get the check results of a standalone host or a SAP system
+// @Produce json
+// @Param id path string true "Host or SAP system Id"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/results [get]
+// @Router /sapsystems/{id}/results [get]
+func ApiCheckTargetResultsHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checkResults, err := s.GetChecksResultAndMetadataByCluster(c.Param("id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, checkResults)
+	}
+}
+
+// ApiCheckTargetChecksExecuteHandler godoc
+// @Summary Request an on-demand checks execution for a standalone host or a SAP system
+// @Produce json
+// @Param id path string true "Host or SAP system Id"
+// @Success 202 {object} JSONChecksExecution
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/checks/execute [post]
+// @Router /sapsystems/{id}/checks/execute [post]
+func ApiCheckTargetChecksExecuteHandler(
+	targetType string, targets services.CheckTargetsService, executions services.ChecksExecutionsService,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		actualType, err := targets.GetTargetType(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if actualType != targetType {
+			_ = c.Error(NotFoundError("could not find " + targetDescription(targetType)))
+			return
+		}
+
+		execution, err := executions.Enqueue(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, execution)
+	}
+}
+
+// ApiCheckTargetLastChecksExecutionHandler godoc
+// @Summary Get the status of the last on-demand checks execution of a standalone host or a SAP system
+// @Produce json
+// @Param id path string true "Host or SAP system Id"
+// @Success 200 {object} JSONChecksExecution
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/checks/executions/last [get]
+// @Router /sapsystems/{id}/checks/executions/last [get]
+func ApiCheckTargetLastChecksExecutionHandler(executions services.ChecksExecutionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		execution, err := executions.GetLastByCluster(c.Param("id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if execution == nil {
+			_ = c.Error(NotFoundError("no checks execution found"))
+			return
+		}
+
+		c.JSON(http.StatusOK, execution)
+	}
+}
+
+func targetDescription(targetType string) string {
+	switch targetType {
+	case models.CheckTargetHost:
+		return "standalone host"
+	case models.CheckTargetSAPSystem:
+		return "SAP system"
+	default:
+		return targetType
+	}
+}
@@ -0,0 +1,138 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiGetChecksTargetsSettingsHandler(t *testing.T) {
+	settings := models.ClustersSettings{
+		{ID: "cluster1", TargetType: models.CheckTargetCluster, SelectedChecks: []string{"A"}},
+		{ID: "host1", TargetType: models.CheckTargetHost, SelectedChecks: []string{"B"}},
+	}
+
+	mockCheckTargetsService := new(services.MockCheckTargetsService)
+	mockCheckTargetsService.On("GetAllSettings").Return(settings, nil)
+
+	deps := setupTestDependencies()
+	deps.checkTargetsService = mockCheckTargetsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/targets/settings", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(settings)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+}
+
+func TestApiCheckTargetChecksExecuteHandler(t *testing.T) {
+	execution := &models.ChecksExecution{
+		ID:        1,
+		ClusterID: "host1",
+		Status:    models.ChecksExecutionQueued,
+	}
+
+	mockCheckTargetsService := new(services.MockCheckTargetsService)
+	mockCheckTargetsService.On("GetTargetType", "host1").Return(models.CheckTargetHost, nil)
+	mockCheckTargetsService.On("GetTargetType", "sapsystem1").Return(models.CheckTargetSAPSystem, nil)
+
+	mockChecksExecutionsService := new(services.MockChecksExecutionsService)
+	mockChecksExecutionsService.On("Enqueue", "host1").Return(execution, nil)
+
+	deps := setupTestDependencies()
+	deps.checkTargetsService = mockCheckTargetsService
+	deps.checksExecutionsService = mockChecksExecutionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/hosts/host1/checks/execute", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(execution)
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	// the id must be of the expected target type
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/hosts/sapsystem1/checks/execute", nil)
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	mockChecksExecutionsService.AssertNumberOfCalls(t, "Enqueue", 1)
+}
+
+func TestApiCheckTargetResultsHandler(t *testing.T) {
+	results := &models.ChecksResultAsList{
+		Hosts:  map[string]*models.HostState{"host1": {Reachable: true}},
+		Checks: []*models.ChecksByHost{},
+	}
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetChecksResultAndMetadataByCluster", "sapsystem1").Return(results, nil)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/sapsystems/sapsystem1/results", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(results)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+}
+
+func TestApiCheckCreateSettingsByIdHandlerTargetType(t *testing.T) {
+	mockCheckTargetsService := new(services.MockCheckTargetsService)
+	mockCheckTargetsService.On("GetTargetType", "sapsystem1").Return(models.CheckTargetSAPSystem, nil)
+	mockCheckTargetsService.On("ValidateSelectedChecks", models.CheckTargetSAPSystem, []string{"CLUSTERONLY"}).Return(
+		services.ErrInvalidSelectedChecks)
+
+	mockChecksService := new(services.MockChecksService)
+
+	deps := setupTestDependencies()
+	deps.checkTargetsService = mockCheckTargetsService
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(&JSONChecksSettings{
+		SelectedChecks:     []string{"CLUSTERONLY"},
+		ConnectionSettings: map[string]string{},
+	})
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/sapsystem1/settings", bytes.NewBuffer(body))
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	mockChecksService.AssertNotCalled(t, "CreateSelectedChecks", "sapsystem1", []string{"CLUSTERONLY"})
+}
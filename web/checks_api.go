@@ -1,32 +1,90 @@
 package web
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
+	"gorm.io/gorm"
 
 	"github.com/trento-project/trento/web/models"
 	"github.com/trento-project/trento/web/services"
 )
 
 type JSONChecksSettings struct {
+	// TargetType is the kind of checks target the settings belong to: cluster, host or sapsystem
+	TargetType         string            `json:"target_type,omitempty"`
 	SelectedChecks     []string          `json:"selected_checks" binding:"required"`
 	ConnectionSettings map[string]string `json:"connection_settings" binding:"required"`
 	Hostnames          []string          `json:"hostnames"`
+	RemovedChecks      []string          `json:"removed_checks,omitempty"`
+	// NotApplicableChecks are the checks of the catalog not applying to the environment of the target,
+	// they are kept when selected but not run
+	NotApplicableChecks []string `json:"not_applicable_checks,omitempty"`
+	// CheckParameters are the parameters overridden for the cluster, the stored ones are kept when not given
+	CheckParameters map[string]string `json:"check_parameters,omitempty"`
+	// EffectiveCheckParameters are the parameters overridden for the cluster and its tags, as passed to the checks
+	EffectiveCheckParameters map[string]string `json:"effective_check_parameters,omitempty"`
+	// ClusterConnectionProfile is the connection profile of all the hosts of the cluster
+	ClusterConnectionProfile *JSONConnectionProfile `json:"cluster_connection_profile,omitempty"`
+	// ConnectionProfiles are the connection profiles of the hosts, overriding the one of the cluster.
+	// When not given, only the users of the connection settings are updated
+	ConnectionProfiles map[string]*JSONConnectionProfile `json:"connection_profiles,omitempty"`
 }
 
+type JSONConnectionProfile struct {
+	User         string `json:"user,omitempty"`
+	Port         int    `json:"port,omitempty"`
+	PrivateKey   string `json:"private_key,omitempty"`
+	ProxyJump    string `json:"proxy_jump,omitempty"`
+	BecomeMethod string `json:"become_method,omitempty"`
+	BecomeUser   string `json:"become_user,omitempty"`
+}
+
+func newJSONConnectionProfile(settings models.ConnectionSettings) *JSONConnectionProfile {
+	return &JSONConnectionProfile{
+		User:         settings.User,
+		Port:         settings.Port,
+		PrivateKey:   settings.PrivateKey,
+		ProxyJump:    settings.ProxyJump,
+		BecomeMethod: settings.BecomeMethod,
+		BecomeUser:   settings.BecomeUser,
+	}
+}
+
+func (p *JSONConnectionProfile) toConnectionSettings(id string, node string) models.ConnectionSettings {
+	return models.ConnectionSettings{
+		ID:           id,
+		Node:         node,
+		User:         p.User,
+		Port:         p.Port,
+		PrivateKey:   p.PrivateKey,
+		ProxyJump:    p.ProxyJump,
+		BecomeMethod: p.BecomeMethod,
+		BecomeUser:   p.BecomeUser,
+	}
+}
+
+type JSONCheckParameters map[string]string
+
 type JSONChecksCatalog []*JSONCheck
 
 type JSONCheck struct {
-	ID             string `json:"id,omitempty" binding:"required"`
-	Name           string `json:"name,omitempty" binding:"required"`
-	Group          string `json:"group,omitempty" binding:"required"`
-	Description    string `json:"description,omitempty"`
-	Remediation    string `json:"remediation,omitempty"`
-	Implementation string `json:"implementation,omitempty"`
-	Labels         string `json:"labels,omitempty"`
-	Premium        bool   `json:"premium,omitempty"`
+	ID             string   `json:"id,omitempty" binding:"required"`
+	Name           string   `json:"name,omitempty" binding:"required"`
+	Group          string   `json:"group,omitempty" binding:"required"`
+	Description    string   `json:"description,omitempty"`
+	Remediation    string   `json:"remediation,omitempty"`
+	Implementation string   `json:"implementation,omitempty"`
+	Labels         string   `json:"labels,omitempty"`
+	Premium        bool     `json:"premium,omitempty"`
+	Supersedes     []string `json:"supersedes,omitempty"`
+	Targets        []string `json:"targets,omitempty"`
+	// Applicability are the conditions the environment of a target must meet for the check to run
+	Applicability *models.CheckApplicability `json:"applicability,omitempty"`
+	Remediable    bool                       `json:"remediable,omitempty"`
 }
 
 type JSONChecksGroup struct {
@@ -109,6 +167,10 @@ func ApiCreateChecksCatalogHandler(s services.ChecksService) gin.HandlerFunc {
 				Implementation: checkData.Implementation,
 				Labels:         checkData.Labels,
 				Premium:        checkData.Premium,
+				Supersedes:     checkData.Supersedes,
+				Targets:        checkData.Targets,
+				Applicability:  checkData.Applicability,
+				Remediable:     checkData.Remediable,
 			}
 			catalog = append(catalog, newCheck)
 		}
@@ -123,6 +185,65 @@ func ApiCreateChecksCatalogHandler(s services.ChecksService) gin.HandlerFunc {
 	}
 }
 
+// ApiChecksCatalogVersionsHandler godoc
+// @Summary Get the changes of the checks catalog, the most recent first
+// @Produce json
+// @Param page query int false "Page number"
+// @Param per_page query int false "Versions per page"
+// @Success 200 {array} models.ChecksCatalogVersion
+// @Failure 500 {object} map[string]string
+// @Router /checks/catalog/versions [get]
+func ApiChecksCatalogVersionsHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pageNumber, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+		if err != nil {
+			pageNumber = 1
+		}
+		pageSize, err := strconv.Atoi(c.DefaultQuery("per_page", "50"))
+		if err != nil {
+			pageSize = 50
+		}
+
+		versions, err := s.GetChecksCatalogVersions(&services.Page{Number: pageNumber, Size: pageSize})
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, versions)
+	}
+}
+
+// ApiChecksCatalogVersionHandler godoc
+// @Summary Get a version of the checks catalog, with all its checks
+// @Produce json
+// @Param id path int true "Version Id"
+// @Success 200 {object} models.ChecksCatalogVersion
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /checks/catalog/versions/{id} [get]
+func ApiChecksCatalogVersionHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid version id"))
+			return
+		}
+
+		version, err := s.GetChecksCatalogVersion(id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = c.Error(NotFoundError("could not find the catalog version"))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, version)
+	}
+}
+
 // ApiCheckResultsHandler godoc
 // @Summary Get a specific cluster's check results
 // @Produce json
@@ -144,6 +265,79 @@ func ApiClusterCheckResultsHandler(s services.ChecksService) gin.HandlerFunc {
 	}
 }
 
+// ApiClusterChecksResultsHistoryHandler godoc
+// @Summary Get the past checks executions of a cluster, the most recent first
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Param page query int false "Page number"
+// @Param per_page query int false "Executions per page"
+// @Success 200 {array} models.ChecksResultSummary
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/results/history [get]
+func ApiClusterChecksResultsHistoryHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterId := c.Param("cluster_id")
+
+		pageNumber, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+		if err != nil {
+			pageNumber = 1
+		}
+		pageSize, err := strconv.Atoi(c.DefaultQuery("per_page", "50"))
+		if err != nil {
+			pageSize = 50
+		}
+
+		history, err := s.GetChecksResultsHistoryByCluster(clusterId, &services.Page{Number: pageNumber, Size: pageSize})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, history)
+	}
+}
+
+// ApiClusterChecksResultsDiffHandler godoc
+// @Summary Compare two checks executions of a cluster
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Param from query int true "Id of the older execution"
+// @Param to query int true "Id of the newer execution"
+// @Success 200 {object} models.ChecksResultDiff
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/results/diff [get]
+func ApiClusterChecksResultsDiffHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterId := c.Param("cluster_id")
+
+		fromId, err := strconv.ParseInt(c.Query("from"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid from execution id"))
+			return
+		}
+
+		toId, err := strconv.ParseInt(c.Query("to"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid to execution id"))
+			return
+		}
+
+		diff, err := s.GetChecksResultsDiff(clusterId, fromId, toId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = c.Error(NotFoundError("could not find the checks executions"))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, diff)
+	}
+}
+
 // ApiCreateChecksResultHandler godoc
 // @Summary Create a checks result entry
 // @Produce json
@@ -187,28 +381,59 @@ func ApiCreateChecksResultHandler(s services.ChecksService) gin.HandlerFunc {
 // @Success 200 {object} JSONChecksSettings
 // @Failure 404 {object} map[string]string
 // @Router /checks/{id}/settings [get]
-func ApiCheckGetSettingsByIdHandler(s services.ClustersService) gin.HandlerFunc {
+func ApiCheckGetSettingsByIdHandler(targets services.CheckTargetsService, checksService services.ChecksService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		resourceId := c.Param("id")
 
-		clusterSettings, err := s.GetClusterSettingsByID(resourceId)
+		clusterSettings, err := targets.GetSettingsByID(resourceId)
 		if err != nil {
 			c.Error(err)
 			return
 		}
 
 		if clusterSettings == nil {
-			c.Error(NotFoundError("cluster not found"))
+			c.Error(NotFoundError("checks target not found"))
+			return
+		}
+
+		checkParameters, err := checksService.GetCheckParameters(models.CheckParametersClusterScope, resourceId)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		connectionSettings, err := checksService.GetConnectionSettingsById(resourceId)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		notApplicableChecks, err := targets.GetNotApplicableChecks(resourceId)
+		if err != nil {
+			_ = c.Error(err)
 			return
 		}
 
+		// the selected checks not applicable are returned too, so they are kept when the settings are saved again
+		var selectedChecks []string
+		selectedChecks = append(selectedChecks, clusterSettings.SelectedChecks...)
+		selectedChecks = append(selectedChecks, clusterSettings.NotApplicableChecks...)
+
 		resp := &JSONChecksSettings{
-			SelectedChecks:     clusterSettings.SelectedChecks,
-			ConnectionSettings: make(map[string]string),
+			TargetType:               clusterSettings.TargetType,
+			SelectedChecks:           selectedChecks,
+			NotApplicableChecks:      notApplicableChecks,
+			ConnectionSettings:       make(map[string]string),
+			RemovedChecks:            clusterSettings.RemovedChecks,
+			CheckParameters:          checkParameters,
+			EffectiveCheckParameters: clusterSettings.CheckParameters,
+			ClusterConnectionProfile: newJSONConnectionProfile(connectionSettings[models.ClusterConnectionNode]),
+			ConnectionProfiles:       make(map[string]*JSONConnectionProfile),
 		}
 
 		for _, host := range clusterSettings.Hosts {
 			resp.ConnectionSettings[host.Name] = host.User
+			resp.ConnectionProfiles[host.Name] = newJSONConnectionProfile(connectionSettings[host.Name])
 			resp.Hostnames = append(resp.Hostnames, host.Name)
 		}
 
@@ -223,9 +448,10 @@ func ApiCheckGetSettingsByIdHandler(s services.ClustersService) gin.HandlerFunc
 // @Param id path string true "Resource id"
 // @Param Body body JSONChecksSettings true "Checks settings"
 // @Success 201 {object} JSONChecksSettings
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /checks/{id}/settings [post]
-func ApiCheckCreateSettingsByIdHandler(s services.ChecksService) gin.HandlerFunc {
+func ApiCheckCreateSettingsByIdHandler(s services.ChecksService, targets services.CheckTargetsService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		resourceId := c.Param("id")
 
@@ -237,14 +463,52 @@ func ApiCheckCreateSettingsByIdHandler(s services.ChecksService) gin.HandlerFunc
 			return
 		}
 
+		targetType, err := targets.GetTargetType(resourceId)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		// the settings of a target not discovered yet are stored as they are
+		if targetType != "" {
+			err := targets.ValidateSelectedChecks(targetType, r.SelectedChecks)
+			if errors.Is(err, services.ErrInvalidSelectedChecks) {
+				_ = c.Error(BadRequestError(err.Error()))
+				return
+			}
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+		}
+
 		err = s.CreateSelectedChecks(resourceId, r.SelectedChecks)
 		if err != nil {
 			_ = c.Error(err)
 			return
 		}
 
-		for node, user := range r.ConnectionSettings {
-			err = s.CreateConnectionSettings(resourceId, node, user)
+		effectiveUsers, err := effectiveConnectionUsers(targets, resourceId, targetType)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if err := saveConnectionSettings(s, resourceId, &r, effectiveUsers); err != nil {
+			if errors.Is(err, services.ErrInvalidConnectionSettings) {
+				_ = c.Error(BadRequestError(err.Error()))
+				return
+			}
+			_ = c.Error(err)
+			return
+		}
+
+		if r.CheckParameters != nil {
+			err = s.CreateCheckParameters(models.CheckParametersClusterScope, resourceId, r.CheckParameters)
+			if errors.Is(err, services.ErrInvalidCheckParameters) {
+				_ = c.Error(BadRequestError(err.Error()))
+				return
+			}
 			if err != nil {
 				_ = c.Error(err)
 				return
@@ -254,3 +518,137 @@ func ApiCheckCreateSettingsByIdHandler(s services.ChecksService) gin.HandlerFunc
 		c.JSON(http.StatusCreated, &r)
 	}
 }
+
+// saveConnectionSettings stores the connection profiles of the cluster and its hosts.
+// The users of the connection settings apply to the hosts without a user in their profile,
+// or replace the stored ones when no profile is given. As the settings return the effective users,
+// defaulting to the one of the cluster profile or of the environment, a user is only stored when it differs
+// from the effective one, so the defaults are not frozen in the host profiles
+func saveConnectionSettings(
+	s services.ChecksService, clusterID string, r *JSONChecksSettings, effectiveUsers map[string]string,
+) error {
+	profiles := make(map[string]models.ConnectionSettings)
+
+	changedUsers := make(map[string]string)
+	for node, user := range r.ConnectionSettings {
+		if effective, ok := effectiveUsers[node]; !ok || user != effective {
+			changedUsers[node] = user
+		}
+	}
+
+	if r.ConnectionProfiles == nil {
+		// only the users are updated, keeping the rest of the stored profiles
+		stored, err := s.GetConnectionSettingsById(clusterID)
+		if err != nil {
+			return err
+		}
+		for node, user := range changedUsers {
+			profile := stored[node]
+			profile.User = user
+			profiles[node] = profile
+		}
+	} else {
+		for node, profile := range r.ConnectionProfiles {
+			if profile != nil {
+				profiles[node] = profile.toConnectionSettings(clusterID, node)
+			}
+		}
+
+		for node, user := range changedUsers {
+			profile := profiles[node]
+			if profile.User == "" {
+				profile.User = user
+			}
+			profiles[node] = profile
+		}
+	}
+
+	if r.ClusterConnectionProfile != nil {
+		profiles[models.ClusterConnectionNode] = r.ClusterConnectionProfile.toConnectionSettings(
+			clusterID, models.ClusterConnectionNode)
+	}
+
+	for node, profile := range profiles {
+		profile.ID = clusterID
+		profile.Node = node
+		if err := s.CreateConnectionSettings(profile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// effectiveConnectionUsers returns the users the checks connect with to the hosts of the target, by host name.
+// Nothing is returned for the targets not discovered yet
+func effectiveConnectionUsers(targets services.CheckTargetsService, id string, targetType string) (map[string]string, error) {
+	users := make(map[string]string)
+	if targetType == "" {
+		return users, nil
+	}
+
+	settings, err := targets.GetSettingsByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return users, nil
+	}
+
+	for _, host := range settings.Hosts {
+		users[host.Name] = host.User
+	}
+
+	return users, nil
+}
+
+// ApiGetTagCheckParametersHandler godoc
+// @Summary Get the check parameters overridden for all the clusters with a tag
+// @Produce json
+// @Param tag path string true "Tag"
+// @Success 200 {object} JSONCheckParameters
+// @Failure 500 {object} map[string]string
+// @Router /checks/parameters/tags/{tag} [get]
+func ApiGetTagCheckParametersHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parameters, err := s.GetCheckParameters(models.CheckParametersTagScope, c.Param("tag"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, JSONCheckParameters(parameters))
+	}
+}
+
+// ApiUpdateTagCheckParametersHandler godoc
+// @Summary Replace the check parameters overridden for all the clusters with a tag
+// @Accept json
+// @Produce json
+// @Param tag path string true "Tag"
+// @Param Body body JSONCheckParameters true "Check parameters"
+// @Success 200 {object} JSONCheckParameters
+// @Failure 400 {object} map[string]string
+// @Router /checks/parameters/tags/{tag} [put]
+func ApiUpdateTagCheckParametersHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONCheckParameters
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err := s.CreateCheckParameters(models.CheckParametersTagScope, c.Param("tag"), r)
+		if errors.Is(err, services.ErrInvalidCheckParameters) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, r)
+	}
+}
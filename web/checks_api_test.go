@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 
 	"github.com/trento-project/trento/web/models"
 	"github.com/trento-project/trento/web/services"
@@ -374,9 +375,11 @@ func TestApiCreateChecksCatalogHandler(t *testing.T) {
 }
 
 func TestApiCheckGetSettingsByIdHandler(t *testing.T) {
-	mockClustersService := new(services.MockClustersService)
-	mockClustersService.On("GetClusterSettingsByID", "cluster_id").Return(&models.ClusterSettings{
-		SelectedChecks: []string{"ABCDEF", "123456"},
+	mockCheckTargetsService := new(services.MockCheckTargetsService)
+	mockCheckTargetsService.On("GetSettingsByID", "cluster_id").Return(&models.ClusterSettings{
+		TargetType:          models.CheckTargetCluster,
+		SelectedChecks:      []string{"ABCDEF", "123456"},
+		NotApplicableChecks: []string{"AZURE1"},
 		Hosts: []*models.HostConnection{
 			{
 				Name: "host1",
@@ -387,10 +390,21 @@ func TestApiCheckGetSettingsByIdHandler(t *testing.T) {
 				User: "user2",
 			},
 		},
+		CheckParameters: map[string]string{"1.1.1": "5000", "1.1.2": "36000"},
+	}, nil)
+	mockCheckTargetsService.On("GetNotApplicableChecks", "cluster_id").Return([]string{"AZURE1", "AZURE2"}, nil)
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetCheckParameters", models.CheckParametersClusterScope, "cluster_id").Return(
+		map[string]string{"1.1.1": "5000"}, nil)
+	mockChecksService.On("GetConnectionSettingsById", "cluster_id").Return(map[string]models.ConnectionSettings{
+		models.ClusterConnectionNode: {ID: "cluster_id", ProxyJump: "bastion"},
+		"host1":                      {ID: "cluster_id", Node: "host1", User: "user1", Port: 2222},
 	}, nil)
 
 	deps := setupTestDependencies()
-	deps.clustersService = mockClustersService
+	deps.checkTargetsService = mockCheckTargetsService
+	deps.checksService = mockChecksService
 
 	config := setupTestConfig()
 	app, err := NewAppWithDeps(config, deps)
@@ -406,20 +420,29 @@ func TestApiCheckGetSettingsByIdHandler(t *testing.T) {
 	json.Unmarshal(resp.Body.Bytes(), &settings)
 
 	assert.Equal(t, 200, resp.Code)
-	assert.Equal(t, []string{"ABCDEF", "123456"}, settings.SelectedChecks)
+	assert.Equal(t, models.CheckTargetCluster, settings.TargetType)
+	assert.Equal(t, []string{"ABCDEF", "123456", "AZURE1"}, settings.SelectedChecks)
+	assert.Equal(t, []string{"AZURE1", "AZURE2"}, settings.NotApplicableChecks)
 	assert.Equal(t, map[string]string{
 		"host1": "user1",
 		"host2": "user2",
 	}, settings.ConnectionSettings)
 	assert.Equal(t, []string{"host1", "host2"}, settings.Hostnames)
+	assert.Equal(t, map[string]string{"1.1.1": "5000"}, settings.CheckParameters)
+	assert.Equal(t, map[string]string{"1.1.1": "5000", "1.1.2": "36000"}, settings.EffectiveCheckParameters)
+	assert.Equal(t, &JSONConnectionProfile{ProxyJump: "bastion"}, settings.ClusterConnectionProfile)
+	assert.Equal(t, map[string]*JSONConnectionProfile{
+		"host1": {User: "user1", Port: 2222},
+		"host2": {},
+	}, settings.ConnectionProfiles)
 }
 
 func TestApiCheckGetSettingsByIdHandler404(t *testing.T) {
-	mockClustersService := new(services.MockClustersService)
-	mockClustersService.On("GetClusterSettingsByID", "not_found").Return(nil, nil)
+	mockCheckTargetsService := new(services.MockCheckTargetsService)
+	mockCheckTargetsService.On("GetSettingsByID", "not_found").Return(nil, nil)
 
 	deps := setupTestDependencies()
-	deps.clustersService = mockClustersService
+	deps.checkTargetsService = mockCheckTargetsService
 
 	config := setupTestConfig()
 	app, err := NewAppWithDeps(config, deps)
@@ -442,10 +465,15 @@ func TestApiCheckCreateConnectionByIdHandler(t *testing.T) {
 	mockChecksService.On(
 		"CreateSelectedChecks", "otherId", []string{"ABCDEF", "123456"}).Return(fmt.Errorf("not storing"))
 
-	mockChecksService.On(
-		"CreateConnectionSettings", "group1", "node1", "user1").Return(nil)
-	mockChecksService.On(
-		"CreateConnectionSettings", "group1", "node2", "user2").Return(nil)
+	mockChecksService.On("GetConnectionSettingsById", "group1").Return(map[string]models.ConnectionSettings{
+		"node1": {ID: "group1", Node: "node1", User: "olduser", Port: 2222},
+	}, nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: "node1", User: "user1", Port: 2222,
+	}).Return(nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: "node2", User: "user2",
+	}).Return(nil)
 
 	deps := setupTestDependencies()
 	deps.checksService = mockChecksService
@@ -487,4 +515,335 @@ func TestApiCheckCreateConnectionByIdHandler(t *testing.T) {
 	assert.Equal(t, 500, resp.Code)
 
 	mockChecksService.AssertExpectations(t)
+	mockChecksService.AssertNotCalled(t, "CreateCheckParameters", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApiCheckCreateSettingsByIdHandlerCheckParameters(t *testing.T) {
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("CreateSelectedChecks", "group1", []string{"ABCDEF"}).Return(nil)
+	mockChecksService.On("GetConnectionSettingsById", "group1").Return(map[string]models.ConnectionSettings{}, nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: "node1", User: "user1",
+	}).Return(nil)
+	mockChecksService.On("CreateCheckParameters", models.CheckParametersClusterScope, "group1",
+		map[string]string{"1.1.1": "5000"}).Return(nil)
+	mockChecksService.On("CreateCheckParameters", models.CheckParametersClusterScope, "group1",
+		map[string]string{"1.1.1": "5'000"}).Return(fmt.Errorf("%w: quotes", services.ErrInvalidCheckParameters))
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for value, expectedCode := range map[string]int{"5000": http.StatusCreated, "5'000": http.StatusBadRequest} {
+		body, _ := json.Marshal(&JSONChecksSettings{
+			SelectedChecks:     []string{"ABCDEF"},
+			ConnectionSettings: map[string]string{"node1": "user1"},
+			CheckParameters:    map[string]string{"1.1.1": value},
+		})
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/checks/group1/settings", bytes.NewBuffer(body))
+		req.Header.Set("Accept", "application/json")
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, expectedCode, resp.Code, value)
+	}
+
+	mockChecksService.AssertExpectations(t)
+}
+
+func TestApiCheckCreateSettingsByIdHandlerConnectionProfiles(t *testing.T) {
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("CreateSelectedChecks", "group1", []string{"ABCDEF"}).Return(nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: models.ClusterConnectionNode, Port: 2222, ProxyJump: "admin@bastion",
+	}).Return(nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: "node1", User: "admin", PrivateKey: "/etc/trento/node1", BecomeMethod: "sudo",
+	}).Return(nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: "node2", User: "user2",
+	}).Return(nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: "node3", Port: 70000,
+	}).Return(fmt.Errorf("%w: the port 70000 is out of range", services.ErrInvalidConnectionSettings))
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(&JSONChecksSettings{
+		SelectedChecks:           []string{"ABCDEF"},
+		ConnectionSettings:       map[string]string{"node1": "user1", "node2": "user2"},
+		ClusterConnectionProfile: &JSONConnectionProfile{Port: 2222, ProxyJump: "admin@bastion"},
+		ConnectionProfiles: map[string]*JSONConnectionProfile{
+			"node1": {User: "admin", PrivateKey: "/etc/trento/node1", BecomeMethod: "sudo"},
+		},
+	})
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/group1/settings", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	body, _ = json.Marshal(&JSONChecksSettings{
+		SelectedChecks:     []string{"ABCDEF"},
+		ConnectionSettings: map[string]string{},
+		ConnectionProfiles: map[string]*JSONConnectionProfile{"node3": {Port: 70000}},
+	})
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/checks/group1/settings", bytes.NewBuffer(body))
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	mockChecksService.AssertExpectations(t)
+	mockChecksService.AssertNotCalled(t, "GetConnectionSettingsById", mock.Anything)
+}
+
+func TestApiCheckCreateSettingsByIdHandlerEffectiveUsers(t *testing.T) {
+	mockCheckTargetsService := new(services.MockCheckTargetsService)
+	mockCheckTargetsService.On("GetTargetType", "group1").Return(models.CheckTargetCluster, nil)
+	mockCheckTargetsService.On("ValidateSelectedChecks", models.CheckTargetCluster, []string{"ABCDEF"}).Return(nil)
+	// node1 connects with the default user, node2 with the one of the cluster profile
+	mockCheckTargetsService.On("GetSettingsByID", "group1").Return(&models.ClusterSettings{
+		Hosts: []*models.HostConnection{
+			{Name: "node1", User: "root"},
+			{Name: "node2", User: "sapadm"},
+			{Name: "node3", User: "root"},
+		},
+	}, nil)
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("CreateSelectedChecks", "group1", []string{"ABCDEF"}).Return(nil)
+	mockChecksService.On("GetConnectionSettingsById", "group1").Return(map[string]models.ConnectionSettings{}, nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: "node3", User: "admin",
+	}).Return(nil)
+	mockChecksService.On("CreateConnectionSettings", models.ConnectionSettings{
+		ID: "group1", Node: "node1", Port: 2222,
+	}).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+	deps.checkTargetsService = mockCheckTargetsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the users returned by the settings are sent back, only the changed one is stored
+	body, _ := json.Marshal(&JSONChecksSettings{
+		SelectedChecks:     []string{"ABCDEF"},
+		ConnectionSettings: map[string]string{"node1": "root", "node2": "sapadm", "node3": "admin"},
+	})
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/group1/settings", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	// the profiles don't get the effective users either
+	body, _ = json.Marshal(&JSONChecksSettings{
+		SelectedChecks:     []string{"ABCDEF"},
+		ConnectionSettings: map[string]string{"node1": "root", "node2": "sapadm"},
+		ConnectionProfiles: map[string]*JSONConnectionProfile{"node1": {Port: 2222}},
+	})
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/checks/group1/settings", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	mockChecksService.AssertExpectations(t)
+	mockChecksService.AssertNumberOfCalls(t, "CreateConnectionSettings", 2)
+}
+
+func TestApiTagCheckParametersHandlers(t *testing.T) {
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetCheckParameters", models.CheckParametersTagScope, "azure").Return(
+		map[string]string{"1.1.1": "30000"}, nil)
+	mockChecksService.On("CreateCheckParameters", models.CheckParametersTagScope, "azure",
+		map[string]string{"1.1.1": "30000", "1.1.2": "36000"}).Return(nil)
+	mockChecksService.On("CreateCheckParameters", models.CheckParametersTagScope, "azure",
+		map[string]string{"wrong name": "1"}).Return(fmt.Errorf("%w: wrong name", services.ErrInvalidCheckParameters))
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/parameters/tags/azure", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"1.1.1":"30000"}`, resp.Body.String())
+
+	for body, expectedCode := range map[string]int{
+		`{"1.1.1":"30000","1.1.2":"36000"}`: http.StatusOK,
+		`{"wrong name":"1"}`:                http.StatusBadRequest,
+		`["1.1.1"]`:                         http.StatusBadRequest,
+	} {
+		resp = httptest.NewRecorder()
+		req = httptest.NewRequest("PUT", "/api/checks/parameters/tags/azure", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, expectedCode, resp.Code, body)
+	}
+}
+
+func TestApiClusterChecksResultsHistoryHandler(t *testing.T) {
+	history := []*models.ChecksResultSummary{
+		{
+			ID:         2,
+			Health:     models.CheckCritical,
+			Aggregated: &models.AggregatedCheckData{PassingCount: 1, CriticalCount: 1},
+		},
+		{
+			ID:         1,
+			Health:     models.CheckPassing,
+			Aggregated: &models.AggregatedCheckData{PassingCount: 2},
+		},
+	}
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On(
+		"GetChecksResultsHistoryByCluster", "cluster1", &services.Page{Number: 2, Size: 10}).Return(history, nil)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/results/history?page=2&per_page=10", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(history)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+	mockChecksService.AssertExpectations(t)
+}
+
+func TestApiClusterChecksResultsDiffHandler(t *testing.T) {
+	diff := &models.ChecksResultDiff{
+		From: &models.ChecksResultSummary{ID: 1},
+		To:   &models.ChecksResultSummary{ID: 2},
+		Changes: []*models.CheckResultChange{
+			{CheckID: "check1", Host: "host1", From: models.CheckPassing, To: models.CheckCritical},
+		},
+	}
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetChecksResultsDiff", "cluster1", int64(1), int64(2)).Return(diff, nil)
+	mockChecksService.On("GetChecksResultsDiff", "cluster1", int64(1), int64(3)).Return(nil, gorm.ErrRecordNotFound)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/results/diff?from=1&to=2", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(diff)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/cluster1/results/diff?from=1&to=3", nil)
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/cluster1/results/diff?from=1", nil)
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestApiChecksCatalogVersionsHandlers(t *testing.T) {
+	versions := []*models.ChecksCatalogVersion{
+		{
+			ID:         2,
+			Added:      []string{"check4"},
+			Removed:    []string{"check3"},
+			Changed:    []string{},
+			Superseded: map[string]string{"check3": "check4"},
+		},
+		{ID: 1, Added: []string{"check1", "check3"}, Removed: []string{}, Changed: []string{}},
+	}
+	version := *versions[0]
+	version.Catalog = models.ChecksCatalog{{ID: "check1", Name: "name1"}, {ID: "check4", Name: "name4"}}
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetChecksCatalogVersions", &services.Page{Number: 1, Size: 50}).Return(versions, nil)
+	mockChecksService.On("GetChecksCatalogVersion", int64(2)).Return(&version, nil)
+	mockChecksService.On("GetChecksCatalogVersion", int64(3)).Return(nil, gorm.ErrRecordNotFound)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/catalog/versions", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(versions)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/checks/catalog/versions/2", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ = json.Marshal(version)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	for url, code := range map[string]int{
+		"/api/checks/catalog/versions/3":   http.StatusNotFound,
+		"/api/checks/catalog/versions/foo": http.StatusBadRequest,
+	} {
+		resp = httptest.NewRecorder()
+		req = httptest.NewRequest("GET", url, nil)
+		req.Header.Set("Accept", "application/json")
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, code, resp.Code, url)
+	}
 }
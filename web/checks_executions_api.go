@@ -0,0 +1,224 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONChecksExecution models.ChecksExecution
+
+type JSONChecksExecutionStart struct {
+	ClusterID string `json:"cluster_id" binding:"required"`
+	Trigger   string `json:"trigger" binding:"required"`
+}
+
+type JSONChecksExecutionResult struct {
+	Status     string            `json:"status" binding:"required"`
+	ExitCode   *int              `json:"exit_code,omitempty"`
+	Stderr     string            `json:"stderr,omitempty"`
+	HostErrors map[string]string `json:"host_errors,omitempty"`
+}
+
+// ApiClusterChecksExecuteHandler godoc
+// @Summary Request an on-demand checks execution for a cluster
+// @Produce json
+// @Param id path string true "Cluster Id"
+// @Success 202 {object} JSONChecksExecution
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{id}/checks/execute [post]
+func ApiClusterChecksExecuteHandler(clusters services.ClustersService, executions services.ChecksExecutionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterID := c.Param("id")
+
+		cluster, err := clusters.GetByID(clusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if cluster == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		execution, err := executions.Enqueue(clusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, execution)
+	}
+}
+
+// ApiClusterLastChecksExecutionHandler godoc
+// @Summary Get the status of the last on-demand checks execution of a cluster
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} JSONChecksExecution
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/checks/executions/last [get]
+func ApiClusterLastChecksExecutionHandler(executions services.ChecksExecutionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		execution, err := executions.GetLastByCluster(c.Param("cluster_id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if execution == nil {
+			_ = c.Error(NotFoundError("no checks execution found"))
+			return
+		}
+
+		c.JSON(http.StatusOK, execution)
+	}
+}
+
+// ApiClusterChecksExecutionsHandler godoc
+// @Summary Get the checks executions of a cluster, the most recent first
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Param status query []string false "Filter by status, e.g. failed"
+// @Param page query int false "Page number"
+// @Param per_page query int false "Executions per page"
+// @Success 200 {array} JSONChecksExecution
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/checks/executions [get]
+func ApiClusterChecksExecutionsHandler(executions services.ChecksExecutionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pageNumber, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+		if err != nil {
+			pageNumber = 1
+		}
+		pageSize, err := strconv.Atoi(c.DefaultQuery("per_page", "50"))
+		if err != nil {
+			pageSize = 50
+		}
+
+		executionList, err := executions.GetAllByCluster(
+			c.Param("cluster_id"), c.QueryArray("status"), &services.Page{Number: pageNumber, Size: pageSize})
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, executionList)
+	}
+}
+
+// ApiStartChecksExecutionHandler godoc
+// @Summary Record a checks execution started by the runner, e.g. a scheduled one
+// @Accept json
+// @Produce json
+// @Param Body body JSONChecksExecutionStart true "Cluster and trigger, scheduled or manual"
+// @Success 201 {object} JSONChecksExecution
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /checks/executions [post]
+func ApiStartChecksExecutionHandler(executions services.ChecksExecutionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONChecksExecutionStart
+
+		err := c.BindJSON(&r)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		if r.Trigger != models.ChecksExecutionScheduled && r.Trigger != models.ChecksExecutionManual {
+			_ = c.Error(BadRequestError("trigger must be either scheduled or manual"))
+			return
+		}
+
+		execution, err := executions.Start(r.ClusterID, r.Trigger)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, execution)
+	}
+}
+
+// ApiClaimChecksExecutionHandler godoc
+// @Summary Claim the oldest queued checks execution, used by the runner
+// @Produce json
+// @Param runner_id query string false "Runner Id, to claim the executions of the clusters leased to the runner only"
+// @Success 200 {object} JSONChecksExecution
+// @Success 204
+// @Failure 500 {object} map[string]string
+// @Router /checks/executions/claim [post]
+func ApiClaimChecksExecutionHandler(executions services.ChecksExecutionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		execution, err := executions.Claim(c.Query("runner_id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if execution == nil {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		c.JSON(http.StatusOK, execution)
+	}
+}
+
+// ApiUpdateChecksExecutionHandler godoc
+// @Summary Store the outcome of a running checks execution, used by the runner
+// @Accept json
+// @Produce json
+// @Param id path int true "Execution Id"
+// @Param Body body JSONChecksExecutionResult true "Final status, completed or failed, and execution details"
+// @Success 200 {object} JSONChecksExecutionResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /checks/executions/{id} [put]
+func ApiUpdateChecksExecutionHandler(executions services.ChecksExecutionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid checks execution id"))
+			return
+		}
+
+		var r JSONChecksExecutionResult
+
+		err = c.BindJSON(&r)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		if r.Status != models.ChecksExecutionCompleted && r.Status != models.ChecksExecutionFailed {
+			_ = c.Error(BadRequestError("status must be either completed or failed"))
+			return
+		}
+
+		err = executions.Complete(id, &models.ChecksExecutionResult{
+			Status:     r.Status,
+			ExitCode:   r.ExitCode,
+			Stderr:     r.Stderr,
+			HostErrors: r.HostErrors,
+		})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = c.Error(NotFoundError("could not find a running checks execution"))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &r)
+	}
+}
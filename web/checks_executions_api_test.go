@@ -0,0 +1,253 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiClusterChecksExecuteHandler(t *testing.T) {
+	execution := &models.ChecksExecution{
+		ID:        1,
+		ClusterID: "47d1190ffb4f781974c8356d7f863b03",
+		Status:    models.ChecksExecutionQueued,
+	}
+
+	mockClustersService := new(services.MockClustersService)
+	mockClustersService.On("GetByID", "47d1190ffb4f781974c8356d7f863b03").Return(&models.Cluster{}, nil)
+	mockClustersService.On("GetByID", "other").Return(nil, nil)
+
+	mockChecksExecutionsService := new(services.MockChecksExecutionsService)
+	mockChecksExecutionsService.On("Enqueue", "47d1190ffb4f781974c8356d7f863b03").Return(execution, nil)
+
+	deps := setupTestDependencies()
+	deps.clustersService = mockClustersService
+	deps.checksExecutionsService = mockChecksExecutionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/clusters/47d1190ffb4f781974c8356d7f863b03/checks/execute", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(execution)
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/clusters/other/checks/execute", nil)
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	mockChecksExecutionsService.AssertNumberOfCalls(t, "Enqueue", 1)
+}
+
+func TestApiClusterLastChecksExecutionHandler(t *testing.T) {
+	execution := &models.ChecksExecution{
+		ID:        1,
+		ClusterID: "cluster1",
+		Status:    models.ChecksExecutionRunning,
+	}
+
+	mockChecksExecutionsService := new(services.MockChecksExecutionsService)
+	mockChecksExecutionsService.On("GetLastByCluster", "cluster1").Return(execution, nil)
+	mockChecksExecutionsService.On("GetLastByCluster", "cluster2").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.checksExecutionsService = mockChecksExecutionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/checks/executions/last", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(execution)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/cluster2/checks/executions/last", nil)
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestApiClaimChecksExecutionHandler(t *testing.T) {
+	execution := &models.ChecksExecution{
+		ID:        1,
+		ClusterID: "cluster1",
+		Status:    models.ChecksExecutionRunning,
+	}
+
+	mockChecksExecutionsService := new(services.MockChecksExecutionsService)
+	mockChecksExecutionsService.On("Claim", "").Return(execution, nil).Once()
+	mockChecksExecutionsService.On("Claim", "").Return(nil, nil).Once()
+	mockChecksExecutionsService.On("Claim", "runner1").Return(nil, nil).Once()
+
+	deps := setupTestDependencies()
+	deps.checksExecutionsService = mockChecksExecutionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/executions/claim", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(execution)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/checks/executions/claim", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Empty(t, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/checks/executions/claim?runner_id=runner1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	mockChecksExecutionsService.AssertExpectations(t)
+}
+
+func TestApiUpdateChecksExecutionHandler(t *testing.T) {
+	exitCode := 2
+
+	mockChecksExecutionsService := new(services.MockChecksExecutionsService)
+	mockChecksExecutionsService.On("Complete", int64(1), &models.ChecksExecutionResult{
+		Status: models.ChecksExecutionCompleted,
+	}).Return(nil)
+	mockChecksExecutionsService.On("Complete", int64(2), &models.ChecksExecutionResult{
+		Status:     models.ChecksExecutionFailed,
+		ExitCode:   &exitCode,
+		Stderr:     "ERROR! the playbook could not be found",
+		HostErrors: map[string]string{"host1": "unreachable"},
+	}).Return(gorm.ErrRecordNotFound)
+
+	deps := setupTestDependencies()
+	deps.checksExecutionsService = mockChecksExecutionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		url    string
+		body   string
+		status int
+	}{
+		{"/api/checks/executions/1", `{"status":"completed"}`, http.StatusOK},
+		{"/api/checks/executions/2", `{"status":"failed","exit_code":2,"stderr":"ERROR! the playbook could not be found","host_errors":{"host1":"unreachable"}}`, http.StatusNotFound},
+		{"/api/checks/executions/1", `{"status":"running"}`, http.StatusBadRequest},
+		{"/api/checks/executions/abc", `{"status":"completed"}`, http.StatusBadRequest},
+		{"/api/checks/executions/1", `{}`, http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("PUT", tc.url, bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, tc.status, resp.Code, tc.url+" "+tc.body)
+	}
+
+	mockChecksExecutionsService.AssertExpectations(t)
+}
+
+func TestApiClusterChecksExecutionsHandler(t *testing.T) {
+	exitCode := 4
+	executionList := []*models.ChecksExecution{
+		{
+			ID:         2,
+			ClusterID:  "cluster1",
+			Status:     models.ChecksExecutionFailed,
+			Trigger:    models.ChecksExecutionScheduled,
+			ExitCode:   &exitCode,
+			HostErrors: map[string]string{"host1": "unreachable"},
+		},
+	}
+
+	mockChecksExecutionsService := new(services.MockChecksExecutionsService)
+	mockChecksExecutionsService.On(
+		"GetAllByCluster", "cluster1", []string{"failed"}, &services.Page{Number: 1, Size: 50}).Return(executionList, nil)
+
+	deps := setupTestDependencies()
+	deps.checksExecutionsService = mockChecksExecutionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/checks/executions?status=failed", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(executionList)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+	mockChecksExecutionsService.AssertExpectations(t)
+}
+
+func TestApiStartChecksExecutionHandler(t *testing.T) {
+	execution := &models.ChecksExecution{
+		ID:        1,
+		ClusterID: "cluster1",
+		Status:    models.ChecksExecutionRunning,
+		Trigger:   models.ChecksExecutionScheduled,
+	}
+
+	mockChecksExecutionsService := new(services.MockChecksExecutionsService)
+	mockChecksExecutionsService.On("Start", "cluster1", models.ChecksExecutionScheduled).Return(execution, nil)
+
+	deps := setupTestDependencies()
+	deps.checksExecutionsService = mockChecksExecutionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/executions", bytes.NewBufferString(`{"cluster_id":"cluster1","trigger":"scheduled"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(execution)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/checks/executions", bytes.NewBufferString(`{"cluster_id":"cluster1","trigger":"other"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	mockChecksExecutionsService.AssertNumberOfCalls(t, "Start", 1)
+}
@@ -0,0 +1,123 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// checksProgressStreamDuration is how long a progress stream is kept open. It ends before the server write timeout,
+// the browsers reconnect right away and get the current progress first, so no update is lost
+const checksProgressStreamDuration = webServerWriteTimeout - 2*time.Second
+
+type JSONChecksProgressEvent struct {
+	Type    string    `json:"type" binding:"required"`
+	Host    string    `json:"host,omitempty"`
+	CheckID string    `json:"check_id,omitempty"`
+	Task    string    `json:"task,omitempty"`
+	Result  string    `json:"result,omitempty"`
+	Msg     string    `json:"msg,omitempty"`
+	Time    time.Time `json:"time,omitempty"`
+}
+
+// ApiCreateChecksProgressEventHandler godoc
+// @Summary Publish a progress event of the checks run of a target, as the runner executes the checks
+// @Accept json
+// @Produce json
+// @Param id path string true "Checks target id"
+// @Param Body body JSONChecksProgressEvent true "Checks progress event"
+// @Success 201 {object} JSONChecksProgressEvent
+// @Failure 400 {object} map[string]string
+// @Router /checks/{id}/progress [post]
+func ApiCreateChecksProgressEventHandler(progress services.ChecksProgressService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONChecksProgressEvent
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		event := models.ChecksProgressEvent(r)
+		err := progress.Publish(c.Param("id"), &event)
+		if errors.Is(err, services.ErrInvalidChecksProgressEvent) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, JSONChecksProgressEvent(event))
+	}
+}
+
+// ApiGetChecksProgressHandler godoc
+// @Summary Get the progress of the current, or last, checks run of a target
+// @Produce json
+// @Param id path string true "Checks target id"
+// @Success 200 {object} models.ChecksProgress
+// @Failure 404 {object} map[string]string
+// @Router /checks/{id}/progress [get]
+func ApiGetChecksProgressHandler(progress services.ChecksProgressService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		current := progress.GetProgress(c.Param("id"))
+		if current == nil {
+			_ = c.Error(NotFoundError("no checks progress found"))
+			return
+		}
+
+		c.JSON(http.StatusOK, current)
+	}
+}
+
+// ApiChecksProgressStreamHandler godoc
+// @Summary Stream the progress of the checks runs of a target as server-sent events
+// @Description Every "progress" event has the whole progress of the run. The current one is sent first
+// @Produce text/event-stream
+// @Param id path string true "Checks target id"
+// @Success 200 {object} models.ChecksProgress
+// @Router /checks/{id}/progress/stream [get]
+func ApiChecksProgressStreamHandler(progress services.ChecksProgressService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		updates, unsubscribe := progress.Subscribe(id)
+		defer unsubscribe()
+
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		if current := progress.GetProgress(id); current != nil {
+			c.SSEvent("progress", current)
+		} else {
+			c.SSEvent("waiting", id)
+		}
+		c.Writer.Flush()
+
+		timeout := time.NewTimer(checksProgressStreamDuration)
+		defer timeout.Stop()
+
+		for {
+			select {
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				c.SSEvent("progress", update)
+				c.Writer.Flush()
+			case <-timeout.C:
+				return
+			case <-c.Request.Context().Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiCreateChecksProgressEventHandler(t *testing.T) {
+	deps := setupTestDependencies()
+	deps.checksProgressService = services.NewChecksProgressService()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(&JSONChecksProgressEvent{Type: models.ChecksProgressTask, Host: "node1", CheckID: "156F64"})
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/cluster1/progress", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/checks/cluster1/progress", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	var progress models.ChecksProgress
+	json.Unmarshal(resp.Body.Bytes(), &progress)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, models.ChecksExecutionRunning, progress.Status)
+	assert.Equal(t, "156F64", progress.Hosts["node1"].CheckID)
+
+	// the result events need the check result
+	body, _ = json.Marshal(&JSONChecksProgressEvent{Type: models.ChecksProgressResult, Host: "node1", CheckID: "156F64"})
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/checks/cluster1/progress", bytes.NewBuffer(body))
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestApiGetChecksProgressHandler404(t *testing.T) {
+	deps := setupTestDependencies()
+	deps.checksProgressService = services.NewChecksProgressService()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/cluster1/progress", nil)
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestApiChecksProgressStreamHandler(t *testing.T) {
+	startedAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	current := models.NewChecksProgress("cluster1", startedAt)
+
+	update := current.Copy()
+	update.Apply(&models.ChecksProgressEvent{Type: models.ChecksProgressTask, Host: "node1", CheckID: "156F64", Time: startedAt})
+
+	// the stream ends when the updates channel is closed
+	updates := make(chan *models.ChecksProgress, 1)
+	updates <- update
+	close(updates)
+
+	mockChecksProgressService := new(services.MockChecksProgressService)
+	mockChecksProgressService.On("Subscribe", "cluster1").Return((<-chan *models.ChecksProgress)(updates), func() {})
+	mockChecksProgressService.On("GetProgress", "cluster1").Return(current)
+
+	deps := setupTestDependencies()
+	deps.checksProgressService = mockChecksProgressService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/cluster1/progress/stream", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	currentJSON, _ := json.Marshal(current)
+	updateJSON, _ := json.Marshal(update)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/event-stream", resp.Header().Get("Content-Type"))
+	assert.Equal(t,
+		"event:progress\ndata:"+string(currentJSON)+"\n\nevent:progress\ndata:"+string(updateJSON)+"\n\n",
+		resp.Body.String())
+	mockChecksProgressService.AssertExpectations(t)
+}
@@ -131,10 +131,42 @@ func NewClusterHandler(clusterService services.ClustersService) gin.HandlerFunc
 			Layout:        "vertical",
 		}
 
+		alerts := GetAlerts(c)
+
+		settings, err := clusterService.GetClusterSettingsByID(clusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if settings != nil && len(settings.RemovedChecks) > 0 {
+			alerts = append(alerts, SelectedChecksRemoved(settings.RemovedChecks))
+		}
+
 		c.HTML(http.StatusOK, "cluster_hana.html.tmpl", gin.H{
 			"Cluster":         cluster,
 			"HealthContainer": hContainer,
-			"Alerts":          GetAlerts(c),
+			"Alerts":          alerts,
+		})
+	}
+}
+
+func NewClusterChecksHistoryHandler(clusterService services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterID := c.Param("id")
+
+		cluster, err := clusterService.GetByID(clusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if cluster == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		c.HTML(http.StatusOK, "cluster_checks_history.html.tmpl", gin.H{
+			"Cluster": cluster,
 		})
 	}
 }
@@ -29,3 +29,65 @@ func ApiGetClustersSettingsHandler(clusters services.ClustersService) gin.Handle
 		c.JSON(http.StatusOK, clustersSettings)
 	}
 }
+
+// ApiListClustersHandler godoc
+// @Summary Retrieve the clusters, optionally filtered
+// @Produce json
+// @Param name query []string false "Filter by names" collectionFormat(multi)
+// @Param cluster_type query []string false "Filter by cluster types" collectionFormat(multi)
+// @Param sids query []string false "Filter by SAP system SIDs" collectionFormat(multi)
+// @Param tags query []string false "Filter by tags" collectionFormat(multi)
+// @Param health query []string false "Filter by checks health: passing, warning or critical" collectionFormat(multi)
+// @Param page query int false "Page number, all the clusters are returned when no page is given"
+// @Param per_page query int false "Clusters per page"
+// @Success 200 {array} models.Cluster
+// @Failure 500 {object} map[string]string
+// @Router /clusters [get]
+func ApiListClustersHandler(clusters services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+
+		clusterList, err := clusters.GetAll(&services.ClustersFilter{
+			Name:        query["name"],
+			ClusterType: query["cluster_type"],
+			SIDs:        query["sids"],
+			Tags:        query["tags"],
+			Health:      query["health"],
+		}, queryOptionalPage(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if clusterList == nil {
+			clusterList = models.ClusterList{}
+		}
+
+		c.JSON(http.StatusOK, clusterList)
+	}
+}
+
+// ApiGetClusterHandler godoc
+// @Summary Retrieve a cluster, with the details of the HANA clusters
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} models.Cluster
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id} [get]
+func ApiGetClusterHandler(clusters services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cluster, err := clusters.GetByID(c.Param("cluster_id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if cluster == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		c.JSON(http.StatusOK, cluster)
+	}
+}
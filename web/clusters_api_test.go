@@ -109,3 +109,49 @@ func mockedClustersSettings() models.ClustersSettings {
 		},
 	}
 }
+
+func (suite *ClustersApiTestCase) Test_ListAndGetClusters() {
+	cluster := &models.Cluster{
+		ID:          "cluster1",
+		Name:        "hana_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		Details:     &models.HANAClusterDetails{FencingType: "external/sbd"},
+	}
+	suite.mockClusterService.On("GetAll", &services.ClustersFilter{ClusterType: []string{models.ClusterTypeHANAScaleUp}}, (*services.Page)(nil)).
+		Return(models.ClusterList{cluster}, nil)
+	suite.mockClusterService.On("GetByID", "cluster1").Return(cluster, nil)
+	suite.mockClusterService.On("GetByID", "unknown").Return(nil, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters?cluster_type=HANA+scale-up", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	var clusters models.ClusterList
+	json.Unmarshal(resp.Body.Bytes(), &clusters)
+
+	suite.Equal(200, resp.Code)
+	suite.Equal(models.ClusterList{cluster}, clusters)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/cluster1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	var found models.Cluster
+	json.Unmarshal(resp.Body.Bytes(), &found)
+
+	suite.Equal(200, resp.Code)
+	suite.Equal(cluster, &found)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/unknown", nil)
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(404, resp.Code)
+}
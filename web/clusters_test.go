@@ -428,6 +428,11 @@ func TestClusterHandlerHANA(t *testing.T) {
 			},
 		},
 	}, nil)
+	clustersService.On("GetClusterSettingsByID", clusterID).Return(&models.ClusterSettings{
+		ID:             clusterID,
+		SelectedChecks: []string{"156F64"},
+		RemovedChecks:  []string{"OLD001", "OLD002"},
+	}, nil)
 
 	deps := setupTestDependencies()
 	deps.clustersService = clustersService
@@ -457,6 +462,8 @@ func TestClusterHandlerHANA(t *testing.T) {
 
 	assert.Equal(t, 200, resp.Code)
 	assert.Contains(t, resp.Body.String(), "Cluster details")
+	assert.Contains(t, resp.Body.String(), "Some selected checks were removed from the catalog")
+	assert.Contains(t, resp.Body.String(), "The checks OLD001, OLD002 are not in the catalog anymore")
 	// Summary
 	assert.Regexp(t, regexp.MustCompile("<strong>SID:</strong><br><span.*>PRD</span>"), minified)
 	assert.Regexp(t, regexp.MustCompile("<strong>Cluster name:</strong><br><span.*>hana_cluster</span>"), minified)
@@ -479,3 +486,42 @@ func TestClusterHandlerHANA(t *testing.T) {
 	assert.Regexp(t, regexp.MustCompile("<td>dummy_failed</td><td>dummy</td><td>Started</td><td>failed</td><td>0</td>"), minified)
 	assert.Regexp(t, regexp.MustCompile("<h4>Stopped resources</h4><div.*><div.*><span .*>dummy_failed</span>"), minified)
 }
+
+func TestClusterChecksHistoryHandler(t *testing.T) {
+	clusterID := "47d1190ffb4f781974c8356d7f863b03"
+
+	clustersService := new(services.MockClustersService)
+	clustersService.On("GetByID", clusterID).Return(&models.Cluster{
+		ID:   clusterID,
+		Name: "hana_cluster",
+	}, nil)
+	clustersService.On("GetByID", "other").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.clustersService = clustersService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/clusters/"+clusterID+"/checks/history", nil)
+	req.Header.Set("Accept", "text/html")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Checks history")
+	assert.Contains(t, resp.Body.String(), `<a href="/clusters/`+clusterID+`">hana_cluster</a>`)
+	assert.Contains(t, resp.Body.String(), `data-cluster-id="`+clusterID+`"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/clusters/other/checks/history", nil)
+	req.Header.Set("Accept", "text/html")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
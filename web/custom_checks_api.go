@@ -0,0 +1,178 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONCustomCheck struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Group          string `json:"group"`
+	Description    string `json:"description"`
+	Remediation    string `json:"remediation,omitempty"`
+	Labels         string `json:"labels,omitempty"`
+	Implementation string `json:"implementation"`
+}
+
+func (j *JSONCustomCheck) toModel() *models.CustomCheck {
+	return &models.CustomCheck{
+		ID:             j.ID,
+		Name:           j.Name,
+		Group:          j.Group,
+		Description:    j.Description,
+		Remediation:    j.Remediation,
+		Labels:         j.Labels,
+		Implementation: j.Implementation,
+	}
+}
+
+// ApiListCustomChecksHandler godoc
+// @Summary Retrieve the custom checks
+// @Produce json
+// @Success 200 {object} []models.CustomCheck
+// @Failure 500 {object} map[string]string
+// @Router /checks/custom [get]
+func ApiListCustomChecksHandler(s services.CustomChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		customChecks, err := s.GetAll()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, customChecks)
+	}
+}
+
+// ApiGetCustomCheckHandler godoc
+// @Summary Retrieve a custom check
+// @Produce json
+// @Param id path string true "Check Id"
+// @Success 200 {object} models.CustomCheck
+// @Failure 404 {object} map[string]string
+// @Router /checks/custom/{id} [get]
+func ApiGetCustomCheckHandler(s services.CustomChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		customCheck, err := s.GetByID(c.Param("id"))
+		if err != nil {
+			_ = c.Error(customCheckError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, customCheck)
+	}
+}
+
+// ApiGetCustomCheckVersionsHandler godoc
+// @Summary Retrieve all the versions of a custom check, the newest first
+// @Produce json
+// @Param id path string true "Check Id"
+// @Success 200 {object} []models.CustomCheck
+// @Failure 404 {object} map[string]string
+// @Router /checks/custom/{id}/versions [get]
+func ApiGetCustomCheckVersionsHandler(s services.CustomChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		versions, err := s.GetVersions(c.Param("id"))
+		if err != nil {
+			_ = c.Error(customCheckError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, versions)
+	}
+}
+
+// ApiCreateCustomCheckHandler godoc
+// @Summary Create a custom check, extending the checks catalog
+// @Accept json
+// @Produce json
+// @Param Body body JSONCustomCheck true "Custom check"
+// @Success 201 {object} models.CustomCheck
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /checks/custom [post]
+func ApiCreateCustomCheckHandler(s services.CustomChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONCustomCheck
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		customCheck, err := s.Create(r.toModel())
+		if err != nil {
+			_ = c.Error(customCheckError(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, customCheck)
+	}
+}
+
+// ApiUpdateCustomCheckHandler godoc
+// @Summary Update a custom check, storing a new version of it
+// @Accept json
+// @Produce json
+// @Param id path string true "Check Id"
+// @Param Body body JSONCustomCheck true "Custom check"
+// @Success 200 {object} models.CustomCheck
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /checks/custom/{id} [put]
+func ApiUpdateCustomCheckHandler(s services.CustomChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONCustomCheck
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+		r.ID = c.Param("id")
+
+		customCheck, err := s.Update(r.toModel())
+		if err != nil {
+			_ = c.Error(customCheckError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, customCheck)
+	}
+}
+
+// ApiDeleteCustomCheckHandler godoc
+// @Summary Delete a custom check and all its versions
+// @Param id path string true "Check Id"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /checks/custom/{id} [delete]
+func ApiDeleteCustomCheckHandler(s services.CustomChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.Delete(c.Param("id")); err != nil {
+			_ = c.Error(customCheckError(err))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func customCheckError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFoundError("could not find the custom check")
+	case errors.Is(err, services.ErrInvalidCustomCheck):
+		return BadRequestError(err.Error())
+	case errors.Is(err, services.ErrCustomCheckConflict):
+		return ConflictError(err.Error())
+	default:
+		return err
+	}
+}
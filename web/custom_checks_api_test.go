@@ -0,0 +1,178 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+const testAdminToken = "some-admin-token"
+
+func setupAdminTestConfig() *Config {
+	config := setupTestConfig()
+	config.AdminToken = testAdminToken
+
+	return config
+}
+
+func TestApiCreateCustomCheckHandler(t *testing.T) {
+	request := &models.CustomCheck{
+		ID:             "SITE01",
+		Name:           "9.1.1",
+		Group:          "Site",
+		Implementation: "- debug: msg=site",
+	}
+	created := *request
+	created.Version = 1
+
+	mockCustomChecksService := new(services.MockCustomChecksService)
+	mockCustomChecksService.On("Create", request).Return(&created, nil).Once()
+	mockCustomChecksService.On("Create", request).Return(nil, services.ErrCustomCheckConflict).Once()
+	mockCustomChecksService.On("Create", &models.CustomCheck{ID: "SITE02"}).Return(
+		nil, fmt.Errorf("%w: the name is required", services.ErrInvalidCustomCheck)).Once()
+
+	deps := setupTestDependencies()
+	deps.customChecksService = mockCustomChecksService
+
+	app, err := NewAppWithDeps(setupAdminTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(request)
+	for _, expected := range []struct {
+		body []byte
+		code int
+	}{
+		{body, http.StatusCreated},
+		{body, http.StatusConflict},
+		{[]byte(`{"id":"SITE02"}`), http.StatusBadRequest},
+		{[]byte(`not json`), http.StatusBadRequest},
+	} {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/checks/custom", bytes.NewBuffer(expected.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, expected.code, resp.Code)
+	}
+
+	mockCustomChecksService.AssertExpectations(t)
+}
+
+func TestApiUpdateCustomCheckHandler(t *testing.T) {
+	updated := &models.CustomCheck{
+		ID:             "SITE01",
+		Version:        2,
+		Name:           "9.1.1",
+		Group:          "Site",
+		Implementation: "- debug: msg=site",
+	}
+
+	mockCustomChecksService := new(services.MockCustomChecksService)
+	mockCustomChecksService.On("Update", &models.CustomCheck{
+		ID:             "SITE01",
+		Name:           "9.1.1",
+		Group:          "Site",
+		Implementation: "- debug: msg=site",
+	}).Return(updated, nil)
+	mockCustomChecksService.On("Update", &models.CustomCheck{
+		ID:             "other",
+		Name:           "9.1.1",
+		Group:          "Site",
+		Implementation: "- debug: msg=site",
+	}).Return(nil, gorm.ErrRecordNotFound)
+
+	deps := setupTestDependencies()
+	deps.customChecksService = mockCustomChecksService
+
+	app, err := NewAppWithDeps(setupAdminTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the id of the path takes precedence over the one of the body
+	body := `{"id":"ignored","name":"9.1.1","group":"Site","implementation":"- debug: msg=site"}`
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/checks/custom/SITE01", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(updated)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/api/checks/custom/other", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestApiGetCustomChecksHandlers(t *testing.T) {
+	versions := []*models.CustomCheck{
+		{ID: "SITE01", Version: 2, Name: "9.1.1", Group: "Site"},
+		{ID: "SITE01", Version: 1, Name: "9.1.1", Group: "Site"},
+	}
+
+	mockCustomChecksService := new(services.MockCustomChecksService)
+	mockCustomChecksService.On("GetAll").Return(versions[:1], nil)
+	mockCustomChecksService.On("GetByID", "SITE01").Return(versions[0], nil)
+	mockCustomChecksService.On("GetByID", "other").Return(nil, gorm.ErrRecordNotFound)
+	mockCustomChecksService.On("GetVersions", "SITE01").Return(versions, nil)
+	mockCustomChecksService.On("Delete", "SITE01").Return(nil)
+	mockCustomChecksService.On("Delete", "other").Return(gorm.ErrRecordNotFound)
+
+	deps := setupTestDependencies()
+	deps.customChecksService = mockCustomChecksService
+
+	app, err := NewAppWithDeps(setupAdminTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, _ := json.Marshal(versions[:1])
+	check, _ := json.Marshal(versions[0])
+	all, _ := json.Marshal(versions)
+
+	for _, expected := range []struct {
+		method string
+		url    string
+		code   int
+		body   string
+	}{
+		{"GET", "/api/checks/custom", http.StatusOK, string(list)},
+		{"GET", "/api/checks/custom/SITE01", http.StatusOK, string(check)},
+		{"GET", "/api/checks/custom/other", http.StatusNotFound, ""},
+		{"GET", "/api/checks/custom/SITE01/versions", http.StatusOK, string(all)},
+		{"DELETE", "/api/checks/custom/SITE01", http.StatusNoContent, ""},
+		{"DELETE", "/api/checks/custom/other", http.StatusNotFound, ""},
+	} {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(expected.method, expected.url, nil)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, expected.code, resp.Code, expected.url)
+		if expected.body != "" {
+			assert.JSONEq(t, expected.body, resp.Body.String(), expected.url)
+		}
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"testing"
 
-	"github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/trento/agent/discovery/mocks"
+	"github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -63,7 +63,7 @@ func (s *SAPSystemsProjectorTestSuite) Test_SAPSystemDiscoveryHandler_Database()
 	s.Equal("HDB|HDB_WORKER", projectedSAPSystemInstance.Features)
 	s.Equal("Primary", projectedSAPSystemInstance.SystemReplication)
 	s.Equal("SFAIL", projectedSAPSystemInstance.SystemReplicationStatus)
-	s.Equal(pq.StringArray{"PRD"}, projectedSAPSystemInstance.Tenants)
+	s.Equal(db.StringArray{"PRD"}, projectedSAPSystemInstance.Tenants)
 	s.Equal("vmhana01", projectedSAPSystemInstance.SAPHostname)
 	s.Equal("0.3", projectedSAPSystemInstance.StartPriority)
 	s.Equal(50013, projectedSAPSystemInstance.HttpPort)
@@ -83,7 +83,7 @@ func (s *SAPSystemsProjectorTestSuite) Test_SAPSystemDiscoveryHandler_Database_O
 			Features:                "HDB|HDB_WORKER",
 			SystemReplication:       "Primary",
 			SystemReplicationStatus: "SFAIL",
-			Tenants:                 pq.StringArray{"PRD"},
+			Tenants:                 db.StringArray{"PRD"},
 			SAPHostname:             "vmhana01",
 			StartPriority:           "0.3",
 			HttpPort:                50013,
@@ -98,7 +98,7 @@ func (s *SAPSystemsProjectorTestSuite) Test_SAPSystemDiscoveryHandler_Database_O
 			Features:                "HDB|HDB_WORKER",
 			SystemReplication:       "Primary",
 			SystemReplicationStatus: "SFAIL",
-			Tenants:                 pq.StringArray{"PRD"},
+			Tenants:                 db.StringArray{"PRD"},
 			SAPHostname:             "vmhana02",
 			StartPriority:           "0.3",
 			HttpPort:                50013,
@@ -113,7 +113,7 @@ func (s *SAPSystemsProjectorTestSuite) Test_SAPSystemDiscoveryHandler_Database_O
 			Features:                "HDB|HDB_WORKER",
 			SystemReplication:       "Primary",
 			SystemReplicationStatus: "SFAIL",
-			Tenants:                 pq.StringArray{"PRD"},
+			Tenants:                 db.StringArray{"PRD"},
 			SAPHostname:             "vmhana03",
 			StartPriority:           "0.3",
 			HttpPort:                50013,
@@ -81,7 +81,7 @@ func (suite *SlesSubscriptionsProjectorTestSuite) Test_SlesSubscriptionsProjecto
 	subsProjector_SubscriptionDiscoveryHandler(dataCollectedEvent, suite.tx)
 
 	var projectedSub entities.SlesSubscription
-	suite.tx.Where("agent_id", "779cdd70-e9e2-58ca-b18a-bf3eb3f71244").Last(&projectedSub)
+	suite.tx.Where("agent_id = ? AND id = ?", "779cdd70-e9e2-58ca-b18a-bf3eb3f71244", "SLES_SAP").First(&projectedSub)
 
 	expectedSub := entities.SlesSubscription{
 		AgentID:            "779cdd70-e9e2-58ca-b18a-bf3eb3f71244",
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type AuditLogEntry struct {
+	ID        int64
+	CreatedAt time.Time `gorm:"index"`
+	Action    string
+	Resource  string
+	Actor     string
+	Message   string
+}
+
+func (e *AuditLogEntry) ToModel() *models.AuditLogEntry {
+	return &models.AuditLogEntry{
+		ID:        e.ID,
+		CreatedAt: e.CreatedAt,
+		Action:    e.Action,
+		Resource:  e.Resource,
+		Actor:     e.Actor,
+		Message:   e.Message,
+	}
+}
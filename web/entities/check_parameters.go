@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"encoding/json"
+
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type CheckParameters struct {
+	Scope      string `gorm:"primaryKey"`
+	Target     string `gorm:"primaryKey"`
+	Parameters datatypes.JSON
+}
+
+func (p *CheckParameters) ToModel() (*models.CheckParameters, error) {
+	parameters := make(map[string]string)
+	if len(p.Parameters) > 0 {
+		if err := json.Unmarshal(p.Parameters, &parameters); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.CheckParameters{
+		Scope:      p.Scope,
+		Target:     p.Target,
+		Parameters: parameters,
+	}, nil
+}
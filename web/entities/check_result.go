@@ -22,3 +22,19 @@ func (c *ChecksResult) ToModel() (*models.ChecksResult, error) {
 
 	return &checkResult, err
 }
+
+func (c *ChecksResult) ToSummary() (*models.ChecksResultSummary, error) {
+	checkResult, err := c.ToModel()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregated := checkResult.GetAggregatedChecksResultByCluster()
+
+	return &models.ChecksResultSummary{
+		ID:         c.ID,
+		CreatedAt:  c.CreatedAt,
+		Health:     aggregated.String(),
+		Aggregated: aggregated,
+	}, nil
+}
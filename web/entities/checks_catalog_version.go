@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type ChecksCatalogVersion struct {
+	ID         int64
+	CreatedAt  time.Time
+	Added      datatypes.JSON
+	Removed    datatypes.JSON
+	Changed    datatypes.JSON
+	Superseded datatypes.JSON
+	Catalog    datatypes.JSON
+}
+
+// ToModel returns the version, including the whole catalog only when withCatalog is set
+func (v *ChecksCatalogVersion) ToModel(withCatalog bool) (*models.ChecksCatalogVersion, error) {
+	version := &models.ChecksCatalogVersion{
+		ID:        v.ID,
+		CreatedAt: v.CreatedAt,
+	}
+
+	fields := map[*datatypes.JSON]interface{}{
+		&v.Added:      &version.Added,
+		&v.Removed:    &version.Removed,
+		&v.Changed:    &version.Changed,
+		&v.Superseded: &version.Superseded,
+	}
+	if withCatalog {
+		fields[&v.Catalog] = &version.Catalog
+	}
+
+	for data, field := range fields {
+		if len(*data) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(*data, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return version, nil
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type ChecksExecution struct {
+	ID          int64
+	ClusterID   string `gorm:"index;uniqueIndex:idx_checks_executions_queued_cluster,where:status = 'queued'"`
+	Status      string `gorm:"index"`
+	Trigger     string
+	RunnerID    string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	UpdatedAt   time.Time
+	ExitCode    *int
+	Stderr      string
+	HostErrors  datatypes.JSON
+}
+
+func (e *ChecksExecution) ToModel() *models.ChecksExecution {
+	execution := &models.ChecksExecution{
+		ID:          e.ID,
+		ClusterID:   e.ClusterID,
+		Status:      e.Status,
+		Trigger:     e.Trigger,
+		CreatedAt:   e.CreatedAt,
+		StartedAt:   e.StartedAt,
+		CompletedAt: e.CompletedAt,
+		ExitCode:    e.ExitCode,
+		Stderr:      e.Stderr,
+	}
+
+	if len(e.HostErrors) > 0 {
+		// host errors are informative only, an unreadable payload is ignored
+		_ = json.Unmarshal(e.HostErrors, &execution.HostErrors)
+	}
+
+	return execution
+}
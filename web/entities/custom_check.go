@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type CustomCheck struct {
+	ID             string `gorm:"primaryKey"`
+	Version        int
+	Name           string
+	Group          string
+	Description    string
+	Remediation    string
+	Labels         string
+	Implementation string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (c *CustomCheck) ToModel() *models.CustomCheck {
+	return &models.CustomCheck{
+		ID:             c.ID,
+		Version:        c.Version,
+		Name:           c.Name,
+		Group:          c.Group,
+		Description:    c.Description,
+		Remediation:    c.Remediation,
+		Labels:         c.Labels,
+		Implementation: c.Implementation,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+	}
+}
+
+// CustomCheckVersion keeps every revision of a custom check
+type CustomCheckVersion struct {
+	CheckID   string `gorm:"primaryKey"`
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Payload   datatypes.JSON
+	CreatedAt time.Time
+}
+
+func (v *CustomCheckVersion) ToModel() (*models.CustomCheck, error) {
+	var check models.CustomCheck
+	if err := json.Unmarshal(v.Payload, &check); err != nil {
+		return nil, err
+	}
+
+	return &check, nil
+}
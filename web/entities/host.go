@@ -3,7 +3,7 @@ package entities
 import (
 	"time"
 
-	"github.com/lib/pq"
+	"github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/datatypes"
 )
@@ -12,7 +12,7 @@ type Host struct {
 	AgentID            string `gorm:"primaryKey"`
 	SSHAddress         string
 	Name               string
-	IPAddresses        pq.StringArray `gorm:"type:text[]"`
+	IPAddresses        db.StringArray
 	CloudProvider      string
 	ClusterID          string
 	ClusterName        string
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+)
+
+// HostChecksResult holds the last check results evaluated by the agent of a host
+type HostChecksResult struct {
+	AgentID   string `gorm:"primaryKey"`
+	ClusterID string `gorm:"index"`
+	Payload   datatypes.JSON
+	UpdatedAt time.Time
+}
+
+func (h *HostChecksResult) ToModel() (map[string]*models.Check, error) {
+	var results map[string]*models.Check
+	err := json.Unmarshal(h.Payload, &results)
+
+	return results, err
+}
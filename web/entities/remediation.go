@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/web/models"
+)
+
+type Remediation struct {
+	ID          int64
+	ClusterID   string `gorm:"index"`
+	Checks      db.StringArray
+	Status      string `gorm:"index"`
+	RequestedBy string
+	ApprovedBy  string
+	RunnerID    string
+	PreviewDiff datatypes.JSON
+	AppliedDiff datatypes.JSON
+	ExitCode    *int
+	Stderr      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ApprovedAt  *time.Time
+	CompletedAt *time.Time
+}
+
+func (r *Remediation) ToModel() *models.Remediation {
+	remediation := &models.Remediation{
+		ID:          r.ID,
+		ClusterID:   r.ClusterID,
+		Checks:      append([]string{}, r.Checks...),
+		Status:      r.Status,
+		RequestedBy: r.RequestedBy,
+		ApprovedBy:  r.ApprovedBy,
+		ExitCode:    r.ExitCode,
+		Stderr:      r.Stderr,
+		CreatedAt:   r.CreatedAt,
+		ApprovedAt:  r.ApprovedAt,
+		CompletedAt: r.CompletedAt,
+	}
+
+	// the diffs are informative only, an unreadable payload is ignored
+	if len(r.PreviewDiff) > 0 {
+		_ = json.Unmarshal(r.PreviewDiff, &remediation.PreviewDiff)
+	}
+	if len(r.AppliedDiff) > 0 {
+		_ = json.Unmarshal(r.AppliedDiff, &remediation.AppliedDiff)
+	}
+
+	return remediation
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/web/models"
+)
+
+type Runner struct {
+	ID          string `gorm:"primaryKey"`
+	Tags        db.StringArray
+	ClusterIDs  db.StringArray
+	HeartbeatAt time.Time `gorm:"index"`
+	CreatedAt   time.Time
+}
+
+type RunnerLease struct {
+	ClusterID  string `gorm:"primaryKey"`
+	RunnerID   string `gorm:"index"`
+	AcquiredAt time.Time
+	ExpiresAt  time.Time `gorm:"index"`
+}
+
+func (r *Runner) ToModel() *models.Runner {
+	return &models.Runner{
+		ID:          r.ID,
+		Tags:        append([]string{}, r.Tags...),
+		ClusterIDs:  append([]string{}, r.ClusterIDs...),
+		HeartbeatAt: r.HeartbeatAt,
+	}
+}
+
+func (l *RunnerLease) ToModel() *models.RunnerLease {
+	return &models.RunnerLease{
+		ClusterID:  l.ClusterID,
+		RunnerID:   l.RunnerID,
+		AcquiredAt: l.AcquiredAt,
+		ExpiresAt:  l.ExpiresAt,
+	}
+}
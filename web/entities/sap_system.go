@@ -4,7 +4,7 @@ import (
 	"sort"
 	"time"
 
-	"github.com/lib/pq"
+	"github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/web/models"
 )
 
@@ -25,8 +25,8 @@ type SAPSystemInstance struct {
 	SystemReplicationStatus string
 	DBHost                  string
 	DBName                  string
-	Tenants                 pq.StringArray `gorm:"type:text[]"`
-	Host                    *Host          `gorm:"foreignKey:AgentID"`
+	Tenants                 db.StringArray
+	Host                    *Host `gorm:"foreignKey:AgentID"`
 	UpdatedAt               time.Time
 	Tags                    []*models.Tag `gorm:"foreignKey:ResourceID"`
 }
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type Waiver struct {
+	ID            int64
+	CheckID       string `gorm:"index"`
+	Scope         string
+	Target        string
+	Justification string
+	Owner         string
+	ExpiresAt     time.Time `gorm:"index"`
+	Expired       bool
+	CreatedAt     time.Time
+}
+
+func (w *Waiver) ToModel() *models.Waiver {
+	return &models.Waiver{
+		ID:            w.ID,
+		CheckID:       w.CheckID,
+		Scope:         w.Scope,
+		Target:        w.Target,
+		Justification: w.Justification,
+		Owner:         w.Owner,
+		ExpiresAt:     w.ExpiresAt,
+		Expired:       w.Expired,
+		CreatedAt:     w.CreatedAt,
+	}
+}
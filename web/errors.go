@@ -77,3 +77,19 @@ func InternalServerError(msg string) *HttpError {
 		"error.html.tmpl",
 	}
 }
+
+func ConflictError(msg string) *HttpError {
+	return &HttpError{
+		msg,
+		http.StatusConflict,
+		"error.html.tmpl",
+	}
+}
+
+func UnauthorizedError(msg string) *HttpError {
+	return &HttpError{
+		msg,
+		http.StatusUnauthorized,
+		"error.html.tmpl",
+	}
+}
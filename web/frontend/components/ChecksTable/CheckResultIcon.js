@@ -4,6 +4,8 @@ const PASSING = 'passing';
 const WARNING = 'warning';
 const CRITICAL = 'critical';
 const SKIPPED = 'skipped';
+const WAIVED = 'waived';
+const NOT_APPLICABLE = 'not_applicable';
 
 const CheckResultIcon = ({ result, tooltip = null }) => {
   const tooltipData = tooltip ? { datatoggle: 'tooltip', title: tooltip } : {};
@@ -27,6 +29,18 @@ const CheckResultIcon = ({ result, tooltip = null }) => {
           error
         </i>
       );
+    case WAIVED:
+      return (
+        <i className="eos-icons eos-18 text-info" {...tooltipData}>
+          verified_user
+        </i>
+      );
+    case NOT_APPLICABLE:
+      return (
+        <i className="eos-icons eos-18 text-muted" {...tooltipData}>
+          block
+        </i>
+      );
     case SKIPPED:
       return (
         <i className="eos-icons eos-18 text-muted" {...tooltipData}>
@@ -0,0 +1,81 @@
+import React from 'react';
+
+const width = 800;
+const height = 200;
+const padding = 30;
+
+const toPoints = (values, maxValue) =>
+  values
+    .map((value, index) => {
+      const x =
+        values.length > 1
+          ? padding + (index * (width - 2 * padding)) / (values.length - 1)
+          : width / 2;
+      const y =
+        height - padding - (value * (height - 2 * padding)) / maxValue;
+      return `${x},${y}`;
+    })
+    .join(' ');
+
+// Draws a line per series, with the points evenly spaced in the given order
+const TrendChart = ({ labels, series }) => {
+  const maxValue = Math.max(1, ...series.flatMap(({ values }) => values));
+
+  return (
+    <svg
+      className="trend-chart"
+      viewBox={`0 0 ${width} ${height}`}
+      width="100%"
+      role="img"
+    >
+      <line
+        x1={padding}
+        y1={height - padding}
+        x2={width - padding}
+        y2={height - padding}
+        stroke="#ccc"
+      />
+      <line
+        x1={padding}
+        y1={padding}
+        x2={padding}
+        y2={height - padding}
+        stroke="#ccc"
+      />
+      <text x={padding - 5} y={padding} textAnchor="end" fontSize="10">
+        {maxValue}
+      </text>
+      <text x={padding - 5} y={height - padding} textAnchor="end" fontSize="10">
+        0
+      </text>
+      {labels.length > 0 && (
+        <>
+          <text x={padding} y={height - 10} fontSize="10">
+            {labels[0]}
+          </text>
+          <text
+            x={width - padding}
+            y={height - 10}
+            textAnchor="end"
+            fontSize="10"
+          >
+            {labels[labels.length - 1]}
+          </text>
+        </>
+      )}
+      {series.map(({ name, color, values }) => (
+        <polyline
+          key={name}
+          fill="none"
+          stroke={color}
+          strokeWidth="2"
+          points={toPoints(values, maxValue)}
+        >
+          <title>{name}</title>
+        </polyline>
+      ))}
+    </svg>
+  );
+};
+
+export default TrendChart;
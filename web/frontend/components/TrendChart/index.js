@@ -0,0 +1,3 @@
+import TrendChart from './TrendChart';
+
+export default TrendChart;
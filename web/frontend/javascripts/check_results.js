@@ -5,8 +5,9 @@ import classNames from 'classnames';
 
 import ChecksTable, { CheckResultIcon } from '@components/ChecksTable';
 import Dropdown, { DropdownItem } from '@components/Dropdown';
+import { getCheckTarget } from '@lib/targets';
 
-const clusterId = window.location.pathname.split('/').pop();
+const { resource, id: clusterId } = getCheckTarget();
 
 const toggleFilter = (filter, selectedFilters) =>
   selectedFilters.includes(filter)
@@ -28,10 +29,18 @@ const ClustersChecks = ({ clusterId }) => {
   });
 
   useEffect(() => {
-    get(`/api/clusters/${clusterId}/results`).then(({ data }) => {
-      setResults(data.checks);
-      setHosts(data.hosts);
-    });
+    const fetchResults = () =>
+      get(`/api/${resource}/${clusterId}/results`).then(({ data }) => {
+        setResults(data.checks);
+        setHosts(data.hosts);
+      });
+
+    fetchResults();
+    // refresh the results when an on-demand execution completes
+    window.addEventListener('checks-execution-completed', fetchResults);
+
+    return () =>
+      window.removeEventListener('checks-execution-completed', fetchResults);
   }, []);
 
   return (
@@ -52,6 +61,15 @@ const ClustersChecks = ({ clusterId }) => {
             <CheckResultIcon result="critical" />
             Critical
           </DropdownItem>
+          <DropdownItem
+            className={classNames({
+              selected: filters.includes('not_applicable'),
+            })}
+            onClick={() => setFilters(toggleFilter('not_applicable', filters))}
+          >
+            <CheckResultIcon result="not_applicable" />
+            Not applicable
+          </DropdownItem>
           <DropdownItem onClick={() => setFilters([])}>See all</DropdownItem>
         </Dropdown>
       </div>
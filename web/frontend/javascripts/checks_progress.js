@@ -0,0 +1,101 @@
+import React, { useEffect, useState } from 'react';
+import ReactDOM from 'react-dom';
+import Card from 'react-bootstrap/Card';
+import Table from 'react-bootstrap/Table';
+import Spinner from 'react-bootstrap/Spinner';
+
+import { CheckResultIcon } from '@components/ChecksTable';
+import { getCheckTarget } from '@lib/targets';
+
+const { id: targetId } = getCheckTarget();
+
+const isRunning = (progress) => progress && progress.status === 'running';
+
+const HostProgress = ({ name, host }) => {
+  const results = Object.entries(host.results || {});
+
+  return (
+    <tr>
+      <td>{name}</td>
+      <td>
+        {!host.reachable && host.msg ? (
+          <span className="text-danger">{host.msg}</span>
+        ) : host.check_id ? (
+          <span>
+            <Spinner animation="border" size="sm" as="span" /> {host.check_id}
+            {host.task && <span className="text-muted"> - {host.task}</span>}
+          </span>
+        ) : (
+          <span className="text-muted">-</span>
+        )}
+      </td>
+      <td>
+        {results.map(([checkId, result]) => (
+          <CheckResultIcon key={checkId} result={result} tooltip={checkId} />
+        ))}
+      </td>
+    </tr>
+  );
+};
+
+// ChecksProgress shows the checks running on every host while the runner executes them, as streamed by the server
+const ChecksProgress = ({ targetId }) => {
+  const [progress, setProgress] = useState(null);
+
+  useEffect(() => {
+    // the browser reconnects when the server ends the stream, and the current progress is sent first
+    const source = new EventSource(`/api/checks/${targetId}/progress/stream`);
+
+    source.addEventListener('progress', ({ data }) => {
+      const update = JSON.parse(data);
+      setProgress((previous) => {
+        if (isRunning(previous) && update.status === 'completed') {
+          window.dispatchEvent(new Event('checks-execution-completed'));
+        }
+        return update;
+      });
+    });
+
+    return () => source.close();
+  }, [targetId]);
+
+  if (!isRunning(progress)) {
+    return null;
+  }
+
+  return (
+    <Card className="mb-4">
+      <Card.Header>
+        <Spinner animation="grow" size="sm" as="span" /> Checks execution in
+        progress
+      </Card.Header>
+      <Card.Body>
+        <Table size="sm">
+          <thead>
+            <tr>
+              <th>Host</th>
+              <th>Running check</th>
+              <th>Results</th>
+            </tr>
+          </thead>
+          <tbody>
+            {Object.keys(progress.hosts)
+              .sort()
+              .map((name) => (
+                <HostProgress
+                  key={name}
+                  name={name}
+                  host={progress.hosts[name]}
+                />
+              ))}
+          </tbody>
+        </Table>
+      </Card.Body>
+    </Card>
+  );
+};
+
+ReactDOM.render(
+  <ChecksProgress targetId={targetId} />,
+  document.getElementById('cluster-checks-progress')
+);
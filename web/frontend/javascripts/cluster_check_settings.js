@@ -11,28 +11,93 @@ import Spinner from 'react-bootstrap/Spinner';
 
 import { logError } from '@lib/log';
 import { toggle, hasOne, remove } from '@lib/lists';
+import { getCheckTarget, appliesTo } from '@lib/targets';
 import Checkbox from '@components/Checkbox';
 import { AccordionToggle } from '@components/Accordion';
 import { showSuccessToast, showErrorToast } from '@components/Toast';
 
-const clusterId = window.location.pathname.split('/').pop();
+const { id: clusterId } = getCheckTarget();
 
 const getChecksIds = (checks) => checks.map(({ id }) => id);
 
-const mergeConnectionSettings = (hostnames, connectionSettings) =>
+const profileFields = [
+  { field: 'user', label: 'User' },
+  { field: 'port', label: 'Port' },
+  { field: 'private_key', label: 'Private key' },
+  { field: 'proxy_jump', label: 'Jump host' },
+  { field: 'become_method', label: 'Become method' },
+  { field: 'become_user', label: 'Become user' },
+];
+
+const mergeConnectionProfiles = (hostnames, connectionProfiles = {}) =>
   hostnames.reduce(
-    (accumulator, current) =>
-      connectionSettings[current]
-        ? { ...accumulator, [current]: connectionSettings[current] }
-        : { ...accumulator, [current]: '' },
+    (accumulator, current) => ({
+      ...accumulator,
+      [current]: connectionProfiles[current] || {},
+    }),
     {}
   );
 
+const toProfilePayload = (profile) =>
+  profileFields.reduce((accumulator, { field }) => {
+    const value = `${profile[field] || ''}`.trim();
+    if (value === '') {
+      return accumulator;
+    }
+    return {
+      ...accumulator,
+      [field]: field === 'port' ? parseInt(value, 10) || 0 : value,
+    };
+  }, {});
+
+const toParametersList = (parameters = {}) =>
+  Object.keys(parameters)
+    .sort()
+    .map((name) => ({ name, value: parameters[name] }));
+
+const fromParametersList = (parametersList) =>
+  parametersList
+    .filter(({ name }) => name.trim() !== '')
+    .reduce(
+      (accumulator, { name, value }) => ({
+        ...accumulator,
+        [name.trim()]: value,
+      }),
+      {}
+    );
+
+const targetLabels = {
+  cluster: 'cluster',
+  host: 'host',
+  sapsystem: 'SAP system',
+};
+
+// only the checks applying to the target type and to its environment can be selected
+const filterCatalog = (catalog, targetType, notApplicableChecks) =>
+  catalog
+    .map(({ group, checks }) => ({
+      group,
+      checks: checks.filter(
+        (check) =>
+          appliesTo(check, targetType) &&
+          !notApplicableChecks.includes(check.id)
+      ),
+    }))
+    .filter(({ checks }) => checks.length > 0);
+
 const SettingsButton = () => {
   const [modalOpen, setModalOpen] = useState(false);
   const [checksCatalog, setChecksCatalog] = useState([]);
   const [selectedChecks, setSelectedChecks] = useState([]);
-  const [settings, setSettings] = useState({});
+  const [targetType, setTargetType] = useState('cluster');
+  const [notApplicableChecks, setNotApplicableChecks] = useState([]);
+  const [defaultUsers, setDefaultUsers] = useState({});
+  const [clusterProfile, setClusterProfile] = useState({});
+  const [hostProfiles, setHostProfiles] = useState({});
+  const [checkParameters, setCheckParameters] = useState([]);
+  const [effectiveCheckParameters, setEffectiveCheckParameters] = useState(
+    {}
+  );
   const [loading, setLoading] = useState(false);
 
   useEffect(() => {
@@ -43,22 +108,33 @@ const SettingsButton = () => {
     get(`/api/checks/${clusterId}/settings`)
       .then(({ data }) => {
         const {
+          target_type: settingsTargetType,
           hostnames,
           connection_settings: connectionSettings,
+          cluster_connection_profile: clusterConnectionProfile,
+          connection_profiles: connectionProfiles,
           selected_checks: selectedChecks,
+          not_applicable_checks: notApplicable,
+          check_parameters: clusterCheckParameters,
+          effective_check_parameters: effectiveParameters,
         } = data;
-        const newSettings = mergeConnectionSettings(
-          hostnames,
-          connectionSettings
+        setTargetType(settingsTargetType || 'cluster');
+        setNotApplicableChecks(notApplicable || []);
+        setDefaultUsers(connectionSettings || {});
+        setClusterProfile(clusterConnectionProfile || {});
+        setHostProfiles(
+          mergeConnectionProfiles(hostnames || [], connectionProfiles)
         );
-        setSettings(newSettings);
         setSelectedChecks(selectedChecks);
+        setCheckParameters(toParametersList(clusterCheckParameters));
+        setEffectiveCheckParameters(effectiveParameters || {});
         setLoading(false);
       })
       .catch((error) => {
         logError(error);
         setSelectedChecks([]);
-        setSettings({});
+        setClusterProfile({});
+        setHostProfiles({});
         setLoading(false);
         showErrorToast({
           content: 'Error fetching the checks data, please refresh.',
@@ -69,7 +145,16 @@ const SettingsButton = () => {
   const submit = useCallback(() => {
     const payload = {
       selected_checks: selectedChecks,
-      connection_settings: settings,
+      connection_settings: {},
+      cluster_connection_profile: toProfilePayload(clusterProfile),
+      connection_profiles: Object.keys(hostProfiles).reduce(
+        (accumulator, host) => ({
+          ...accumulator,
+          [host]: toProfilePayload(hostProfiles[host]),
+        }),
+        {}
+      ),
+      check_parameters: fromParametersList(checkParameters),
     };
     setLoading(true);
     post(`/api/checks/${clusterId}/settings`, payload)
@@ -77,7 +162,7 @@ const SettingsButton = () => {
         setLoading(false);
         setModalOpen(false);
         showSuccessToast({
-          content: 'Cluster settings successfully saved.',
+          content: 'Checks settings successfully saved.',
         });
       })
       .catch((err) => {
@@ -87,16 +172,40 @@ const SettingsButton = () => {
           content: 'Error saving the checks settings, please retry',
         });
       });
-  }, [selectedChecks, settings]);
+  }, [selectedChecks, clusterProfile, hostProfiles, checkParameters]);
+
+  const updateHostProfile = (host, field, value) =>
+    setHostProfiles({
+      ...hostProfiles,
+      [host]: { ...hostProfiles[host], [field]: value },
+    });
+
+  const displayedCatalog = filterCatalog(
+    checksCatalog,
+    targetType,
+    notApplicableChecks
+  );
+
+  const hostPlaceholder = (host, field) =>
+    field === 'user'
+      ? clusterProfile.user || defaultUsers[host] || 'root'
+      : clusterProfile[field] || '';
+
+  const updateCheckParameter = (index, field, value) =>
+    setCheckParameters(
+      checkParameters.map((parameter, current) =>
+        current === index ? { ...parameter, [field]: value } : parameter
+      )
+    );
 
   return (
     <Fragment>
       <Button variant="secondary" size="sm" onClick={() => setModalOpen(true)}>
         <i className="eos-icons eos-18">settings</i>Settings
       </Button>
-      <Modal show={modalOpen} onHide={() => setModalOpen(false)}>
+      <Modal size="lg" show={modalOpen} onHide={() => setModalOpen(false)}>
         <Modal.Header closeButton>
-          <Modal.Title>Cluster settings</Modal.Title>
+          <Modal.Title>Checks settings</Modal.Title>
         </Modal.Header>
         <Modal.Body>
           <h6>Connection settings</h6>
@@ -111,39 +220,165 @@ const SettingsButton = () => {
               </Card.Header>
               <Accordion.Collapse eventKey="connection-settings">
                 <Card.Body className="card-check-selection">
-                  <Table>
+                  <Table size="sm">
                     <thead>
                       <tr>
                         <th>Host</th>
-                        <th>Connection user</th>
-                        <th>Default user</th>
+                        {profileFields.map(({ field, label }) => (
+                          <th key={field}>{label}</th>
+                        ))}
                       </tr>
                     </thead>
                     <tbody>
-                      {Object.keys(settings).map((host) => (
+                      <tr>
+                        <td>All hosts</td>
+                        {profileFields.map(({ field }) => (
+                          <td key={field}>
+                            <Form.Control
+                              size="sm"
+                              value={clusterProfile[field] || ''}
+                              placeholder={field === 'user' ? 'root' : ''}
+                              onChange={({ target: { value } }) =>
+                                setClusterProfile({
+                                  ...clusterProfile,
+                                  [field]: value,
+                                })
+                              }
+                            />
+                          </td>
+                        ))}
+                      </tr>
+                      {Object.keys(hostProfiles).map((host) => (
                         <tr key={host}>
                           <td>{host}</td>
+                          {profileFields.map(({ field }) => (
+                            <td key={field}>
+                              <Form.Control
+                                size="sm"
+                                value={hostProfiles[host][field] || ''}
+                                placeholder={hostPlaceholder(host, field)}
+                                onChange={({ target: { value } }) =>
+                                  updateHostProfile(host, field, value)
+                                }
+                              />
+                            </td>
+                          ))}
+                        </tr>
+                      ))}
+                    </tbody>
+                  </Table>
+                </Card.Body>
+              </Accordion.Collapse>
+            </Card>
+          </Accordion>
+          <h6>Checks parameters</h6>
+          <Accordion>
+            <Card>
+              <Card.Header>
+                Expected values overridden for this {targetLabels[targetType]}
+                <AccordionToggle
+                  className="float-right"
+                  eventKey="check-parameters"
+                />
+              </Card.Header>
+              <Accordion.Collapse eventKey="check-parameters">
+                <Card.Body className="card-check-selection">
+                  <Table>
+                    <thead>
+                      <tr>
+                        <th>Parameter</th>
+                        <th>Value</th>
+                        <th>Applied value</th>
+                        <th></th>
+                      </tr>
+                    </thead>
+                    <tbody>
+                      {checkParameters.map(({ name, value }, index) => (
+                        <tr key={index}>
                           <td>
                             <Form.Control
                               size="sm"
-                              value={settings[host]}
-                              onChange={({ target: { value } }) =>
-                                setSettings({ ...settings, [host]: value })
+                              placeholder="1.1.1"
+                              value={name}
+                              onChange={({ target }) =>
+                                updateCheckParameter(
+                                  index,
+                                  'name',
+                                  target.value
+                                )
+                              }
+                            />
+                          </td>
+                          <td>
+                            <Form.Control
+                              size="sm"
+                              value={value}
+                              onChange={({ target }) =>
+                                updateCheckParameter(
+                                  index,
+                                  'value',
+                                  target.value
+                                )
                               }
                             />
                           </td>
-                          <td>root</td>
+                          <td>{effectiveCheckParameters[name]}</td>
+                          <td>
+                            <Button
+                              variant="link"
+                              size="sm"
+                              onClick={() =>
+                                setCheckParameters(
+                                  checkParameters.filter(
+                                    (_, current) => current !== index
+                                  )
+                                )
+                              }
+                            >
+                              <i className="eos-icons eos-18">delete</i>
+                            </Button>
+                          </td>
                         </tr>
                       ))}
+                      {Object.keys(effectiveCheckParameters)
+                        .filter(
+                          (name) =>
+                            !checkParameters.some(
+                              (parameter) => parameter.name === name
+                            )
+                        )
+                        .sort()
+                        .map((name) => (
+                          <tr key={name} className="text-muted">
+                            <td>{name}</td>
+                            <td>
+                              Inherited from the {targetLabels[targetType]} tags
+                            </td>
+                            <td>{effectiveCheckParameters[name]}</td>
+                            <td></td>
+                          </tr>
+                        ))}
                     </tbody>
                   </Table>
+                  <Button
+                    variant="secondary"
+                    size="sm"
+                    onClick={() =>
+                      setCheckParameters([
+                        ...checkParameters,
+                        { name: '', value: '' },
+                      ])
+                    }
+                  >
+                    Add parameter
+                  </Button>
                 </Card.Body>
               </Accordion.Collapse>
             </Card>
           </Accordion>
           <h6>Checks selection</h6>
           <Accordion>
-            {checksCatalog.map(({ group, checks }) => (
+            {displayedCatalog.map(({ group, checks }) => (
               <Card key={group}>
                 <Card.Header>
                   <Checkbox
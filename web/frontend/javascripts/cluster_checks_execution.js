@@ -0,0 +1,131 @@
+import React, { Fragment, useState, useEffect, useCallback } from 'react';
+import ReactDOM from 'react-dom';
+import { get, post } from 'axios';
+import Button from 'react-bootstrap/Button';
+import Badge from 'react-bootstrap/Badge';
+import Spinner from 'react-bootstrap/Spinner';
+
+import { logError } from '@lib/log';
+import { showSuccessToast, showErrorToast } from '@components/Toast';
+import { getCheckTarget } from '@lib/targets';
+
+const { resource, id: clusterId } = getCheckTarget();
+
+const pollInterval = 3000;
+
+const statusVariants = {
+  queued: 'secondary',
+  running: 'info',
+  completed: 'success',
+  failed: 'danger',
+};
+
+const isPending = (execution) =>
+  execution && ['queued', 'running'].includes(execution.status);
+
+const failureDetails = ({ exit_code, stderr, host_errors }) => {
+  const details = Object.entries(host_errors || {}).map(
+    ([host, error]) => `${host}: ${error}`
+  );
+  if (exit_code !== undefined) {
+    details.unshift(`Exit code: ${exit_code}`);
+  }
+  if (stderr) {
+    details.push(stderr);
+  }
+  return details.join('\n');
+};
+
+const ChecksExecutionButton = ({ clusterId }) => {
+  const [execution, setExecution] = useState(null);
+  const [loading, setLoading] = useState(false);
+
+  const fetchLastExecution = useCallback(
+    () =>
+      get(`/api/${resource}/${clusterId}/checks/executions/last`)
+        .then(({ data }) => data)
+        .catch((error) => {
+          if (error.response && error.response.status === 404) {
+            return null;
+          }
+          throw error;
+        }),
+    [clusterId]
+  );
+
+  useEffect(() => {
+    fetchLastExecution().then(setExecution).catch(logError);
+  }, []);
+
+  useEffect(() => {
+    if (!isPending(execution)) {
+      return;
+    }
+
+    const timer = setTimeout(() => {
+      fetchLastExecution()
+        .then((last) => {
+          setExecution(last);
+          if (last && last.status === 'completed') {
+            showSuccessToast({ content: 'Checks execution completed.' });
+            window.dispatchEvent(new Event('checks-execution-completed'));
+          }
+          if (last && last.status === 'failed') {
+            showErrorToast({ content: 'Checks execution failed.' });
+          }
+        })
+        .catch(logError);
+    }, pollInterval);
+
+    return () => clearTimeout(timer);
+  }, [execution]);
+
+  const execute = useCallback(() => {
+    setLoading(true);
+    post(`/api/${resource}/${clusterId}/checks/execute`)
+      .then(({ data }) => {
+        setLoading(false);
+        setExecution(data);
+        showSuccessToast({ content: 'Checks execution requested.' });
+      })
+      .catch((error) => {
+        logError(error);
+        setLoading(false);
+        showErrorToast({
+          content: 'Error requesting the checks execution, please retry',
+        });
+      });
+  }, [clusterId]);
+
+  return (
+    <Fragment>
+      <Button
+        variant="primary"
+        size="sm"
+        disabled={loading || isPending(execution)}
+        onClick={execute}
+      >
+        {isPending(execution) ? (
+          <Spinner animation="border" role="status" as="span" size="sm" />
+        ) : (
+          <i className="eos-icons eos-18">play_arrow</i>
+        )}
+        Run checks
+      </Button>
+      {execution && (
+        <Badge
+          variant={statusVariants[execution.status]}
+          className="ml-2"
+          title={execution.status === 'failed' ? failureDetails(execution) : ''}
+        >
+          {execution.status}
+        </Badge>
+      )}
+    </Fragment>
+  );
+};
+
+ReactDOM.render(
+  <ChecksExecutionButton clusterId={clusterId} />,
+  document.getElementById('cluster-checks-execution')
+);
@@ -0,0 +1,287 @@
+import React, { useEffect, useState } from 'react';
+import ReactDOM from 'react-dom';
+import { get } from 'axios';
+import Table from 'react-bootstrap/Table';
+import Badge from 'react-bootstrap/Badge';
+
+import { logError } from '@lib/log';
+import { CheckResultIcon } from '@components/ChecksTable';
+import TrendChart from '@components/TrendChart';
+import { showErrorToast } from '@components/Toast';
+
+const container = document.getElementById('cluster-checks-history');
+const clusterId = container.dataset.clusterId;
+
+const formatDate = (date) => new Date(date).toLocaleString();
+
+const statusVariants = {
+  queued: 'secondary',
+  running: 'info',
+  completed: 'success',
+  failed: 'danger',
+};
+
+const formatDuration = ({ started_at, completed_at }) => {
+  if (!started_at || !completed_at) {
+    return '-';
+  }
+  const seconds = Math.round(
+    (new Date(completed_at) - new Date(started_at)) / 1000
+  );
+  return `${seconds}s`;
+};
+
+const RunnerExecutions = ({ clusterId }) => {
+  const [executions, setExecutions] = useState([]);
+  const [onlyFailed, setOnlyFailed] = useState(false);
+
+  useEffect(() => {
+    const status = onlyFailed ? '?status=failed' : '';
+    get(`/api/clusters/${clusterId}/checks/executions${status}`)
+      .then(({ data }) => setExecutions(data))
+      .catch((error) => {
+        logError(error);
+        showErrorToast({ content: 'Error fetching the runner executions.' });
+      });
+  }, [onlyFailed]);
+
+  return (
+    <div>
+      <h4 className="mt-4">Runner executions</h4>
+      <label>
+        <input
+          type="checkbox"
+          className="mr-2"
+          checked={onlyFailed}
+          onChange={() => setOnlyFailed(!onlyFailed)}
+        />
+        Show only failed executions
+      </label>
+      {executions.length === 0 ? (
+        <p className="text-muted">No runner execution found.</p>
+      ) : (
+        <Table className="eos-table">
+          <thead>
+            <tr>
+              <th>Status</th>
+              <th>Trigger</th>
+              <th>Started at</th>
+              <th>Duration</th>
+              <th>Exit code</th>
+              <th>Errors</th>
+            </tr>
+          </thead>
+          <tbody>
+            {executions.map((execution) => (
+              <tr
+                key={execution.id}
+                className={
+                  execution.status === 'failed' ? 'table-danger' : undefined
+                }
+              >
+                <td>
+                  <Badge variant={statusVariants[execution.status]}>
+                    {execution.status}
+                  </Badge>
+                </td>
+                <td>{execution.trigger}</td>
+                <td>
+                  {execution.started_at
+                    ? formatDate(execution.started_at)
+                    : '-'}
+                </td>
+                <td>{formatDuration(execution)}</td>
+                <td>
+                  {execution.exit_code === undefined
+                    ? '-'
+                    : execution.exit_code}
+                </td>
+                <td>
+                  {Object.entries(execution.host_errors || {}).map(
+                    ([host, error]) => (
+                      <div key={host}>
+                        <strong>{host}</strong>: {error}
+                      </div>
+                    )
+                  )}
+                  {execution.stderr && (
+                    <pre className="mb-0">{execution.stderr}</pre>
+                  )}
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </Table>
+      )}
+    </div>
+  );
+};
+
+const DiffTable = ({ diff }) => {
+  if (diff.changes.length === 0) {
+    return (
+      <p className="text-muted">
+        No check result changed between the selected executions.
+      </p>
+    );
+  }
+
+  return (
+    <Table className="eos-table">
+      <thead>
+        <tr>
+          <th>Check</th>
+          <th>Description</th>
+          <th>Host</th>
+          <th>{formatDate(diff.from.created_at)}</th>
+          <th>{formatDate(diff.to.created_at)}</th>
+        </tr>
+      </thead>
+      <tbody>
+        {diff.changes.map(({ check_id, description, host, from, to }) => (
+          <tr key={`${check_id}-${host}`}>
+            <td>{check_id}</td>
+            <td>{description}</td>
+            <td>{host}</td>
+            <td>
+              <CheckResultIcon result={from} /> {from || 'not executed'}
+            </td>
+            <td>
+              <CheckResultIcon result={to} /> {to || 'not executed'}
+            </td>
+          </tr>
+        ))}
+      </tbody>
+    </Table>
+  );
+};
+
+const ChecksHistory = ({ clusterId }) => {
+  const [history, setHistory] = useState([]);
+  const [from, setFrom] = useState(null);
+  const [to, setTo] = useState(null);
+  const [diff, setDiff] = useState(null);
+
+  useEffect(() => {
+    get(`/api/clusters/${clusterId}/results/history`)
+      .then(({ data }) => {
+        setHistory(data);
+        if (data.length > 1) {
+          setTo(data[0].id);
+          setFrom(data[1].id);
+        }
+      })
+      .catch((error) => {
+        logError(error);
+        showErrorToast({ content: 'Error fetching the checks history.' });
+      });
+  }, []);
+
+  useEffect(() => {
+    if (from === null || to === null) {
+      return;
+    }
+    get(`/api/clusters/${clusterId}/results/diff?from=${from}&to=${to}`)
+      .then(({ data }) => setDiff(data))
+      .catch((error) => {
+        logError(error);
+        showErrorToast({ content: 'Error comparing the checks executions.' });
+      });
+  }, [from, to]);
+
+  if (history.length === 0) {
+    return (
+      <div>
+        <p className="text-muted">No checks execution found.</p>
+        <RunnerExecutions clusterId={clusterId} />
+      </div>
+    );
+  }
+
+  // the chart goes from the oldest to the most recent execution
+  const chronological = [...history].reverse();
+
+  return (
+    <div>
+      <h4>Trend</h4>
+      <TrendChart
+        labels={chronological.map(({ created_at }) => formatDate(created_at))}
+        series={[
+          {
+            name: 'Passing',
+            color: '#28a745',
+            values: chronological.map(
+              ({ aggregated }) => aggregated.passing_count
+            ),
+          },
+          {
+            name: 'Warning',
+            color: '#ffc107',
+            values: chronological.map(
+              ({ aggregated }) => aggregated.warning_count
+            ),
+          },
+          {
+            name: 'Critical',
+            color: '#dc3545',
+            values: chronological.map(
+              ({ aggregated }) => aggregated.critical_count
+            ),
+          },
+        ]}
+      />
+      <h4 className="mt-4">Executions</h4>
+      <Table className="eos-table">
+        <thead>
+          <tr>
+            <th>From</th>
+            <th>To</th>
+            <th>Health</th>
+            <th>Executed at</th>
+            <th>Passing</th>
+            <th>Warning</th>
+            <th>Critical</th>
+          </tr>
+        </thead>
+        <tbody>
+          {history.map(({ id, created_at, health, aggregated }) => (
+            <tr key={id}>
+              <td>
+                <input
+                  type="radio"
+                  name="diff-from"
+                  checked={from === id}
+                  onChange={() => setFrom(id)}
+                />
+              </td>
+              <td>
+                <input
+                  type="radio"
+                  name="diff-to"
+                  checked={to === id}
+                  onChange={() => setTo(id)}
+                />
+              </td>
+              <td>
+                <CheckResultIcon result={health} />
+              </td>
+              <td>{formatDate(created_at)}</td>
+              <td>{aggregated.passing_count}</td>
+              <td>{aggregated.warning_count}</td>
+              <td>{aggregated.critical_count}</td>
+            </tr>
+          ))}
+        </tbody>
+      </Table>
+      {diff && (
+        <div>
+          <h4 className="mt-4">Changes</h4>
+          <DiffTable diff={diff} />
+        </div>
+      )}
+      <RunnerExecutions clusterId={clusterId} />
+    </div>
+  );
+};
+
+ReactDOM.render(<ChecksHistory clusterId={clusterId} />, container);
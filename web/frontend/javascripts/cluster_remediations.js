@@ -0,0 +1,345 @@
+import React, { Fragment, useState, useEffect, useCallback } from 'react';
+import ReactDOM from 'react-dom';
+import { get, post } from 'axios';
+import Button from 'react-bootstrap/Button';
+import Badge from 'react-bootstrap/Badge';
+import Modal from 'react-bootstrap/Modal';
+import Table from 'react-bootstrap/Table';
+import Form from 'react-bootstrap/Form';
+import Spinner from 'react-bootstrap/Spinner';
+
+import { logError } from '@lib/log';
+import { toggle } from '@lib/lists';
+import { getCheckTarget } from '@lib/targets';
+import Checkbox from '@components/Checkbox';
+import { CheckResultIcon } from '@components/ChecksTable';
+import { showSuccessToast, showErrorToast } from '@components/Toast';
+
+const { id: clusterId } = getCheckTarget();
+
+const pollInterval = 3000;
+
+const statusVariants = {
+  preview_queued: 'secondary',
+  previewing: 'info',
+  pending_approval: 'warning',
+  approved: 'secondary',
+  applying: 'info',
+  applied: 'success',
+  rejected: 'secondary',
+  failed: 'danger',
+};
+
+const isRunning = (remediation) =>
+  remediation &&
+  ['preview_queued', 'previewing', 'approved', 'applying'].includes(
+    remediation.status
+  );
+
+const isActive = (remediation) =>
+  isRunning(remediation) ||
+  (remediation && remediation.status === 'pending_approval');
+
+const failingResults = ['warning', 'critical'];
+
+// only the remediable checks failing on some host can be remediated
+const getRemediableChecks = (catalog, results) => {
+  const remediable = catalog
+    .flatMap(({ checks }) => checks)
+    .filter(({ remediable }) => remediable)
+    .map(({ id }) => id);
+
+  return results.filter(
+    ({ id, hosts }) =>
+      remediable.includes(id) &&
+      Object.values(hosts).some(({ result }) =>
+        failingResults.includes(result)
+      )
+  );
+};
+
+const Diff = ({ diff }) => {
+  const hosts = Object.keys(diff || {}).sort();
+
+  if (hosts.length === 0) {
+    return <p className="text-muted">No changes.</p>;
+  }
+
+  return hosts.map((host) =>
+    Object.keys(diff[host])
+      .sort()
+      .map((checkId) => (
+        <div key={`${host}-${checkId}`}>
+          <h6>
+            {host} - {checkId}
+          </h6>
+          <pre className="bg-light p-2">{diff[host][checkId]}</pre>
+        </div>
+      ))
+  );
+};
+
+const RemediationDetails = ({ remediation }) => (
+  <Fragment>
+    <p>
+      Checks: <strong>{remediation.checks.join(', ')}</strong>{' '}
+      <Badge variant={statusVariants[remediation.status]}>
+        {remediation.status}
+      </Badge>
+    </p>
+    {remediation.requested_by && (
+      <p className="text-muted">Requested by {remediation.requested_by}</p>
+    )}
+    {remediation.approved_by && (
+      <p className="text-muted">Approved by {remediation.approved_by}</p>
+    )}
+    {isRunning(remediation) && (
+      <p>
+        <Spinner animation="border" size="sm" as="span" /> Waiting for the
+        runner...
+      </p>
+    )}
+    {remediation.status === 'failed' && remediation.stderr && (
+      <pre className="text-danger">{remediation.stderr}</pre>
+    )}
+    {remediation.applied_diff ? (
+      <Fragment>
+        <h6>Applied changes</h6>
+        <Diff diff={remediation.applied_diff} />
+      </Fragment>
+    ) : (
+      remediation.preview_diff && (
+        <Fragment>
+          <h6>Changes to apply</h6>
+          <Diff diff={remediation.preview_diff} />
+        </Fragment>
+      )
+    )}
+  </Fragment>
+);
+
+const RemediationsButton = ({ clusterId }) => {
+  const [modalOpen, setModalOpen] = useState(false);
+  const [remediableChecks, setRemediableChecks] = useState([]);
+  const [selectedChecks, setSelectedChecks] = useState([]);
+  const [remediation, setRemediation] = useState(null);
+  const [actor, setActor] = useState('');
+  const [adminToken, setAdminToken] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  const fetchLastRemediation = useCallback(
+    () =>
+      get(`/api/clusters/${clusterId}/remediations?per_page=1`).then(
+        ({ data }) => (data.length > 0 ? data[0] : null)
+      ),
+    [clusterId]
+  );
+
+  useEffect(() => {
+    if (!modalOpen) {
+      return;
+    }
+
+    Promise.all([
+      get('/api/checks/catalog'),
+      get(`/api/clusters/${clusterId}/results`),
+    ])
+      .then(([{ data: catalog }, { data: results }]) =>
+        setRemediableChecks(getRemediableChecks(catalog, results.checks || []))
+      )
+      .catch(logError);
+    fetchLastRemediation().then(setRemediation).catch(logError);
+  }, [modalOpen]);
+
+  useEffect(() => {
+    if (!modalOpen || !isRunning(remediation)) {
+      return;
+    }
+
+    const timer = setTimeout(() => {
+      fetchLastRemediation()
+        .then((last) => {
+          setRemediation(last);
+          if (last && last.status === 'applied') {
+            showSuccessToast({
+              content: 'Remediation applied, the checks are executed again.',
+            });
+          }
+          if (last && last.status === 'failed') {
+            showErrorToast({ content: 'Remediation failed.' });
+          }
+        })
+        .catch(logError);
+    }, pollInterval);
+
+    return () => clearTimeout(timer);
+  }, [modalOpen, remediation]);
+
+  const request = (url, payload, message) => {
+    setLoading(true);
+    post(url, payload, {
+      headers: { Authorization: `Bearer ${adminToken}` },
+    })
+      .then(({ data }) => {
+        setLoading(false);
+        setRemediation(data);
+        setSelectedChecks([]);
+        showSuccessToast({ content: message });
+      })
+      .catch((error) => {
+        logError(error);
+        setLoading(false);
+        const reason =
+          error.response && error.response.data && error.response.data.error;
+        showErrorToast({
+          content: reason || 'Error updating the remediation, please retry',
+        });
+      });
+  };
+
+  const preview = () =>
+    request(
+      `/api/clusters/${clusterId}/remediations`,
+      { checks: selectedChecks, requested_by: actor },
+      'Remediation requested, its changes are previewed first.'
+    );
+
+  const approve = () =>
+    request(
+      `/api/remediations/${remediation.id}/approve`,
+      { actor },
+      'Remediation approved, its changes are applied next.'
+    );
+
+  const reject = () =>
+    request(
+      `/api/remediations/${remediation.id}/reject`,
+      { actor },
+      'Remediation rejected.'
+    );
+
+  const pendingApproval =
+    remediation && remediation.status === 'pending_approval';
+
+  return (
+    <Fragment>
+      <Button variant="secondary" size="sm" onClick={() => setModalOpen(true)}>
+        <i className="eos-icons eos-18">build</i>Remediate
+      </Button>
+      <Modal size="lg" show={modalOpen} onHide={() => setModalOpen(false)}>
+        <Modal.Header closeButton>
+          <Modal.Title>Remediation of the failing checks</Modal.Title>
+        </Modal.Header>
+        <Modal.Body>
+          <Form.Group>
+            <Form.Label>Your name, recorded in the audit log</Form.Label>
+            <Form.Control
+              size="sm"
+              value={actor}
+              onChange={({ target }) => setActor(target.value)}
+            />
+          </Form.Group>
+          <Form.Group>
+            <Form.Label>Admin token, required to request and review</Form.Label>
+            <Form.Control
+              size="sm"
+              type="password"
+              value={adminToken}
+              onChange={({ target }) => setAdminToken(target.value)}
+            />
+          </Form.Group>
+          {remediation && (
+            <Fragment>
+              <h5>{isActive(remediation) ? 'Current' : 'Last'} remediation</h5>
+              <RemediationDetails remediation={remediation} />
+            </Fragment>
+          )}
+          {!isActive(remediation) && (
+            <Fragment>
+              <h5>Failing checks with a remediation</h5>
+              {remediableChecks.length === 0 ? (
+                <p className="text-muted">No failing check can be remediated.</p>
+              ) : (
+                <Table size="sm">
+                  <thead>
+                    <tr>
+                      <th></th>
+                      <th>Check</th>
+                      <th>Description</th>
+                      <th>Results</th>
+                    </tr>
+                  </thead>
+                  <tbody>
+                    {remediableChecks.map(({ id, description, hosts }) => (
+                      <tr key={id}>
+                        <td>
+                          <Checkbox
+                            checked={selectedChecks.includes(id)}
+                            onChange={() =>
+                              setSelectedChecks(toggle(id, selectedChecks))
+                            }
+                          />
+                        </td>
+                        <td>{id}</td>
+                        <td>{description}</td>
+                        <td>
+                          {Object.keys(hosts).map((host) => (
+                            <CheckResultIcon
+                              key={host}
+                              result={hosts[host].result}
+                              tooltip={host}
+                            />
+                          ))}
+                        </td>
+                      </tr>
+                    ))}
+                  </tbody>
+                </Table>
+              )}
+            </Fragment>
+          )}
+        </Modal.Body>
+        <Modal.Footer>
+          <Button
+            variant="secondary"
+            disabled={loading}
+            onClick={() => setModalOpen(false)}
+          >
+            Close
+          </Button>
+          {pendingApproval && (
+            <Fragment>
+              <Button variant="danger" disabled={loading} onClick={reject}>
+                Reject
+              </Button>
+              <Button
+                variant="primary"
+                disabled={loading || actor.trim() === ''}
+                onClick={approve}
+              >
+                Approve and apply
+              </Button>
+            </Fragment>
+          )}
+          {!isActive(remediation) && (
+            <Button
+              variant="primary"
+              disabled={loading || selectedChecks.length === 0}
+              onClick={preview}
+            >
+              {loading && (
+                <Spinner animation="border" role="status" as="span" size="sm" />
+              )}{' '}
+              Preview changes
+            </Button>
+          )}
+        </Modal.Footer>
+      </Modal>
+    </Fragment>
+  );
+};
+
+ReactDOM.render(
+  <RemediationsButton clusterId={clusterId} />,
+  document.getElementById('cluster-remediations-button')
+);
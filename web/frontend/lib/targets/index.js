@@ -0,0 +1,17 @@
+// The checks targets are the clusters, the standalone hosts and the SAP systems.
+// The HANA databases are SAP systems as well, their checks are stored under the system id
+const resources = {
+  clusters: 'clusters',
+  hosts: 'hosts',
+  sapsystems: 'sapsystems',
+  databases: 'sapsystems',
+};
+
+export const getCheckTarget = (pathname = window.location.pathname) => {
+  const [, resource, id] = pathname.split('/');
+  return { resource: resources[resource] || resource, id };
+};
+
+// the checks without declared targets apply to the clusters only
+export const appliesTo = ({ targets }, targetType) =>
+  (targets && targets.length > 0 ? targets : ['cluster']).includes(targetType);
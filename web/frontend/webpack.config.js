@@ -9,6 +9,10 @@ const config = {
   entry: {
     check_results: './javascripts/check_results.js',
     cluster_check_settings: './javascripts/cluster_check_settings.js',
+    cluster_checks_execution: './javascripts/cluster_checks_execution.js',
+    cluster_checks_history: './javascripts/cluster_checks_history.js',
+    cluster_remediations: './javascripts/cluster_remediations.js',
+    checks_progress: './javascripts/checks_progress.js',
   },
   output: {
     path: path.resolve(__dirname, 'assets/js'),
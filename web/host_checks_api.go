@@ -0,0 +1,72 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiGetHostChecksHandler returns the definitions of the checks selected for the cluster of a host,
+// so its agent can evaluate them locally. A nil engine means the agents are not running the checks
+func ApiGetHostChecksHandler(hostsService services.HostsService, checksService services.ChecksService, engine *checks.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		agentID := c.Param("id")
+
+		host, err := hostsService.GetByID(agentID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		if engine == nil || host.ClusterID == "" {
+			c.JSON(http.StatusOK, []*checks.Check{})
+			return
+		}
+
+		selectedChecks, err := checksService.GetSelectedChecksById(host.ClusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, engine.Select(selectedChecks.SelectedChecks))
+	}
+}
+
+// ApiPostHostChecksResultsHandler stores the check results evaluated by the agent of a host
+func ApiPostHostChecksResultsHandler(hostChecksResultsService services.HostChecksResultsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		agentID := c.Param("id")
+
+		var results map[string]*models.Check
+		if err := c.BindJSON(&results); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err := hostChecksResultsService.Store(agentID, results)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		case errors.Is(err, services.ErrHostWithoutCluster):
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		case err != nil:
+			_ = c.Error(err)
+			return
+		}
+
+		c.Writer.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,137 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiGetHostChecksHandler(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "agent1").Return(&models.Host{ID: "agent1", ClusterID: "cluster1"}, nil)
+	mockHostsService.On("GetByID", "agent2").Return(&models.Host{ID: "agent2"}, nil)
+	mockHostsService.On("GetByID", "other").Return(nil, nil)
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetSelectedChecksById", "cluster1").Return(models.SelectedChecks{
+		ID:             "cluster1",
+		SelectedChecks: []string{"A1244C", "156F64", "unknown"},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.checksService = mockChecksService
+
+	config := setupTestConfig()
+	config.ChecksEngine = ChecksEngineAgent
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/agent1/checks", nil)
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var definitions []*checks.Check
+	err = json.Unmarshal(resp.Body.Bytes(), &definitions)
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 2)
+	assert.Equal(t, "A1244C", definitions[0].ID)
+	assert.Equal(t, "156F64", definitions[1].ID)
+	assert.NotEmpty(t, definitions[0].Expect)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/hosts/agent2/checks", nil)
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, "[]", resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/hosts/other/checks", nil)
+	req.Header.Set("Accept", "application/json")
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestApiGetHostChecksHandlerNotAgentEngine(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "agent1").Return(&models.Host{ID: "agent1", ClusterID: "cluster1"}, nil)
+
+	mockChecksService := new(services.MockChecksService)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/agent1/checks", nil)
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, "[]", resp.Body.String())
+	mockChecksService.AssertNotCalled(t, "GetSelectedChecksById", "cluster1")
+}
+
+func TestApiPostHostChecksResultsHandler(t *testing.T) {
+	results := map[string]*models.Check{
+		"156F64": {Result: models.CheckPassing},
+		"A1244C": {Result: models.CheckCritical, Msg: "totem.token is 5000, expected eq 30000"},
+	}
+
+	mockHostChecksResultsService := new(services.MockHostChecksResultsService)
+	mockHostChecksResultsService.On("Store", "agent1", results).Return(nil)
+	mockHostChecksResultsService.On("Store", "agent2", results).Return(services.ErrHostWithoutCluster)
+	mockHostChecksResultsService.On("Store", "other", results).Return(gorm.ErrRecordNotFound)
+
+	deps := setupTestDependencies()
+	deps.hostChecksResultsService = mockHostChecksResultsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(results)
+
+	for agentID, expectedCode := range map[string]int{
+		"agent1": http.StatusAccepted,
+		"agent2": http.StatusBadRequest,
+		"other":  http.StatusNotFound,
+	} {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/hosts/"+agentID+"/checks/results", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		app.collectorEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, expectedCode, resp.Code, agentID)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/hosts/agent1/checks/results", bytes.NewBufferString("not json"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	mockHostChecksResultsService.AssertNumberOfCalls(t, "Store", 3)
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiListHostsHandler godoc
+// @Summary Retrieve the hosts, optionally filtered
+// @Produce json
+// @Param sids query []string false "Filter by SAP system SIDs" collectionFormat(multi)
+// @Param tags query []string false "Filter by tags" collectionFormat(multi)
+// @Param health query []string false "Filter by health: passing, warning or critical" collectionFormat(multi)
+// @Param page query int false "Page number, all the hosts are returned when no page is given"
+// @Param per_page query int false "Hosts per page"
+// @Success 200 {array} models.Host
+// @Failure 500 {object} map[string]string
+// @Router /hosts [get]
+func ApiListHostsHandler(s services.HostsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+
+		hosts, err := s.GetAll(&services.HostsFilter{
+			SIDs:   query["sids"],
+			Tags:   query["tags"],
+			Health: query["health"],
+		}, queryOptionalPage(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if hosts == nil {
+			hosts = models.HostList{}
+		}
+
+		c.JSON(http.StatusOK, hosts)
+	}
+}
+
+// ApiGetHostHandler godoc
+// @Summary Retrieve a host
+// @Produce json
+// @Param id path string true "Host Id"
+// @Success 200 {object} models.Host
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id} [get]
+func ApiGetHostHandler(s services.HostsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		host, err := s.GetByID(c.Param("id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		c.JSON(http.StatusOK, host)
+	}
+}
@@ -0,0 +1,75 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiListHostsHandler(t *testing.T) {
+	hostsService := new(services.MockHostsService)
+	hostsService.On("GetAll", &services.HostsFilter{Tags: []string{"prod"}}, &services.Page{Number: 2, Size: 1}).Return(models.HostList{
+		{ID: "host2", Name: "vmhana02", Tags: []string{"prod"}},
+	}, nil)
+	hostsService.On("GetAll", &services.HostsFilter{Health: []string{"critical"}}, (*services.Page)(nil)).Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = hostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts?tags=prod&page=2&per_page=1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	var hosts models.HostList
+	json.Unmarshal(resp.Body.Bytes(), &hosts)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, models.HostList{{ID: "host2", Name: "vmhana02", Tags: []string{"prod"}}}, hosts)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/hosts?health=critical", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[]`, resp.Body.String())
+	hostsService.AssertExpectations(t)
+}
+
+func TestApiGetHostHandler(t *testing.T) {
+	hostsService := new(services.MockHostsService)
+	hostsService.On("GetByID", "host1").Return(&models.Host{ID: "host1", Name: "vmhana01"}, nil)
+	hostsService.On("GetByID", "unknown").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = hostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/host1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"name":"vmhana01"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/hosts/unknown", nil)
+	req.Header.Set("Accept", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
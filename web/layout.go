@@ -14,9 +14,7 @@ import (
 
 	"github.com/gin-gonic/gin/render"
 
-	"github.com/gomarkdown/markdown"
-	"github.com/gomarkdown/markdown/html"
-	"github.com/gomarkdown/markdown/parser"
+	"github.com/trento-project/trento/web/markdown"
 )
 
 // LayoutRender wraps user templates into a root one which has it's own data and a bunch of inner blocks
@@ -154,7 +152,7 @@ func (r *LayoutRender) addFileFromFS(templatesFS fs.FS, file string) {
 		"sum": func(a int, b int) int {
 			return a + b
 		},
-		"markdown": markdownToHTML,
+		"markdown": markdown.ToHTML,
 		"split":    strings.Split,
 		"script":   script,
 	})
@@ -169,17 +167,6 @@ func script(filename string) template.HTML {
 	return template.HTML(scriptTag)
 }
 
-func markdownToHTML(md string) template.HTML {
-	extensions := parser.CommonExtensions | parser.AutoHeadingIDs
-	markdownParser := parser.NewWithExtensions(extensions)
-
-	htmlFlags := html.CommonFlags | html.HrefTargetBlank
-	htmlOptions := html.RendererOptions{Flags: htmlFlags}
-	markdownRenderer := html.NewRenderer(htmlOptions)
-	h := markdown.ToHTML([]byte(md), markdownParser, markdownRenderer)
-	return template.HTML(h)
-}
-
 // addTemplate adds a new user template to the render
 func (r *LayoutRender) addTemplate(name string, tmpl *template.Template) {
 	if tmpl == nil {
@@ -0,0 +1,18 @@
+package markdown
+
+import (
+	"html/template"
+
+	gomarkdown "github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/html"
+	"github.com/gomarkdown/markdown/parser"
+)
+
+// ToHTML renders the markdown of the checks descriptions and remediations, shared by the web pages and the reports.
+// The links are opened in a new tab
+func ToHTML(md string) template.HTML {
+	markdownParser := parser.NewWithExtensions(parser.CommonExtensions | parser.AutoHeadingIDs)
+	markdownRenderer := html.NewRenderer(html.RendererOptions{Flags: html.CommonFlags | html.HrefTargetBlank})
+
+	return template.HTML(gomarkdown.ToHTML([]byte(md), markdownParser, markdownRenderer))
+}
@@ -0,0 +1,19 @@
+package markdown
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHTML(t *testing.T) {
+	input := `
+# Heading
+This is a _test_, see [the docs](https://example.com)
+`
+	output := ToHTML(input)
+	expected := template.HTML("<h1 id=\"heading\">Heading</h1>\n\n" +
+		"<p>This is a <em>test</em>, see <a href=\"https://example.com\" target=\"_blank\">the docs</a></p>\n")
+	assert.Equal(t, expected, output)
+}
@@ -1,7 +1,9 @@
 package web
 
 import (
+	"crypto/subtle"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
@@ -28,3 +30,56 @@ func EulaMiddleware(premiumDetection services.PremiumDetectionService) gin.Handl
 		c.Next()
 	}
 }
+
+// RunnerAuthMiddleware only lets the authenticated runners through: the ones sending the runner token
+// as bearer token, or presenting a client certificate verified by the web server TLS configuration
+func RunnerAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if hasVerifiedClientCertificate(c.Request) || hasBearerToken(c.Request, token) {
+			c.Next()
+			return
+		}
+
+		c.Header("WWW-Authenticate", "Bearer")
+		_ = c.Error(UnauthorizedError("the runner is not authenticated"))
+		c.Abort()
+	}
+}
+
+func hasVerifiedClientCertificate(request *http.Request) bool {
+	return request.TLS != nil && len(request.TLS.VerifiedChains) > 0
+}
+
+// AdminAuthMiddleware only lets through the requests sending the admin token as bearer token.
+// Everything is refused when no admin token is configured
+func AdminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if hasBearerToken(c.Request, token) {
+			c.Next()
+			return
+		}
+
+		c.Header("WWW-Authenticate", "Bearer")
+		if token == "" {
+			_ = c.Error(UnauthorizedError("no admin token is configured, the operation is disabled"))
+		} else {
+			_ = c.Error(UnauthorizedError("the admin token is required"))
+		}
+		c.Abort()
+	}
+}
+
+func hasBearerToken(request *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+
+	authorization := request.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return false
+	}
+
+	bearer := strings.TrimPrefix(authorization, "Bearer ")
+
+	return subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+}
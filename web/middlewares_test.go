@@ -1,7 +1,10 @@
 package web
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -67,3 +70,98 @@ func TestEulaMiddlewareError(t *testing.T) {
 
 	assert.Equal(t, 500, resp.Code)
 }
+
+func TestRunnerAuthMiddleware(t *testing.T) {
+	remediationsService := new(services.MockRemediationsService)
+	remediationsService.On("Claim", "runner1").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.remediationsService = remediationsService
+	config := setupTestConfig()
+	config.RunnerToken = "some-token"
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifiedTLS := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{&x509.Certificate{}}}}
+
+	cases := []struct {
+		authorization string
+		tls           *tls.ConnectionState
+		code          int
+	}{
+		{"", nil, http.StatusUnauthorized},
+		{"Bearer other-token", nil, http.StatusUnauthorized},
+		{"some-token", nil, http.StatusUnauthorized},
+		{"Bearer some-token", nil, http.StatusNoContent},
+		{"", &tls.ConnectionState{}, http.StatusUnauthorized},
+		{"", verifiedTLS, http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/remediations/claim?runner_id=runner1", nil)
+		req.Header.Set("Accept", "application/json")
+		if tc.authorization != "" {
+			req.Header.Set("Authorization", tc.authorization)
+		}
+		req.TLS = tc.tls
+
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, tc.code, resp.Code, tc.authorization)
+	}
+
+	// the endpoints not used by the runners are still open
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/ping", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	customChecksService := new(services.MockCustomChecksService)
+	customChecksService.On("Delete", "SITE01").Return(nil)
+
+	verifiedTLS := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{&x509.Certificate{}}}}
+
+	cases := []struct {
+		adminToken    string
+		authorization string
+		tls           *tls.ConnectionState
+		code          int
+	}{
+		{"", "", nil, http.StatusUnauthorized},
+		{"", "Bearer ", nil, http.StatusUnauthorized},
+		{"some-token", "", nil, http.StatusUnauthorized},
+		{"some-token", "Bearer other-token", nil, http.StatusUnauthorized},
+		// the runners certificates don't grant the admin permissions
+		{"some-token", "", verifiedTLS, http.StatusUnauthorized},
+		{"some-token", "Bearer some-token", nil, http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		deps := setupTestDependencies()
+		deps.customChecksService = customChecksService
+		config := setupTestConfig()
+		config.AdminToken = tc.adminToken
+		app, err := NewAppWithDeps(config, deps)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("DELETE", "/api/checks/custom/SITE01", nil)
+		req.Header.Set("Accept", "application/json")
+		if tc.authorization != "" {
+			req.Header.Set("Authorization", tc.authorization)
+		}
+		req.TLS = tc.tls
+
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, tc.code, resp.Code, tc.authorization)
+	}
+}
@@ -0,0 +1,166 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+// The initial schema mirrors what used to be created by AutoMigrate, so that existing
+// installations are adopted by the migration subsystem without any change
+var initialSchema = &db.Migration{
+	Version:     1,
+	Description: "initial schema",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, initialSchemaTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, initialSchemaTables())
+	},
+}
+
+func initialSchemaTables() []table {
+	type settings struct {
+		InstallationID string `gorm:"primaryKey"`
+		EulaAccepted   bool
+	}
+
+	type tag struct {
+		Value        string `gorm:"primaryKey"`
+		ResourceID   string `gorm:"primaryKey"`
+		ResourceType string `gorm:"primaryKey"`
+	}
+
+	type selectedChecks struct {
+		ID             string `gorm:"primaryKey"`
+		SelectedChecks db.StringArray
+	}
+
+	type connectionSettings struct {
+		ID   string `gorm:"primaryKey"`
+		Node string `gorm:"primaryKey"`
+		User string
+	}
+
+	type check struct {
+		ID        string `gorm:"primaryKey"`
+		CreatedAt time.Time
+		Payload   datatypes.JSON
+	}
+
+	type dataCollectedEvent struct {
+		ID            int64
+		CreatedAt     time.Time
+		AgentID       string
+		DiscoveryType string
+		Payload       datatypes.JSON
+	}
+
+	type subscription struct {
+		LastProjectedEventID int64
+		AgentID              string `gorm:"primaryKey"`
+		ProjectorID          string `gorm:"primaryKey"`
+		UpdatedAt            time.Time
+	}
+
+	type hostTelemetry struct {
+		AgentID       string `gorm:"column:agent_id; primaryKey"`
+		HostName      string `gorm:"column:host_name"`
+		SLESVersion   string `gorm:"column:sles_version"`
+		CPUCount      int    `gorm:"column:cpu_count"`
+		SocketCount   int    `gorm:"column:socket_count"`
+		TotalMemoryMB int    `gorm:"column:total_memory_mb"`
+		CloudProvider string `gorm:"column:cloud_provider"`
+		UpdatedAt     time.Time
+	}
+
+	type cluster struct {
+		ID              string `gorm:"primaryKey"`
+		Name            string
+		ClusterType     string
+		SID             string `gorm:"column:sid"`
+		ResourcesNumber int
+		HostsNumber     int
+		UpdatedAt       time.Time
+		Details         datatypes.JSON
+	}
+
+	type host struct {
+		AgentID       string `gorm:"primaryKey"`
+		SSHAddress    string
+		Name          string
+		IPAddresses   db.StringArray
+		CloudProvider string
+		ClusterID     string
+		ClusterName   string
+		ClusterType   string
+		AgentVersion  string
+		UpdatedAt     time.Time
+		CloudData     datatypes.JSON
+	}
+
+	type hostHeartbeat struct {
+		AgentID   string `gorm:"primaryKey"`
+		UpdatedAt time.Time
+	}
+
+	type slesSubscription struct {
+		AgentID            string `gorm:"primaryKey"`
+		ID                 string `gorm:"primaryKey"`
+		Version            string
+		Type               string
+		Arch               string
+		Status             string
+		StartsAt           string
+		ExpiresAt          string
+		SubscriptionStatus string
+	}
+
+	type sapSystemInstance struct {
+		ID                      string `gorm:"primaryKey"`
+		AgentID                 string `gorm:"primaryKey"`
+		Type                    string
+		SID                     string `gorm:"column:sid"`
+		InstanceNumber          string `gorm:"primaryKey"`
+		Features                string
+		Description             string
+		StartPriority           string
+		Status                  string
+		SAPHostname             string
+		HttpPort                int
+		HttpsPort               int
+		SystemReplication       string
+		SystemReplicationStatus string
+		DBHost                  string
+		DBName                  string
+		Tenants                 db.StringArray
+		UpdatedAt               time.Time
+	}
+
+	type checksResult struct {
+		ID        int64
+		CreatedAt time.Time
+		GroupID   string
+		Payload   datatypes.JSON
+	}
+
+	return []table{
+		{"settings", &settings{}},
+		{"tags", &tag{}},
+		{"selected_checks", &selectedChecks{}},
+		{"connection_settings", &connectionSettings{}},
+		{"checks", &check{}},
+		{"data_collected_events", &dataCollectedEvent{}},
+		{"subscriptions", &subscription{}},
+		{"host_telemetry", &hostTelemetry{}},
+		{"clusters", &cluster{}},
+		{"hosts", &host{}},
+		{"host_heartbeats", &hostHeartbeat{}},
+		{"sles_subscriptions", &slesSubscription{}},
+		{"sap_system_instances", &sapSystemInstance{}},
+		{"checks_results", &checksResult{}},
+	}
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var checksExecutions = &db.Migration{
+	Version:     2,
+	Description: "on-demand checks executions",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, checksExecutionsTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, checksExecutionsTables())
+	},
+}
+
+func checksExecutionsTables() []table {
+	type checksExecution struct {
+		ID          int64
+		ClusterID   string `gorm:"index"`
+		Status      string `gorm:"index"`
+		CreatedAt   time.Time
+		StartedAt   *time.Time
+		CompletedAt *time.Time
+		UpdatedAt   time.Time
+	}
+
+	return []table{
+		{"checks_executions", &checksExecution{}},
+	}
+}
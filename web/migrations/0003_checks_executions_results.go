@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var checksExecutionsResults = &db.Migration{
+	Version:     3,
+	Description: "checks executions trigger and results",
+	Up: func(tx *gorm.DB) error {
+		if err := createTables(tx, checksExecutionsResultsTables()); err != nil {
+			return err
+		}
+
+		// the executions created so far were all requested on-demand
+		return tx.Table("checks_executions").Where("trigger IS NULL OR trigger = ?", "").
+			Update("trigger", "manual").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		t := checksExecutionsResultsTables()[0]
+		for _, column := range []string{"trigger", "exit_code", "stderr", "host_errors"} {
+			if err := tx.Table(t.name).Migrator().DropColumn(t.model, column); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+func checksExecutionsResultsTables() []table {
+	type checksExecution struct {
+		ID          int64
+		ClusterID   string `gorm:"index"`
+		Status      string `gorm:"index"`
+		Trigger     string
+		CreatedAt   time.Time
+		StartedAt   *time.Time
+		CompletedAt *time.Time
+		UpdatedAt   time.Time
+		ExitCode    *int
+		Stderr      string
+		HostErrors  datatypes.JSON
+	}
+
+	return []table{
+		{"checks_executions", &checksExecution{}},
+	}
+}
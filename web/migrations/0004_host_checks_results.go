@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var hostChecksResults = &db.Migration{
+	Version:     4,
+	Description: "checks results evaluated by the agents",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, hostChecksResultsTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, hostChecksResultsTables())
+	},
+}
+
+func hostChecksResultsTables() []table {
+	type hostChecksResult struct {
+		AgentID   string `gorm:"primaryKey"`
+		ClusterID string `gorm:"index"`
+		Payload   datatypes.JSON
+		UpdatedAt time.Time
+	}
+
+	return []table{
+		{"host_checks_results", &hostChecksResult{}},
+	}
+}
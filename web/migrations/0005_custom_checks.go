@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var customChecks = &db.Migration{
+	Version:     5,
+	Description: "custom checks and their versions",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, customChecksTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, customChecksTables())
+	},
+}
+
+func customChecksTables() []table {
+	type customCheck struct {
+		ID             string `gorm:"primaryKey"`
+		Version        int
+		Name           string
+		Group          string
+		Description    string
+		Remediation    string
+		Labels         string
+		Implementation string
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+	}
+
+	type customCheckVersion struct {
+		CheckID   string `gorm:"primaryKey"`
+		Version   int    `gorm:"primaryKey;autoIncrement:false"`
+		Payload   datatypes.JSON
+		CreatedAt time.Time
+	}
+
+	return []table{
+		{"custom_checks", &customCheck{}},
+		{"custom_check_versions", &customCheckVersion{}},
+	}
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var checksCatalogVersions = &db.Migration{
+	Version:     6,
+	Description: "checks catalog versions",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, checksCatalogVersionsTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, checksCatalogVersionsTables())
+	},
+}
+
+func checksCatalogVersionsTables() []table {
+	type checksCatalogVersion struct {
+		ID         int64
+		CreatedAt  time.Time
+		Added      datatypes.JSON
+		Removed    datatypes.JSON
+		Changed    datatypes.JSON
+		Superseded datatypes.JSON
+		Catalog    datatypes.JSON
+	}
+
+	return []table{
+		{"checks_catalog_versions", &checksCatalogVersion{}},
+	}
+}
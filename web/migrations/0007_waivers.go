@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var waivers = &db.Migration{
+	Version:     7,
+	Description: "checks waivers and audit log",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, waiversTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, waiversTables())
+	},
+}
+
+func waiversTables() []table {
+	type waiver struct {
+		ID            int64
+		CheckID       string `gorm:"index"`
+		Scope         string
+		Target        string
+		Justification string
+		Owner         string
+		ExpiresAt     time.Time `gorm:"index"`
+		Expired       bool
+		CreatedAt     time.Time
+	}
+
+	type auditLogEntry struct {
+		ID        int64
+		CreatedAt time.Time `gorm:"index"`
+		Action    string
+		Resource  string
+		Actor     string
+		Message   string
+	}
+
+	return []table{
+		{"waivers", &waiver{}},
+		{"audit_log_entries", &auditLogEntry{}},
+	}
+}
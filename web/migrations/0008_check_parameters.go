@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var checkParameters = &db.Migration{
+	Version:     8,
+	Description: "checks parameters overrides per cluster and tag",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, checkParametersTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, checkParametersTables())
+	},
+}
+
+func checkParametersTables() []table {
+	type checkParameters struct {
+		Scope      string `gorm:"primaryKey"`
+		Target     string `gorm:"primaryKey"`
+		Parameters datatypes.JSON
+	}
+
+	return []table{
+		{"check_parameters", &checkParameters{}},
+	}
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var connectionProfiles = &db.Migration{
+	Version:     9,
+	Description: "SSH connection profiles per host and cluster",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, connectionProfilesTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		t := connectionProfilesTables()[0]
+		for _, column := range []string{"port", "private_key", "proxy_jump", "become_method", "become_user"} {
+			if err := tx.Table(t.name).Migrator().DropColumn(t.model, column); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+func connectionProfilesTables() []table {
+	type connectionSettings struct {
+		ID           string `gorm:"primaryKey"`
+		Node         string `gorm:"primaryKey"`
+		User         string
+		Port         int
+		PrivateKey   string
+		ProxyJump    string
+		BecomeMethod string
+		BecomeUser   string
+	}
+
+	return []table{
+		{"connection_settings", &connectionSettings{}},
+	}
+}
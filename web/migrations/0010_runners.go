@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var runners = &db.Migration{
+	Version:     10,
+	Description: "runners and checks executions leases",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, runnersTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, runnersTables())
+	},
+}
+
+func runnersTables() []table {
+	type runner struct {
+		ID          string `gorm:"primaryKey"`
+		Tags        db.StringArray
+		ClusterIDs  db.StringArray
+		HeartbeatAt time.Time `gorm:"index"`
+		CreatedAt   time.Time
+	}
+
+	type runnerLease struct {
+		ClusterID  string `gorm:"primaryKey"`
+		RunnerID   string `gorm:"index"`
+		AcquiredAt time.Time
+		ExpiresAt  time.Time `gorm:"index"`
+	}
+
+	return []table{
+		{"runners", &runner{}},
+		{"runner_leases", &runnerLease{}},
+	}
+}
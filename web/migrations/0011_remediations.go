@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var remediations = &db.Migration{
+	Version:     11,
+	Description: "remediations of the failing checks",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, remediationsTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		return dropTables(tx, remediationsTables())
+	},
+}
+
+func remediationsTables() []table {
+	type remediation struct {
+		ID          int64
+		ClusterID   string `gorm:"index"`
+		Checks      db.StringArray
+		Status      string `gorm:"index"`
+		RequestedBy string
+		ApprovedBy  string
+		PreviewDiff datatypes.JSON
+		AppliedDiff datatypes.JSON
+		ExitCode    *int
+		Stderr      string
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		ApprovedAt  *time.Time
+		CompletedAt *time.Time
+	}
+
+	return []table{
+		{"remediations", &remediation{}},
+	}
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var uniqueQueuedChecksExecution = &db.Migration{
+	Version:     12,
+	Description: "at most one queued checks execution per cluster",
+	Up: func(tx *gorm.DB) error {
+		// concurrent requests might have queued the same execution more than once, only the oldest one is kept
+		err := tx.Exec(
+			"DELETE FROM checks_executions WHERE status = 'queued' AND id NOT IN " +
+				"(SELECT MIN(id) FROM checks_executions WHERE status = 'queued' GROUP BY cluster_id)").Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(
+			"CREATE UNIQUE INDEX idx_checks_executions_queued_cluster ON checks_executions (cluster_id) " +
+				"WHERE status = 'queued'").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		// the SQLite column drops of the later migrations rebuild the table without its partial indexes
+		return tx.Exec("DROP INDEX IF EXISTS idx_checks_executions_queued_cluster").Error
+	},
+}
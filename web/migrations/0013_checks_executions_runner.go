@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var checksExecutionsRunner = &db.Migration{
+	Version:     13,
+	Description: "runner running each checks execution",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, checksExecutionsRunnerTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		t := checksExecutionsRunnerTables()[0]
+		if err := tx.Table(t.name).Migrator().DropColumn(t.model, "runner_id"); err != nil {
+			return err
+		}
+
+		// SQLite rebuilds the table to drop the column, losing the partial index of the previous migration
+		return tx.Exec(
+			"CREATE UNIQUE INDEX IF NOT EXISTS idx_checks_executions_queued_cluster ON checks_executions (cluster_id) " +
+				"WHERE status = 'queued'").Error
+	},
+}
+
+func checksExecutionsRunnerTables() []table {
+	type checksExecution struct {
+		ID          int64
+		ClusterID   string `gorm:"index"`
+		Status      string `gorm:"index"`
+		Trigger     string
+		RunnerID    string
+		CreatedAt   time.Time
+		StartedAt   *time.Time
+		CompletedAt *time.Time
+		UpdatedAt   time.Time
+		ExitCode    *int
+		Stderr      string
+		HostErrors  datatypes.JSON
+	}
+
+	return []table{
+		{"checks_executions", &checksExecution{}},
+	}
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+var remediationsRunner = &db.Migration{
+	Version:     14,
+	Description: "runner previewing or applying each remediation",
+	Up: func(tx *gorm.DB) error {
+		return createTables(tx, remediationsRunnerTables())
+	},
+	Down: func(tx *gorm.DB) error {
+		t := remediationsRunnerTables()[0]
+		return tx.Table(t.name).Migrator().DropColumn(t.model, "runner_id")
+	},
+}
+
+func remediationsRunnerTables() []table {
+	type remediation struct {
+		ID          int64
+		ClusterID   string `gorm:"index"`
+		Checks      db.StringArray
+		Status      string `gorm:"index"`
+		RequestedBy string
+		ApprovedBy  string
+		RunnerID    string
+		PreviewDiff datatypes.JSON
+		AppliedDiff datatypes.JSON
+		ExitCode    *int
+		Stderr      string
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		ApprovedAt  *time.Time
+		CompletedAt *time.Time
+	}
+
+	return []table{
+		{"remediations", &remediation{}},
+	}
+}
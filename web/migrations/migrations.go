@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+// Migrations contains all the versioned schema changes of the web application database.
+// New migrations must be appended with a version higher than the last one.
+// Migrations must not reference the entities package: each one declares a snapshot
+// of the tables it touches, so that later changes to the entities don't alter its behaviour.
+var Migrations = []*db.Migration{
+	initialSchema,
+	checksExecutions,
+	checksExecutionsResults,
+	hostChecksResults,
+	customChecks,
+	checksCatalogVersions,
+	waivers,
+	checkParameters,
+	connectionProfiles,
+	runners,
+	remediations,
+	uniqueQueuedChecksExecution,
+	checksExecutionsRunner,
+	remediationsRunner,
+}
+
+type table struct {
+	name  string
+	model interface{}
+}
+
+func createTables(tx *gorm.DB, tables []table) error {
+	for _, t := range tables {
+		if err := tx.Table(t.name).AutoMigrate(t.model); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dropTables(tx *gorm.DB, tables []table) error {
+	for i := len(tables) - 1; i >= 0; i-- {
+		if err := tx.Migrator().DropTable(tables[i].name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trento-project/trento/internal/db"
+)
+
+func TestMigrateAndRollbackAllSQLite(t *testing.T) {
+	conn, err := db.InitDB(&db.Config{
+		Driver: db.SQLiteDriver,
+		Path:   filepath.Join(t.TempDir(), "trento_migrations_test.db"),
+	})
+	require.NoError(t, err)
+
+	migrator, err := db.NewMigrator(conn, Migrations)
+	require.NoError(t, err)
+
+	migrated, err := migrator.Migrate()
+	require.NoError(t, err)
+	assert.Len(t, migrated, len(Migrations))
+
+	// the rollback of a migration restores the schema of the previous one
+	_, err = migrator.Rollback(len(Migrations) - int(uniqueQueuedChecksExecution.Version))
+	require.NoError(t, err)
+	assert.True(t, conn.Migrator().HasIndex("checks_executions", "idx_checks_executions_queued_cluster"))
+
+	rolledBack, err := migrator.Rollback(int(uniqueQueuedChecksExecution.Version))
+	require.NoError(t, err)
+	assert.Len(t, rolledBack, int(uniqueQueuedChecksExecution.Version))
+
+	version, err := migrator.CurrentVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), version)
+
+	for _, table := range []string{"checks_executions", "remediations", "runners"} {
+		assert.False(t, conn.Migrator().HasTable(table), table)
+	}
+
+	// the schema can be migrated up again after a full rollback
+	migrated, err = migrator.Migrate()
+	require.NoError(t, err)
+	assert.Len(t, migrated, len(Migrations))
+	assert.True(t, conn.Migrator().HasIndex("checks_executions", "idx_checks_executions_queued_cluster"))
+}
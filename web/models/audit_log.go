@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	AuditWaiverCreated string = "waiver_created"
+	AuditWaiverDeleted string = "waiver_deleted"
+	AuditWaiverExpired string = "waiver_expired"
+)
+
+// AuditLogEntry records an action changing how the checks results are evaluated
+type AuditLogEntry struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Action    string    `json:"action"`
+	// Resource identifies what was affected, e.g. "waiver/12"
+	Resource string `json:"resource"`
+	Actor    string `json:"actor,omitempty"`
+	Message  string `json:"message"`
+}
@@ -16,9 +16,20 @@ type Check struct {
 	Implementation string `json:"implementation,omitempty" mapstructure:"implementation,omitempty"`
 	Labels         string `json:"labels,omitempty" mapstructure:"labels,omitempty"`
 	Premium        bool   `json:"premium" mapstructure:"premium"`
+	Custom         bool   `json:"custom,omitempty" mapstructure:"custom,omitempty"`
 	Selected       bool   `json:"selected,omitempty" mapstructure:"selected,omitempty"`
 	Result         string `json:"result,omitempty" mapstructure:"result,omitempty"`
 	Msg            string `json:"msg,omitempty" mapstructure:"msg,omitempty"`
+	// WaivedResult is the original result of a check reported as waived
+	WaivedResult string `json:"waived_result,omitempty" mapstructure:"waived_result,omitempty"`
+	// Supersedes lists the ids of the checks replaced by this one, their selections are migrated to it
+	Supersedes []string `json:"supersedes,omitempty" mapstructure:"supersedes,omitempty"`
+	// Targets lists the target types the check applies to, see AppliesTo
+	Targets []string `json:"targets,omitempty" mapstructure:"targets,omitempty"`
+	// Applicability are the conditions on the target environment for the check to apply, see IsApplicable
+	Applicability *CheckApplicability `json:"applicability,omitempty" mapstructure:"applicability,omitempty"`
+	// Remediable checks fix what they verify when they run without the check mode, see Remediation
+	Remediable bool `json:"remediable,omitempty" mapstructure:"remediable,omitempty"`
 }
 
 type GroupedChecks struct {
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strings"
+)
+
+// CheckApplicability are the conditions a target must meet for a check to apply, the empty ones are always met.
+// A condition is met when any of its values matches the target environment
+type CheckApplicability struct {
+	// Providers are the cloud providers, e.g. azure, aws, gcp
+	Providers []string `json:"providers,omitempty" mapstructure:"providers,omitempty" yaml:"providers,omitempty"`
+	// ClusterTypes are the cluster types, e.g. HANA scale-up
+	ClusterTypes []string `json:"cluster_types,omitempty" mapstructure:"cluster_types,omitempty" yaml:"cluster_types,omitempty"`
+	// FencingTypes are the cluster fencing agents, e.g. external/sbd
+	FencingTypes []string `json:"fencing_types,omitempty" mapstructure:"fencing_types,omitempty" yaml:"fencing_types,omitempty"`
+	// OSVersions are the SLES versions, a version matches its service packs too: 15 matches 15.3
+	OSVersions []string `json:"os_versions,omitempty" mapstructure:"os_versions,omitempty" yaml:"os_versions,omitempty"`
+}
+
+// CheckEnvironment describes a checks target, the providers and OS versions are the ones of all its hosts
+type CheckEnvironment struct {
+	Providers   []string `json:"providers,omitempty"`
+	ClusterType string   `json:"cluster_type,omitempty"`
+	FencingType string   `json:"fencing_type,omitempty"`
+	OSVersions  []string `json:"os_versions,omitempty"`
+}
+
+// IsApplicable tells if the check applies to the environment, the checks without conditions apply to any
+func (c *Check) IsApplicable(env *CheckEnvironment) bool {
+	if c.Applicability == nil || env == nil {
+		return true
+	}
+
+	return c.Applicability.Matches(env)
+}
+
+// Matches tells if the environment meets all the conditions
+func (a *CheckApplicability) Matches(env *CheckEnvironment) bool {
+	return matchesAny(a.Providers, env.Providers, strings.EqualFold) &&
+		matchesAny(a.ClusterTypes, []string{env.ClusterType}, strings.EqualFold) &&
+		matchesAny(a.FencingTypes, []string{env.FencingType}, strings.EqualFold) &&
+		matchesAny(a.OSVersions, env.OSVersions, matchesOSVersion)
+}
+
+func matchesAny(conditions []string, values []string, match func(condition, value string) bool) bool {
+	if len(conditions) == 0 {
+		return true
+	}
+
+	for _, condition := range conditions {
+		for _, value := range values {
+			if value != "" && match(condition, value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchesOSVersion(condition string, version string) bool {
+	return version == condition || strings.HasPrefix(version, condition+".")
+}
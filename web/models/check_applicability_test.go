@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckIsApplicable(t *testing.T) {
+	env := &CheckEnvironment{
+		Providers:   []string{"azure"},
+		ClusterType: ClusterTypeHANAScaleUp,
+		FencingType: "external/sbd",
+		OSVersions:  []string{"15.3", "15.4"},
+	}
+
+	for applicability, expected := range map[*CheckApplicability]bool{
+		nil:                                   true,
+		{}:                                    true,
+		{Providers: []string{"aws", "azure"}}: true,
+		{Providers: []string{"gcp"}}:          false,
+		{ClusterTypes: []string{"hana scale-up"}, FencingTypes: []string{"external/sbd"}}: true,
+		{ClusterTypes: []string{ClusterTypeHANAScaleOut}}:                                 false,
+		{FencingTypes: []string{"fence_azure_arm"}}:                                       false,
+		{OSVersions: []string{"15"}}:                                                      true,
+		{OSVersions: []string{"15.4"}}:                                                    true,
+		{OSVersions: []string{"12", "15.5"}}:                                              false,
+		{OSVersions: []string{"1"}}:                                                       false,
+		{Providers: []string{"azure"}, OSVersions: []string{"15.3"}, FencingTypes: []string{"stonith:sbd"}}: false,
+	} {
+		check := &Check{ID: "ABCDEF", Applicability: applicability}
+		assert.Equal(t, expected, check.IsApplicable(env), "%+v", applicability)
+	}
+}
+
+func TestCheckIsApplicableUnknownEnvironment(t *testing.T) {
+	check := &Check{ID: "ABCDEF", Applicability: &CheckApplicability{ClusterTypes: []string{ClusterTypeHANAScaleUp}}}
+
+	// the conditions on values not discovered are not met
+	assert.False(t, check.IsApplicable(&CheckEnvironment{}))
+	assert.True(t, check.IsApplicable(nil))
+}
@@ -1,11 +1,20 @@
 package models
 
+import (
+	"sort"
+	"time"
+)
+
 const (
-	CheckPassing   string = "passing"
-	CheckWarning   string = "warning"
-	CheckCritical  string = "critical"
-	CheckSkipped   string = "skipped"
-	CheckUndefined string = "undefined"
+	CheckPassing  string = "passing"
+	CheckWarning  string = "warning"
+	CheckCritical string = "critical"
+	CheckSkipped  string = "skipped"
+	// CheckNotApplicable is the result of a selected check not applying to the target environment, it is not run
+	CheckNotApplicable string = "not_applicable"
+	CheckUndefined     string = "undefined"
+	// CheckWaived replaces a failing result covered by a waiver, it does not count in the health
+	CheckWaived string = "waived"
 )
 
 type ChecksResult struct {
@@ -34,9 +43,34 @@ type HostState struct {
 }
 
 type AggregatedCheckData struct {
-	PassingCount  int
-	WarningCount  int
-	CriticalCount int
+	PassingCount  int `json:"passing_count"`
+	WarningCount  int `json:"warning_count"`
+	CriticalCount int `json:"critical_count"`
+}
+
+// ChecksResultSummary describes a past checks execution of a cluster
+type ChecksResultSummary struct {
+	ID         int64                `json:"id"`
+	CreatedAt  time.Time            `json:"created_at"`
+	Health     string               `json:"health"`
+	Aggregated *AggregatedCheckData `json:"aggregated"`
+}
+
+// ChecksResultDiff lists the check results that changed between two executions
+type ChecksResultDiff struct {
+	From    *ChecksResultSummary `json:"from"`
+	To      *ChecksResultSummary `json:"to"`
+	Changes []*CheckResultChange `json:"changes"`
+}
+
+// CheckResultChange is the change of a check result on a host, an empty result means that the check
+// was not executed on the host in that run
+type CheckResultChange struct {
+	CheckID     string `json:"check_id"`
+	Description string `json:"description,omitempty"`
+	Host        string `json:"host"`
+	From        string `json:"from"`
+	To          string `json:"to"`
 }
 
 func (c *ChecksResult) GetAggregatedChecksResultByHost() map[string]*AggregatedCheckData {
@@ -74,6 +108,31 @@ func (c *ChecksResult) GetAggregatedChecksResultByCluster() *AggregatedCheckData
 	return aCheckData
 }
 
+// ApplyWaivers marks as waived the failing results covered by the waivers, keeping the original result.
+// The sid is the one of the cluster, used by the waivers scoped to a SAP system
+func (c *ChecksResult) ApplyWaivers(sid string, waivers []*Waiver) {
+	for checkID, check := range c.Checks {
+		for hostName, host := range check.Hosts {
+			if host.Result != CheckWarning && host.Result != CheckCritical {
+				continue
+			}
+
+			for _, waiver := range waivers {
+				if !waiver.Applies(checkID, c.ID, sid, hostName) {
+					continue
+				}
+
+				waived := *host
+				waived.Result = CheckWaived
+				waived.WaivedResult = host.Result
+				waived.Msg = waiver.String()
+				check.Hosts[hostName] = &waived
+				break
+			}
+		}
+	}
+}
+
 func (a *AggregatedCheckData) String() string {
 	if a.CriticalCount > 0 {
 		return CheckCritical
@@ -85,3 +144,48 @@ func (a *AggregatedCheckData) String() string {
 
 	return CheckUndefined
 }
+
+// Diff returns the check results that differ in the other result, sorted by check and host
+func (c *ChecksResult) Diff(other *ChecksResult) []*CheckResultChange {
+	changes := []*CheckResultChange{}
+	results := checkResultsByHost(c)
+	otherResults := checkResultsByHost(other)
+
+	for checkID, hosts := range results {
+		for host, result := range hosts {
+			if otherResult := otherResults[checkID][host]; otherResult != result {
+				changes = append(changes, &CheckResultChange{CheckID: checkID, Host: host, From: result, To: otherResult})
+			}
+		}
+	}
+
+	for checkID, otherHosts := range otherResults {
+		for host, otherResult := range otherHosts {
+			if _, ok := results[checkID][host]; !ok {
+				changes = append(changes, &CheckResultChange{CheckID: checkID, Host: host, To: otherResult})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].CheckID != changes[j].CheckID {
+			return changes[i].CheckID < changes[j].CheckID
+		}
+		return changes[i].Host < changes[j].Host
+	})
+
+	return changes
+}
+
+func checkResultsByHost(c *ChecksResult) map[string]map[string]string {
+	results := make(map[string]map[string]string)
+
+	for checkID, check := range c.Checks {
+		results[checkID] = make(map[string]string)
+		for host, hostResult := range check.Hosts {
+			results[checkID][host] = hostResult.Result
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksResultDiff(t *testing.T) {
+	from := &ChecksResult{
+		Checks: map[string]*ChecksByHost{
+			"check1": {Hosts: map[string]*Check{"host1": {Result: CheckPassing}, "host2": {Result: CheckCritical}}},
+			"check2": {Hosts: map[string]*Check{"host1": {Result: CheckWarning}}},
+		},
+	}
+	to := &ChecksResult{
+		Checks: map[string]*ChecksByHost{
+			"check1": {Hosts: map[string]*Check{"host1": {Result: CheckPassing}, "host2": {Result: CheckPassing}}},
+			"check3": {Hosts: map[string]*Check{"host1": {Result: CheckCritical}}},
+		},
+	}
+
+	assert.Equal(t, []*CheckResultChange{
+		{CheckID: "check1", Host: "host2", From: CheckCritical, To: CheckPassing},
+		{CheckID: "check2", Host: "host1", From: CheckWarning, To: ""},
+		{CheckID: "check3", Host: "host1", From: "", To: CheckCritical},
+	}, from.Diff(to))
+
+	assert.Empty(t, from.Diff(from))
+}
+
+func TestChecksResultApplyWaivers(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &ChecksResult{
+		ID: "cluster1",
+		Checks: map[string]*ChecksByHost{
+			"check1": {Hosts: map[string]*Check{"host1": {Result: CheckCritical, Msg: "failed"}, "host2": {Result: CheckPassing}}},
+			"check2": {Hosts: map[string]*Check{"host1": {Result: CheckWarning}, "host2": {Result: CheckCritical}}},
+			"check3": {Hosts: map[string]*Check{"host1": {Result: CheckCritical}, "host2": {Result: CheckCritical}}},
+		},
+	}
+
+	result.ApplyWaivers("PRD", []*Waiver{
+		{CheckID: "check1", Scope: WaiverScopeCluster, Target: "cluster1", Owner: "admin", Justification: "known", ExpiresAt: expiresAt},
+		{CheckID: "check2", Scope: WaiverScopeHost, Target: "host2", Owner: "admin", Justification: "known", ExpiresAt: expiresAt},
+		{CheckID: "check3", Scope: WaiverScopeSID, Target: "QAS", Owner: "admin", Justification: "known", ExpiresAt: expiresAt},
+	})
+
+	assert.Equal(t, &Check{
+		Result:       CheckWaived,
+		WaivedResult: CheckCritical,
+		Msg:          "Waived by admin until 2030-01-01 00:00: known",
+	}, result.Checks["check1"].Hosts["host1"])
+	assert.Equal(t, CheckPassing, result.Checks["check1"].Hosts["host2"].Result)
+	assert.Equal(t, CheckWarning, result.Checks["check2"].Hosts["host1"].Result)
+	assert.Equal(t, CheckWaived, result.Checks["check2"].Hosts["host2"].Result)
+	assert.Equal(t, CheckCritical, result.Checks["check3"].Hosts["host1"].Result)
+
+	assert.Equal(t, &AggregatedCheckData{PassingCount: 1, WarningCount: 1, CriticalCount: 2}, result.GetAggregatedChecksResultByCluster())
+}
@@ -1,16 +1,67 @@
 package models
 
 import (
-	"github.com/lib/pq"
+	"github.com/trento-project/trento/internal/db"
 )
 
 type SelectedChecks struct {
-	ID             string         `gorm:"primaryKey"`
-	SelectedChecks pq.StringArray `gorm:"type:text[]"`
+	ID             string `gorm:"primaryKey"`
+	SelectedChecks db.StringArray
+	// RemovedChecks are the selected checks that are not in the catalog anymore
+	RemovedChecks []string `gorm:"-"`
 }
 
+const (
+	CheckParametersClusterScope string = "cluster"
+	CheckParametersTagScope     string = "tag"
+)
+
+// CheckParameters overrides the expected values used by the checks, e.g. the corosync token timeout,
+// for a cluster or for all the clusters with a tag. The keys are the ones of the expected values of the runner
+type CheckParameters struct {
+	Scope      string            `json:"scope"`
+	Target     string            `json:"target"`
+	Parameters map[string]string `json:"parameters"`
+}
+
+// ClusterConnectionNode is the node of the connection settings applying to all the hosts of a cluster
+const ClusterConnectionNode string = ""
+
+// ConnectionSettings is the SSH connection profile used by the runner to reach a host of a cluster,
+// or all of them when the node is ClusterConnectionNode. The empty fields fall back to the cluster profile
 type ConnectionSettings struct {
 	ID   string `gorm:"primaryKey"`
 	Node string `gorm:"primaryKey"`
 	User string
+	Port int
+	// PrivateKey is the path of the SSH private key on the runner host
+	PrivateKey string
+	// ProxyJump is the jump host, as [user@]host[:port]
+	ProxyJump    string
+	BecomeMethod string
+	BecomeUser   string
+}
+
+// WithDefaults fills the empty fields of the connection settings with the defaults ones
+func (c ConnectionSettings) WithDefaults(defaults ConnectionSettings) ConnectionSettings {
+	if c.User == "" {
+		c.User = defaults.User
+	}
+	if c.Port == 0 {
+		c.Port = defaults.Port
+	}
+	if c.PrivateKey == "" {
+		c.PrivateKey = defaults.PrivateKey
+	}
+	if c.ProxyJump == "" {
+		c.ProxyJump = defaults.ProxyJump
+	}
+	if c.BecomeMethod == "" {
+		c.BecomeMethod = defaults.BecomeMethod
+	}
+	if c.BecomeUser == "" {
+		c.BecomeUser = defaults.BecomeUser
+	}
+
+	return c
 }
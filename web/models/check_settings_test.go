@@ -0,0 +1,20 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionSettingsWithDefaults(t *testing.T) {
+	host := ConnectionSettings{ID: "cluster", Node: "host1", User: "admin", Port: 2222}
+	cluster := ConnectionSettings{
+		ID: "cluster", Node: ClusterConnectionNode, User: "root", Port: 22, ProxyJump: "bastion", BecomeMethod: "sudo",
+	}
+
+	assert.Equal(t, ConnectionSettings{
+		ID: "cluster", Node: "host1", User: "admin", Port: 2222, ProxyJump: "bastion", BecomeMethod: "sudo",
+	}, host.WithDefaults(cluster))
+
+	assert.Equal(t, host, host.WithDefaults(ConnectionSettings{}))
+}
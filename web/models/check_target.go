@@ -0,0 +1,26 @@
+package models
+
+// The target types of the checks: the HA clusters, the hosts not belonging to any cluster and the SAP systems,
+// checked on the hosts running their instances
+const (
+	CheckTargetCluster   string = "cluster"
+	CheckTargetHost      string = "host"
+	CheckTargetSAPSystem string = "sapsystem"
+)
+
+var CheckTargetTypes = []string{CheckTargetCluster, CheckTargetHost, CheckTargetSAPSystem}
+
+// AppliesTo tells if the check can run on the given target type, the checks without targets apply to the clusters
+func (c *Check) AppliesTo(targetType string) bool {
+	if len(c.Targets) == 0 {
+		return targetType == CheckTargetCluster
+	}
+
+	for _, target := range c.Targets {
+		if target == targetType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ChecksCatalogVersion is a change of the checks catalog published by the runner
+type ChecksCatalogVersion struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Added     []string  `json:"added"`
+	Removed   []string  `json:"removed"`
+	Changed   []string  `json:"changed"`
+	// Superseded maps the removed checks to the checks replacing them, whose selections were migrated
+	Superseded map[string]string `json:"superseded,omitempty"`
+	// Catalog is the whole catalog of the version, only loaded when a single version is requested
+	Catalog ChecksCatalog `json:"catalog,omitempty"`
+}
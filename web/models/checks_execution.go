@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+const (
+	ChecksExecutionQueued    string = "queued"
+	ChecksExecutionRunning   string = "running"
+	ChecksExecutionCompleted string = "completed"
+	ChecksExecutionFailed    string = "failed"
+
+	ChecksExecutionScheduled string = "scheduled"
+	ChecksExecutionManual    string = "manual"
+)
+
+// ChecksExecution is a run of the checks playbook on a cluster, either scheduled by the runner
+// or requested on-demand
+type ChecksExecution struct {
+	ID          int64             `json:"id"`
+	ClusterID   string            `json:"cluster_id"`
+	Status      string            `json:"status"`
+	Trigger     string            `json:"trigger"`
+	CreatedAt   time.Time         `json:"created_at"`
+	StartedAt   *time.Time        `json:"started_at,omitempty"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+	ExitCode    *int              `json:"exit_code,omitempty"`
+	Stderr      string            `json:"stderr,omitempty"`
+	HostErrors  map[string]string `json:"host_errors,omitempty"`
+}
+
+// ChecksExecutionResult is the outcome of an execution, as reported by the runner
+type ChecksExecutionResult struct {
+	Status     string
+	ExitCode   *int
+	Stderr     string
+	HostErrors map[string]string
+}
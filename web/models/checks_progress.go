@@ -0,0 +1,140 @@
+package models
+
+import "time"
+
+const (
+	// ChecksProgressStarted is sent when a checks run starts on a target, it resets its progress
+	ChecksProgressStarted string = "started"
+	// ChecksProgressTask is sent when a task of a check starts on a host
+	ChecksProgressTask string = "task"
+	// ChecksProgressResult is sent when a check has a result on a host
+	ChecksProgressResult string = "result"
+	// ChecksProgressUnreachable is sent when a host can't be reached
+	ChecksProgressUnreachable string = "unreachable"
+	// ChecksProgressCompleted is sent when the checks run ends on a target
+	ChecksProgressCompleted string = "completed"
+)
+
+// ChecksProgressEvent is a step of a checks run, as reported by the runner while the playbook runs
+type ChecksProgressEvent struct {
+	Type    string    `json:"type"`
+	Host    string    `json:"host,omitempty"`
+	CheckID string    `json:"check_id,omitempty"`
+	Task    string    `json:"task,omitempty"`
+	Result  string    `json:"result,omitempty"`
+	Msg     string    `json:"msg,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// ChecksProgress is the state of the current, or last, checks run of a target
+type ChecksProgress struct {
+	TargetID  string                         `json:"target_id"`
+	Status    string                         `json:"status"`
+	StartedAt time.Time                      `json:"started_at"`
+	UpdatedAt time.Time                      `json:"updated_at"`
+	Hosts     map[string]*HostChecksProgress `json:"hosts"`
+	// LastEvent is the event which led to this state
+	LastEvent *ChecksProgressEvent `json:"last_event,omitempty"`
+}
+
+// HostChecksProgress is the progress of the checks run on a host: the check running and the results so far
+type HostChecksProgress struct {
+	CheckID   string            `json:"check_id,omitempty"`
+	Task      string            `json:"task,omitempty"`
+	Reachable bool              `json:"reachable"`
+	Msg       string            `json:"msg,omitempty"`
+	Results   map[string]string `json:"results"`
+}
+
+// IsValidChecksProgressEvent tells if the event type is known and it has the fields its type needs
+func IsValidChecksProgressEvent(event *ChecksProgressEvent) bool {
+	switch event.Type {
+	case ChecksProgressStarted, ChecksProgressCompleted:
+		return true
+	case ChecksProgressTask:
+		return event.Host != "" && event.CheckID != ""
+	case ChecksProgressResult:
+		return event.Host != "" && event.CheckID != "" && event.Result != ""
+	case ChecksProgressUnreachable:
+		return event.Host != ""
+	default:
+		return false
+	}
+}
+
+// NewChecksProgress returns the progress of a checks run starting on the target
+func NewChecksProgress(targetID string, startedAt time.Time) *ChecksProgress {
+	return &ChecksProgress{
+		TargetID:  targetID,
+		Status:    ChecksExecutionRunning,
+		StartedAt: startedAt,
+		UpdatedAt: startedAt,
+		Hosts:     make(map[string]*HostChecksProgress),
+	}
+}
+
+// Apply updates the progress with an event of the run
+func (p *ChecksProgress) Apply(event *ChecksProgressEvent) {
+	p.UpdatedAt = event.Time
+	p.LastEvent = event
+
+	switch event.Type {
+	case ChecksProgressCompleted:
+		p.Status = ChecksExecutionCompleted
+		for _, host := range p.Hosts {
+			host.CheckID = ""
+			host.Task = ""
+		}
+	case ChecksProgressTask:
+		host := p.host(event.Host)
+		host.Reachable = true
+		host.CheckID = event.CheckID
+		host.Task = event.Task
+	case ChecksProgressResult:
+		host := p.host(event.Host)
+		host.Reachable = true
+		host.Results[event.CheckID] = event.Result
+		if host.CheckID == event.CheckID {
+			host.CheckID = ""
+			host.Task = ""
+		}
+	case ChecksProgressUnreachable:
+		host := p.host(event.Host)
+		host.Reachable = false
+		host.Msg = event.Msg
+		host.CheckID = ""
+		host.Task = ""
+	}
+}
+
+// Copy returns a deep copy of the progress, safe to share while the original is updated
+func (p *ChecksProgress) Copy() *ChecksProgress {
+	progress := *p
+	progress.Hosts = make(map[string]*HostChecksProgress, len(p.Hosts))
+
+	for name, host := range p.Hosts {
+		hostCopy := *host
+		hostCopy.Results = make(map[string]string, len(host.Results))
+		for checkID, result := range host.Results {
+			hostCopy.Results[checkID] = result
+		}
+		progress.Hosts[name] = &hostCopy
+	}
+
+	if p.LastEvent != nil {
+		event := *p.LastEvent
+		progress.LastEvent = &event
+	}
+
+	return &progress
+}
+
+func (p *ChecksProgress) host(name string) *HostChecksProgress {
+	host, ok := p.Hosts[name]
+	if !ok {
+		host = &HostChecksProgress{Results: make(map[string]string)}
+		p.Hosts[name] = host
+	}
+
+	return host
+}
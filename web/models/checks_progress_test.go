@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksProgressApply(t *testing.T) {
+	now := time.Now()
+	progress := NewChecksProgress("cluster1", now)
+
+	progress.Apply(&ChecksProgressEvent{Type: ChecksProgressTask, Host: "node1", CheckID: "156F64", Task: "check corosync token", Time: now})
+	progress.Apply(&ChecksProgressEvent{Type: ChecksProgressUnreachable, Host: "node2", Msg: "timeout", Time: now})
+
+	assert.Equal(t, ChecksExecutionRunning, progress.Status)
+	assert.Equal(t, &HostChecksProgress{
+		CheckID:   "156F64",
+		Task:      "check corosync token",
+		Reachable: true,
+		Results:   map[string]string{},
+	}, progress.Hosts["node1"])
+	assert.Equal(t, &HostChecksProgress{Msg: "timeout", Results: map[string]string{}}, progress.Hosts["node2"])
+
+	later := now.Add(time.Second)
+	progress.Apply(&ChecksProgressEvent{Type: ChecksProgressResult, Host: "node1", CheckID: "156F64", Result: CheckPassing, Time: later})
+
+	assert.Equal(t, &HostChecksProgress{Reachable: true, Results: map[string]string{"156F64": CheckPassing}}, progress.Hosts["node1"])
+	assert.Equal(t, later, progress.UpdatedAt)
+
+	progress.Apply(&ChecksProgressEvent{Type: ChecksProgressCompleted, Time: later})
+	assert.Equal(t, ChecksExecutionCompleted, progress.Status)
+	assert.Equal(t, ChecksProgressCompleted, progress.LastEvent.Type)
+}
+
+func TestChecksProgressCopy(t *testing.T) {
+	progress := NewChecksProgress("cluster1", time.Now())
+	progress.Apply(&ChecksProgressEvent{Type: ChecksProgressResult, Host: "node1", CheckID: "156F64", Result: CheckPassing})
+
+	progressCopy := progress.Copy()
+	progress.Apply(&ChecksProgressEvent{Type: ChecksProgressResult, Host: "node1", CheckID: "53D035", Result: CheckCritical})
+
+	assert.Equal(t, map[string]string{"156F64": CheckPassing}, progressCopy.Hosts["node1"].Results)
+	assert.Equal(t, "156F64", progressCopy.LastEvent.CheckID)
+}
+
+func TestIsValidChecksProgressEvent(t *testing.T) {
+	for event, expected := range map[*ChecksProgressEvent]bool{
+		{Type: ChecksProgressStarted}:                                                      true,
+		{Type: ChecksProgressTask, Host: "node1", CheckID: "156F64"}:                       true,
+		{Type: ChecksProgressTask, Host: "node1"}:                                          false,
+		{Type: ChecksProgressResult, Host: "node1", CheckID: "156F64"}:                     false,
+		{Type: ChecksProgressResult, Host: "node1", CheckID: "156F64", Result: "critical"}: true,
+		{Type: ChecksProgressUnreachable}:                                                  false,
+		{Type: "unknown"}:                                                                  false,
+	} {
+		assert.Equal(t, expected, IsValidChecksProgressEvent(event), "%+v", event)
+	}
+}
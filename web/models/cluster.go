@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 const (
 	ClusterTypeHANAScaleUp  = "HANA scale-up"
@@ -13,58 +16,89 @@ const (
 )
 
 type Cluster struct {
-	ID              string
-	Name            string
-	ClusterType     string
-	SID             string
-	ResourcesNumber int
-	HostsNumber     int
-	Health          string
-	PassingCount    int
-	WarningCount    int
-	CriticalCount   int
-	Tags            []string
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	ClusterType     string   `json:"cluster_type"`
+	SID             string   `json:"sid"`
+	ResourcesNumber int      `json:"resources_number"`
+	HostsNumber     int      `json:"hosts_number"`
+	Health          string   `json:"health"`
+	PassingCount    int      `json:"passing_count"`
+	WarningCount    int      `json:"warning_count"`
+	CriticalCount   int      `json:"critical_count"`
+	Tags            []string `json:"tags"`
 	// TODO: this is frontend specific, should be removed
-	HasDuplicatedName bool
-	Details           interface{}
+	HasDuplicatedName bool        `json:"-"`
+	Details           interface{} `json:"details"`
 }
 
 type ClusterList []*Cluster
 
+// UnmarshalJSON decodes the details of the HANA clusters as HANAClusterDetails, as they are built by the server
+func (c *Cluster) UnmarshalJSON(data []byte) error {
+	type cluster Cluster
+	aux := struct {
+		*cluster
+		Details json.RawMessage `json:"details"`
+	}{cluster: (*cluster)(c)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	c.Details = nil
+	if len(aux.Details) == 0 || string(aux.Details) == "null" {
+		return nil
+	}
+
+	switch c.ClusterType {
+	case ClusterTypeHANAScaleUp, ClusterTypeHANAScaleOut:
+		details := &HANAClusterDetails{}
+		if err := json.Unmarshal(aux.Details, details); err != nil {
+			return err
+		}
+		c.Details = details
+	default:
+		return json.Unmarshal(aux.Details, &c.Details)
+	}
+
+	return nil
+}
+
 type HANAClusterDetails struct {
-	SystemReplicationMode          string
-	SystemReplicationOperationMode string
-	SecondarySyncState             string
-	SRHealthState                  string
-	CIBLastWritten                 time.Time
-	FencingType                    string
-	StoppedResources               []*ClusterResource
-	Nodes                          ClusterNodes
-	SBDDevices                     []*SBDDevice
+	SystemReplicationMode          string             `json:"system_replication_mode"`
+	SystemReplicationOperationMode string             `json:"system_replication_operation_mode"`
+	SecondarySyncState             string             `json:"secondary_sync_state"`
+	SRHealthState                  string             `json:"sr_health_state"`
+	CIBLastWritten                 time.Time          `json:"cib_last_written"`
+	FencingType                    string             `json:"fencing_type"`
+	StoppedResources               []*ClusterResource `json:"stopped_resources"`
+	Nodes                          ClusterNodes       `json:"nodes"`
+	SBDDevices                     []*SBDDevice       `json:"sbd_devices"`
 }
 
 type ClusterResource struct {
-	ID        string
-	Type      string
-	Role      string
-	Status    string
-	FailCount int
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	FailCount int    `json:"fail_count"`
 }
 
 type HANAClusterNode struct {
-	HostID      string
-	Name        string
-	Site        string
-	IPAddresses []string
-	VirtualIPs  []string
-	Health      string
-	HANAStatus  string
-	Attributes  map[string]string
-	Resources   []*ClusterResource
+	HostID      string             `json:"host_id"`
+	Name        string             `json:"name"`
+	Site        string             `json:"site"`
+	IPAddresses []string           `json:"ip_addresses"`
+	VirtualIPs  []string           `json:"virtual_ips"`
+	Health      string             `json:"health"`
+	HANAStatus  string             `json:"hana_status"`
+	Attributes  map[string]string  `json:"attributes"`
+	Resources   []*ClusterResource `json:"resources"`
 }
 
 type SBDDevice struct {
-	Device string
+	Device string `json:"device"`
 }
 
 type ClusterNodes []*HANAClusterNode
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterJSONRoundTrip(t *testing.T) {
+	cluster := &Cluster{
+		ID:          "cluster1",
+		Name:        "hana_cluster",
+		ClusterType: ClusterTypeHANAScaleUp,
+		SID:         "PRD",
+		Tags:        []string{"prod"},
+		Details: &HANAClusterDetails{
+			SystemReplicationMode: "sync",
+			FencingType:           "external/sbd",
+			Nodes: ClusterNodes{
+				{Name: "node1", Site: "site1", HANAStatus: HANAStatusPrimary},
+			},
+			SBDDevices: []*SBDDevice{{Device: "/dev/sdb"}},
+		},
+	}
+
+	data, err := json.Marshal(cluster)
+	assert.NoError(t, err)
+
+	var decoded Cluster
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, cluster, &decoded)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"cluster2","cluster_type":"Unknown","details":null}`), &decoded))
+	assert.Equal(t, "cluster2", decoded.ID)
+	assert.Nil(t, decoded.Details)
+}
+
+func TestHostJSONRoundTrip(t *testing.T) {
+	host := &Host{
+		ID:            "host1",
+		Name:          "vmhana01",
+		CloudProvider: "azure",
+		IPAddresses:   []string{"10.74.1.10"},
+		CloudData:     AzureCloudData{VMName: "vmhana01", VMSize: "Standard_E4s_v3"},
+	}
+
+	data, err := json.Marshal(host)
+	assert.NoError(t, err)
+
+	var decoded Host
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, host, &decoded)
+}
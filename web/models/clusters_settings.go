@@ -1,15 +1,27 @@
 package models
 
+// ClusterSettings are the settings of a checks target: a cluster, a standalone host or a SAP system
 type ClusterSettings struct {
-	ID             string            `json:"id"`
-	SelectedChecks []string          `json:"selected_checks"`
-	Hosts          []*HostConnection `json:"hosts"`
+	ID             string   `json:"id"`
+	TargetType     string   `json:"target_type"`
+	SelectedChecks []string `json:"selected_checks"`
+	RemovedChecks  []string `json:"removed_checks,omitempty"`
+	// NotApplicableChecks are the selected checks not applying to the target environment, they are not run
+	NotApplicableChecks []string          `json:"not_applicable_checks,omitempty"`
+	Hosts               []*HostConnection `json:"hosts"`
+	// CheckParameters are the overrides of the cluster merged with the ones of its tags
+	CheckParameters map[string]string `json:"check_parameters,omitempty"`
 }
 
 type HostConnection struct {
-	Name    string `json:"name"`
-	Address string `json:"address"`
-	User    string `json:"user"`
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	User         string `json:"user"`
+	Port         int    `json:"port,omitempty"`
+	PrivateKey   string `json:"private_key,omitempty"`
+	ProxyJump    string `json:"proxy_jump,omitempty"`
+	BecomeMethod string `json:"become_method,omitempty"`
+	BecomeUser   string `json:"become_user,omitempty"`
 }
 
 type ClustersSettings []*ClusterSettings
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// CustomCheck is a site-specific check authored through the API, stored apart from the built-in catalog
+// published by the runner. Its implementation is the list of ansible tasks run on every cluster node
+type CustomCheck struct {
+	ID             string    `json:"id"`
+	Version        int       `json:"version"`
+	Name           string    `json:"name"`
+	Group          string    `json:"group"`
+	Description    string    `json:"description"`
+	Remediation    string    `json:"remediation,omitempty"`
+	Labels         string    `json:"labels,omitempty"`
+	Implementation string    `json:"implementation"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ToCheck returns the catalog entry of the custom check
+func (c *CustomCheck) ToCheck() *Check {
+	return &Check{
+		ID:             c.ID,
+		Name:           c.Name,
+		Group:          c.Group,
+		Description:    c.Description,
+		Remediation:    c.Remediation,
+		Implementation: c.Implementation,
+		Labels:         c.Labels,
+		Custom:         true,
+	}
+}
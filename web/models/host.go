@@ -1,6 +1,8 @@
 package models
 
 import (
+	"encoding/json"
+
 	"github.com/trento-project/trento/internal/cloud"
 )
 
@@ -16,18 +18,18 @@ const (
 )
 
 type Host struct {
-	ID            string
-	Name          string
-	Health        string
-	IPAddresses   []string
-	CloudProvider string
-	ClusterID     string
-	ClusterName   string
-	ClusterType   string
-	SAPSystems    []*SAPSystem
-	AgentVersion  string
-	Tags          []string
-	CloudData     interface{}
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Health        string       `json:"health"`
+	IPAddresses   []string     `json:"ip_addresses"`
+	CloudProvider string       `json:"cloud_provider"`
+	ClusterID     string       `json:"cluster_id"`
+	ClusterName   string       `json:"cluster_name"`
+	ClusterType   string       `json:"cluster_type"`
+	SAPSystems    []*SAPSystem `json:"sap_systems"`
+	AgentVersion  string       `json:"agent_version"`
+	Tags          []string     `json:"tags"`
+	CloudData     interface{}  `json:"cloud_data"`
 }
 
 type AzureCloudData struct {
@@ -43,6 +45,36 @@ type AzureCloudData struct {
 
 type HostList []*Host
 
+// UnmarshalJSON decodes the cloud data of the Azure hosts as AzureCloudData, as they are built by the server
+func (h *Host) UnmarshalJSON(data []byte) error {
+	type host Host
+	aux := struct {
+		*host
+		CloudData json.RawMessage `json:"cloud_data"`
+	}{host: (*host)(h)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	h.CloudData = nil
+	if len(aux.CloudData) == 0 || string(aux.CloudData) == "null" {
+		return nil
+	}
+
+	if h.CloudProvider == cloud.Azure {
+		var cloudData AzureCloudData
+		if err := json.Unmarshal(aux.CloudData, &cloudData); err != nil {
+			return err
+		}
+		h.CloudData = cloudData
+
+		return nil
+	}
+
+	return json.Unmarshal(aux.CloudData, &h.CloudData)
+}
+
 func (h *Host) PrettyProvider() string {
 	switch h.CloudProvider {
 	case cloud.Azure:
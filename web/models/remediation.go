@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+const (
+	// RemediationPreviewQueued waits for a runner to preview the changes, running the checks in diff mode
+	RemediationPreviewQueued string = "preview_queued"
+	RemediationPreviewing    string = "previewing"
+	// RemediationPendingApproval has its changes previewed, they are applied only once approved
+	RemediationPendingApproval string = "pending_approval"
+	RemediationApproved        string = "approved"
+	RemediationApplying        string = "applying"
+	RemediationApplied         string = "applied"
+	RemediationRejected        string = "rejected"
+	RemediationFailed          string = "failed"
+
+	AuditRemediationRequested string = "remediation_requested"
+	AuditRemediationApproved  string = "remediation_approved"
+	AuditRemediationRejected  string = "remediation_rejected"
+	AuditRemediationApplied   string = "remediation_applied"
+	AuditRemediationFailed    string = "remediation_failed"
+)
+
+// Remediation fixes the failing checks of a cluster, running their tasks without the check mode.
+// The changes are previewed first, and applied only after an explicit approval
+type Remediation struct {
+	ID          int64    `json:"id"`
+	ClusterID   string   `json:"cluster_id"`
+	Checks      []string `json:"checks"`
+	Status      string   `json:"status"`
+	RequestedBy string   `json:"requested_by"`
+	ApprovedBy  string   `json:"approved_by,omitempty"`
+	// PreviewDiff are the changes the remediation would apply, by host and check
+	PreviewDiff RemediationDiff `json:"preview_diff,omitempty"`
+	// AppliedDiff are the changes actually applied, by host and check
+	AppliedDiff RemediationDiff `json:"applied_diff,omitempty"`
+	ExitCode    *int            `json:"exit_code,omitempty"`
+	Stderr      string          `json:"stderr,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ApprovedAt  *time.Time      `json:"approved_at,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// RemediationDiff maps the hosts to the unified diffs of the changes of each check
+type RemediationDiff map[string]map[string]string
+
+// RemediationResult is the outcome of a preview or of an apply run, as reported by the runner
+type RemediationResult struct {
+	Failed   bool
+	Diff     RemediationDiff
+	ExitCode *int
+	Stderr   string
+}
+
+// IsPreview tells if the remediation run only previews the changes
+func (r *Remediation) IsPreview() bool {
+	return r.Status == RemediationPreviewQueued || r.Status == RemediationPreviewing
+}
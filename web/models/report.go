@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	ReportScopeCluster   string = "cluster"
+	ReportScopeTag       string = "tag"
+	ReportScopeLandscape string = "landscape"
+)
+
+// Report gathers the last checks results of one or more clusters, as evidence of their compliance
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Scope       string    `json:"scope"`
+	// Target is the cluster id or the tag of the report, empty for the whole landscape
+	Target     string               `json:"target,omitempty"`
+	Aggregated *AggregatedCheckData `json:"aggregated"`
+	Clusters   []*ClusterReport     `json:"clusters"`
+}
+
+type ClusterReport struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	SID  string   `json:"sid,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+	// ExecutedAt is the time of the last checks execution, nil when the checks never ran on the cluster
+	ExecutedAt *time.Time           `json:"executed_at,omitempty"`
+	Health     string               `json:"health"`
+	Aggregated *AggregatedCheckData `json:"aggregated"`
+	Hosts      []*ReportHost        `json:"hosts"`
+	Checks     []*ReportCheck       `json:"checks"`
+	Waivers    []*Waiver            `json:"waivers"`
+}
+
+type ReportHost struct {
+	Name      string `json:"name"`
+	Reachable bool   `json:"reachable"`
+	Msg       string `json:"msg,omitempty"`
+}
+
+type ReportCheck struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name,omitempty"`
+	Group       string               `json:"group,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Remediation string               `json:"remediation,omitempty"`
+	Results     []*ReportCheckResult `json:"results"`
+}
+
+type ReportCheckResult struct {
+	Host         string `json:"host"`
+	Result       string `json:"result"`
+	WaivedResult string `json:"waived_result,omitempty"`
+	Msg          string `json:"msg,omitempty"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Runner is a checks runner instance, registered by its heartbeats. The runner affinity, tags and cluster ids,
+// limits the clusters it is leased: a runner without affinity can check any cluster
+type Runner struct {
+	ID          string         `json:"id"`
+	Tags        []string       `json:"tags"`
+	ClusterIDs  []string       `json:"cluster_ids"`
+	HeartbeatAt time.Time      `json:"heartbeat_at"`
+	Alive       bool           `json:"alive"`
+	Leases      []*RunnerLease `json:"leases"`
+}
+
+// RunnerLease grants a runner the checks executions of a cluster until it expires,
+// a lease is renewed by every heartbeat of its runner
+type RunnerLease struct {
+	ClusterID  string    `json:"cluster_id"`
+	RunnerID   string    `json:"runner_id"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// HasAffinity tells if the runner is restricted to some clusters
+func (r *Runner) HasAffinity() bool {
+	return len(r.Tags) > 0 || len(r.ClusterIDs) > 0
+}
+
+// CanServe tells if the runner affinity matches the cluster, given the cluster tags
+func (r *Runner) CanServe(clusterID string, clusterTags []string) bool {
+	if !r.HasAffinity() {
+		return true
+	}
+
+	for _, id := range r.ClusterIDs {
+		if id == clusterID {
+			return true
+		}
+	}
+
+	for _, tag := range r.Tags {
+		for _, clusterTag := range clusterTags {
+			if tag == clusterTag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
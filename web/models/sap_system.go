@@ -6,35 +6,35 @@ const (
 )
 
 type SAPSystem struct {
-	ID               string
-	SID              string
-	Type             string
-	Instances        []*SAPSystemInstance
-	AttachedDatabase *SAPSystem
-	DBName           string
-	DBHost           string
-	Tags             []string
+	ID               string               `json:"id"`
+	SID              string               `json:"sid"`
+	Type             string               `json:"type"`
+	Instances        []*SAPSystemInstance `json:"instances"`
+	AttachedDatabase *SAPSystem           `json:"attached_database"`
+	DBName           string               `json:"db_name"`
+	DBHost           string               `json:"db_host"`
+	Tags             []string             `json:"tags"`
 	// TODO: this is frontend specific, should be removed
-	HasDuplicatedSID bool
+	HasDuplicatedSID bool `json:"-"`
 }
 
 type SAPSystemInstance struct {
-	Type                    string
-	SID                     string
-	Features                string
-	InstanceNumber          string
-	SystemReplication       string
-	SystemReplicationStatus string
-	SAPHostname             string
-	Status                  string
-	StartPriority           string
-	HttpPort                int
-	HttpsPort               int
-	ClusterName             string
-	ClusterID               string
-	ClusterType             string
-	HostID                  string
-	Hostname                string
+	Type                    string `json:"type"`
+	SID                     string `json:"sid"`
+	Features                string `json:"features"`
+	InstanceNumber          string `json:"instance_number"`
+	SystemReplication       string `json:"system_replication"`
+	SystemReplicationStatus string `json:"system_replication_status"`
+	SAPHostname             string `json:"sap_hostname"`
+	Status                  string `json:"status"`
+	StartPriority           string `json:"start_priority"`
+	HttpPort                int    `json:"http_port"`
+	HttpsPort               int    `json:"https_port"`
+	ClusterName             string `json:"cluster_name"`
+	ClusterID               string `json:"cluster_id"`
+	ClusterType             string `json:"cluster_type"`
+	HostID                  string `json:"host_id"`
+	Hostname                string `json:"hostname"`
 }
 
 type SAPSystemList []*SAPSystem
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	WaiverScopeCluster string = "cluster"
+	WaiverScopeHost    string = "host"
+	WaiverScopeSID     string = "sid"
+)
+
+// Waiver accepts the risk of a failing check on a cluster, a host or the clusters of a SAP system,
+// until it expires
+type Waiver struct {
+	ID      int64  `json:"id"`
+	CheckID string `json:"check_id"`
+	// Scope tells what the target is: the id of a cluster, the name of a host or a SID
+	Scope         string    `json:"scope"`
+	Target        string    `json:"target"`
+	Justification string    `json:"justification"`
+	Owner         string    `json:"owner"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	Expired       bool      `json:"expired"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Applies tells if the waiver covers the result of the check on a host of the cluster
+func (w *Waiver) Applies(checkID string, clusterID string, sid string, host string) bool {
+	if w.CheckID != checkID {
+		return false
+	}
+
+	switch w.Scope {
+	case WaiverScopeCluster:
+		return w.Target == clusterID
+	case WaiverScopeHost:
+		return w.Target == host
+	case WaiverScopeSID:
+		return sid != "" && w.Target == sid
+	}
+
+	return false
+}
+
+func (w *Waiver) String() string {
+	return fmt.Sprintf("Waived by %s until %s: %s", w.Owner, w.ExpiresAt.Format("2006-01-02 15:04"), w.Justification)
+}
@@ -0,0 +1,177 @@
+package nativechecks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// Runner runs the checks server-side with the native engine, evaluating them against the facts
+// published by the agents instead of running ansible over SSH on every node
+type Runner struct {
+	engine                  *checks.Engine
+	checksService           services.ChecksService
+	checksExecutionsService services.ChecksExecutionsService
+	clustersService         services.ClustersService
+	factsService            services.FactsService
+	interval                time.Duration
+	pollInterval            time.Duration
+	lock                    sync.Mutex
+}
+
+func NewRunner(
+	engine *checks.Engine,
+	checksService services.ChecksService,
+	checksExecutionsService services.ChecksExecutionsService,
+	clustersService services.ClustersService,
+	factsService services.FactsService,
+	interval time.Duration,
+	pollInterval time.Duration,
+) *Runner {
+	return &Runner{
+		engine:                  engine,
+		checksService:           checksService,
+		checksExecutionsService: checksExecutionsService,
+		clustersService:         clustersService,
+		factsService:            factsService,
+		interval:                interval,
+		pollInterval:            pollInterval,
+	}
+}
+
+// Start publishes the native checks catalog, then runs the checks of every cluster periodically
+// and the on-demand executions as soon as they are requested
+func (r *Runner) Start(ctx context.Context) {
+	log.Infof("Starting native checks runner")
+
+	if err := r.checksService.CreateChecksCatalog(r.engine.Catalog()); err != nil {
+		log.Errorf("Error storing the native checks catalog: %s", err)
+	}
+
+	go internal.Repeat("native_checks.executions", func() { r.runQueuedExecutions(ctx) }, r.pollInterval, ctx)
+	internal.Repeat("native_checks.scheduled", r.runScheduledChecks, r.interval, ctx)
+}
+
+func (r *Runner) runScheduledChecks() {
+	clustersSettings, err := r.clustersService.GetAllClustersSettings()
+	if err != nil {
+		log.Errorf("Error getting the clusters settings: %s", err)
+		return
+	}
+
+	for _, settings := range clustersSettings {
+		if len(settings.SelectedChecks) == 0 {
+			continue
+		}
+
+		execution, err := r.checksExecutionsService.Start(settings.ID, models.ChecksExecutionScheduled)
+		if err != nil {
+			log.Errorf("Error recording the checks execution of cluster %s: %s", settings.ID, err)
+			continue
+		}
+
+		r.runExecution(execution)
+	}
+}
+
+func (r *Runner) runQueuedExecutions(ctx context.Context) {
+	for ctx.Err() == nil {
+		// the native engine runs in the server and checks every cluster, it holds no leases
+		execution, err := r.checksExecutionsService.Claim("")
+		if err != nil {
+			log.Errorf("Error claiming an on-demand checks execution: %s", err)
+			return
+		}
+
+		if execution == nil {
+			return
+		}
+
+		log.Infof("Running on-demand checks execution %d for cluster %s", execution.ID, execution.ClusterID)
+		r.runExecution(execution)
+	}
+}
+
+func (r *Runner) runExecution(execution *models.ChecksExecution) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	result := &models.ChecksExecutionResult{Status: models.ChecksExecutionCompleted}
+
+	checksResult, err := r.runChecks(execution.ClusterID)
+	if err != nil {
+		log.Errorf("Checks execution %d failed: %s", execution.ID, err)
+		result.Status = models.ChecksExecutionFailed
+		result.Stderr = err.Error()
+	}
+
+	if checksResult != nil {
+		for host, state := range checksResult.Hosts {
+			if state.Reachable {
+				continue
+			}
+			if result.HostErrors == nil {
+				result.HostErrors = make(map[string]string)
+			}
+			result.HostErrors[host] = state.Msg
+		}
+	}
+
+	if err := r.checksExecutionsService.Complete(execution.ID, result); err != nil {
+		log.Errorf("Error updating the checks execution %d: %s", execution.ID, err)
+	}
+}
+
+func (r *Runner) runChecks(clusterID string) (*models.ChecksResult, error) {
+	settings, err := r.clustersService.GetClusterSettingsByID(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings == nil || len(settings.SelectedChecks)+len(settings.NotApplicableChecks) == 0 {
+		return nil, fmt.Errorf("no checks selected for the cluster %s", clusterID)
+	}
+
+	for _, checkID := range settings.SelectedChecks {
+		if !r.engine.Has(checkID) {
+			log.Warnf("Check %s of cluster %s can't be evaluated natively, skipping it", checkID, clusterID)
+		}
+	}
+
+	facts, err := r.factsService.GetClusterFacts(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	checksResult := r.engine.Run(settings.SelectedChecks, facts)
+	checksResult.ID = clusterID
+	addNotApplicableResults(checksResult, settings.NotApplicableChecks)
+
+	if err := r.checksService.CreateChecksResult(checksResult); err != nil {
+		return checksResult, err
+	}
+
+	return checksResult, nil
+}
+
+// addNotApplicableResults reports the selected checks not applying to the cluster environment
+// on every reachable host, instead of evaluating them
+func addNotApplicableResults(checksResult *models.ChecksResult, notApplicableChecks []string) {
+	for _, checkID := range notApplicableChecks {
+		checkResult := &models.ChecksByHost{ID: checkID, Hosts: make(map[string]*models.Check)}
+		for host, state := range checksResult.Hosts {
+			if !state.Reachable {
+				continue
+			}
+			checkResult.Hosts[host] = &models.Check{Result: models.CheckNotApplicable}
+		}
+		checksResult.Checks[checkID] = checkResult
+	}
+}
@@ -0,0 +1,142 @@
+package nativechecks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func testEngine(t *testing.T) *checks.Engine {
+	engine, err := checks.NewEngine([]*checks.Check{
+		{
+			ID:     "156F64",
+			Name:   "1.1.1",
+			Group:  "Corosync",
+			Expect: []*checks.Expectation{{Fact: "cluster.Corosync.totem.token", Operator: checks.OpEqual, Value: "30000"}},
+		},
+	})
+	assert.NoError(t, err)
+
+	return engine
+}
+
+func testFacts() map[string]checks.Facts {
+	return map[string]checks.Facts{
+		"node1": {"cluster": map[string]interface{}{"Corosync": map[string]interface{}{"totem": map[string]interface{}{"token": "30000"}}}},
+		"node2": {},
+	}
+}
+
+func TestRunnerRunScheduledChecks(t *testing.T) {
+	checksService := new(services.MockChecksService)
+	checksExecutionsService := new(services.MockChecksExecutionsService)
+	clustersService := new(services.MockClustersService)
+	factsService := new(services.MockFactsService)
+
+	clustersService.On("GetAllClustersSettings").Return(models.ClustersSettings{
+		{ID: "cluster1", SelectedChecks: []string{"156F64", "ansible-only"}},
+		{ID: "cluster2", SelectedChecks: []string{}},
+	}, nil)
+	checksExecutionsService.On("Start", "cluster1", models.ChecksExecutionScheduled).Return(
+		&models.ChecksExecution{ID: 1, ClusterID: "cluster1"}, nil)
+	clustersService.On("GetClusterSettingsByID", "cluster1").Return(&models.ClusterSettings{
+		ID:                  "cluster1",
+		SelectedChecks:      []string{"156F64", "ansible-only"},
+		NotApplicableChecks: []string{"AZURE1"},
+	}, nil)
+	factsService.On("GetClusterFacts", "cluster1").Return(testFacts(), nil)
+	checksService.On("CreateChecksResult", &models.ChecksResult{
+		ID: "cluster1",
+		Hosts: map[string]*models.HostState{
+			"node1": {Reachable: true},
+			"node2": {Reachable: false, Msg: "No facts collected from the host"},
+		},
+		Checks: map[string]*models.ChecksByHost{
+			"156F64": {ID: "156F64", Hosts: map[string]*models.Check{"node1": {Result: models.CheckPassing}}},
+			"AZURE1": {ID: "AZURE1", Hosts: map[string]*models.Check{"node1": {Result: models.CheckNotApplicable}}},
+		},
+	}).Return(nil)
+	checksExecutionsService.On("Complete", int64(1), &models.ChecksExecutionResult{
+		Status:     models.ChecksExecutionCompleted,
+		HostErrors: map[string]string{"node2": "No facts collected from the host"},
+	}).Return(nil)
+
+	runner := NewRunner(testEngine(t), checksService, checksExecutionsService, clustersService, factsService, 0, 0)
+	runner.runScheduledChecks()
+
+	checksService.AssertExpectations(t)
+	checksExecutionsService.AssertExpectations(t)
+	clustersService.AssertExpectations(t)
+	factsService.AssertExpectations(t)
+}
+
+func TestRunnerRunQueuedExecutions(t *testing.T) {
+	checksService := new(services.MockChecksService)
+	checksExecutionsService := new(services.MockChecksExecutionsService)
+	clustersService := new(services.MockClustersService)
+	factsService := new(services.MockFactsService)
+
+	checksExecutionsService.On("Claim", "").Return(&models.ChecksExecution{ID: 1, ClusterID: "cluster1"}, nil).Once()
+	checksExecutionsService.On("Claim", "").Return(&models.ChecksExecution{ID: 2, ClusterID: "cluster2"}, nil).Once()
+	checksExecutionsService.On("Claim", "").Return(&models.ChecksExecution{ID: 3, ClusterID: "cluster3"}, nil).Once()
+	checksExecutionsService.On("Claim", "").Return(nil, nil).Once()
+
+	clustersService.On("GetClusterSettingsByID", "cluster1").Return(
+		&models.ClusterSettings{ID: "cluster1", SelectedChecks: []string{"156F64"}}, nil)
+	factsService.On("GetClusterFacts", "cluster1").Return(testFacts(), nil)
+	checksService.On("CreateChecksResult", mock.Anything).Return(nil)
+	checksExecutionsService.On("Complete", int64(1), &models.ChecksExecutionResult{
+		Status:     models.ChecksExecutionCompleted,
+		HostErrors: map[string]string{"node2": "No facts collected from the host"},
+	}).Return(nil)
+
+	clustersService.On("GetClusterSettingsByID", "cluster2").Return(
+		&models.ClusterSettings{ID: "cluster2", SelectedChecks: []string{}}, nil)
+	checksExecutionsService.On("Complete", int64(2), &models.ChecksExecutionResult{
+		Status: models.ChecksExecutionFailed,
+		Stderr: "no checks selected for the cluster cluster2",
+	}).Return(nil)
+
+	clustersService.On("GetClusterSettingsByID", "cluster3").Return(
+		&models.ClusterSettings{ID: "cluster3", SelectedChecks: []string{"156F64"}}, nil)
+	factsService.On("GetClusterFacts", "cluster3").Return(nil, fmt.Errorf("kaboom"))
+	checksExecutionsService.On("Complete", int64(3), &models.ChecksExecutionResult{
+		Status: models.ChecksExecutionFailed,
+		Stderr: "kaboom",
+	}).Return(nil)
+
+	runner := NewRunner(testEngine(t), checksService, checksExecutionsService, clustersService, factsService, 0, 0)
+	runner.runQueuedExecutions(context.Background())
+
+	checksService.AssertExpectations(t)
+	checksExecutionsService.AssertExpectations(t)
+	clustersService.AssertExpectations(t)
+	factsService.AssertExpectations(t)
+}
+
+func TestRunnerStart(t *testing.T) {
+	checksService := new(services.MockChecksService)
+	checksExecutionsService := new(services.MockChecksExecutionsService)
+	clustersService := new(services.MockClustersService)
+
+	engine := testEngine(t)
+	checksService.On("CreateChecksCatalog", engine.Catalog()).Return(nil)
+	checksExecutionsService.On("Claim", "").Return(nil, nil)
+	clustersService.On("GetAllClustersSettings").Return(models.ClustersSettings{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	runner := NewRunner(engine, checksService, checksExecutionsService, clustersService, nil, time.Hour, time.Hour)
+	runner.Start(ctx)
+
+	checksService.AssertExpectations(t)
+	clustersService.AssertExpectations(t)
+}
@@ -0,0 +1,244 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONRemediationRequest struct {
+	Checks      []string `json:"checks" binding:"required"`
+	RequestedBy string   `json:"requested_by"`
+}
+
+type JSONRemediationReview struct {
+	Actor string `json:"actor"`
+}
+
+// JSONRemediationResult is the outcome of a remediation run reported by the runner
+type JSONRemediationResult struct {
+	Failed   bool                   `json:"failed"`
+	Diff     models.RemediationDiff `json:"diff"`
+	ExitCode *int                   `json:"exit_code,omitempty"`
+	Stderr   string                 `json:"stderr,omitempty"`
+}
+
+// ApiClusterRemediationsHandler godoc
+// @Summary Retrieve the remediations of a cluster, the most recent first
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Param page query int false "Page number"
+// @Param per_page query int false "Remediations per page"
+// @Success 200 {array} models.Remediation
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/remediations [get]
+func ApiClusterRemediationsHandler(s services.RemediationsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		remediations, err := s.GetAllByCluster(c.Param("cluster_id"), queryPage(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, remediations)
+	}
+}
+
+// ApiCreateClusterRemediationHandler godoc
+// @Summary Request the remediation of failing checks of a cluster
+// @Description The changes are previewed first, running the checks in diff mode. They are applied once approved
+// @Accept json
+// @Produce json
+// @Param id path string true "Cluster Id"
+// @Param Body body JSONRemediationRequest true "Checks to remediate"
+// @Success 201 {object} models.Remediation
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /clusters/{id}/remediations [post]
+func ApiCreateClusterRemediationHandler(s services.RemediationsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONRemediationRequest
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		remediation, err := s.Create(c.Param("id"), r.Checks, r.RequestedBy)
+		if err != nil {
+			_ = c.Error(remediationError(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, remediation)
+	}
+}
+
+// ApiGetRemediationHandler godoc
+// @Summary Retrieve a remediation, with the diff of its changes
+// @Produce json
+// @Param id path int true "Remediation Id"
+// @Success 200 {object} models.Remediation
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /remediations/{id} [get]
+func ApiGetRemediationHandler(s services.RemediationsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid remediation id"))
+			return
+		}
+
+		remediation, err := s.GetByID(id)
+		if err != nil {
+			_ = c.Error(remediationError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, remediation)
+	}
+}
+
+// ApiApproveRemediationHandler godoc
+// @Summary Approve the previewed changes of a remediation, the runner applies them next
+// @Accept json
+// @Produce json
+// @Param id path int true "Remediation Id"
+// @Param Body body JSONRemediationReview true "Approver"
+// @Success 200 {object} models.Remediation
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /remediations/{id}/approve [post]
+func ApiApproveRemediationHandler(s services.RemediationsService) gin.HandlerFunc {
+	return remediationReviewHandler(func(id int64, actor string) (*models.Remediation, error) {
+		return s.Approve(id, actor)
+	})
+}
+
+// ApiRejectRemediationHandler godoc
+// @Summary Reject the previewed changes of a remediation, they are not applied
+// @Accept json
+// @Produce json
+// @Param id path int true "Remediation Id"
+// @Param Body body JSONRemediationReview true "Reviewer"
+// @Success 200 {object} models.Remediation
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /remediations/{id}/reject [post]
+func ApiRejectRemediationHandler(s services.RemediationsService) gin.HandlerFunc {
+	return remediationReviewHandler(func(id int64, actor string) (*models.Remediation, error) {
+		return s.Reject(id, actor)
+	})
+}
+
+func remediationReviewHandler(review func(id int64, actor string) (*models.Remediation, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid remediation id"))
+			return
+		}
+
+		var r JSONRemediationReview
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		remediation, err := review(id, r.Actor)
+		if err != nil {
+			_ = c.Error(remediationError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, remediation)
+	}
+}
+
+// ApiClaimRemediationHandler godoc
+// @Summary Claim the oldest remediation to preview or to apply, used by the runner
+// @Produce json
+// @Param runner_id query string false "Runner Id, to claim the remediations of the clusters leased to the runner only"
+// @Success 200 {object} models.Remediation
+// @Success 204
+// @Failure 500 {object} map[string]string
+// @Router /remediations/claim [post]
+func ApiClaimRemediationHandler(s services.RemediationsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		remediation, err := s.Claim(c.Query("runner_id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if remediation == nil {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		c.JSON(http.StatusOK, remediation)
+	}
+}
+
+// ApiUpdateRemediationHandler godoc
+// @Summary Store the outcome of a remediation preview or apply run, used by the runner
+// @Accept json
+// @Produce json
+// @Param id path int true "Remediation Id"
+// @Param Body body JSONRemediationResult true "Diff of the changes and run details"
+// @Success 200 {object} JSONRemediationResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /remediations/{id} [put]
+func ApiUpdateRemediationHandler(s services.RemediationsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid remediation id"))
+			return
+		}
+
+		var r JSONRemediationResult
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err = s.Complete(id, &models.RemediationResult{
+			Failed:   r.Failed,
+			Diff:     r.Diff,
+			ExitCode: r.ExitCode,
+			Stderr:   r.Stderr,
+		})
+		if err != nil {
+			_ = c.Error(remediationError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, &r)
+	}
+}
+
+func remediationError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFoundError("could not find the remediation")
+	case errors.Is(err, services.ErrInvalidRemediation):
+		return BadRequestError(err.Error())
+	case errors.Is(err, services.ErrRemediationConflict):
+		return ConflictError(err.Error())
+	default:
+		return err
+	}
+}
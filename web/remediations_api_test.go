@@ -0,0 +1,172 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiCreateClusterRemediationHandler(t *testing.T) {
+	remediationsService := new(services.MockRemediationsService)
+	remediationsService.On("Create", "cluster1", []string{"1.1.1"}, "admin").Return(&models.Remediation{
+		ID:        1,
+		ClusterID: "cluster1",
+		Checks:    []string{"1.1.1"},
+		Status:    models.RemediationPreviewQueued,
+	}, nil)
+	remediationsService.On("Create", "cluster1", []string{"1.2.1"}, "admin").Return(
+		nil, fmt.Errorf("%w: the check 1.2.1 is not remediable", services.ErrInvalidRemediation))
+	remediationsService.On("Create", "cluster2", []string{"1.1.1"}, "admin").Return(
+		nil, fmt.Errorf("%w: the cluster cluster2 has a remediation in progress", services.ErrRemediationConflict))
+
+	deps := setupTestDependencies()
+	deps.remediationsService = remediationsService
+
+	app, err := NewAppWithDeps(setupAdminTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		cluster string
+		checks  []string
+		code    int
+	}{
+		{"cluster1", []string{"1.1.1"}, http.StatusCreated},
+		{"cluster1", []string{"1.2.1"}, http.StatusBadRequest},
+		{"cluster2", []string{"1.1.1"}, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		body, _ := json.Marshal(&JSONRemediationRequest{Checks: tc.checks, RequestedBy: "admin"})
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/clusters/%s/remediations", tc.cluster), bytes.NewBuffer(body))
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, tc.code, resp.Code, tc.checks)
+	}
+
+	remediationsService.AssertExpectations(t)
+}
+
+func TestApiApproveRemediationHandler(t *testing.T) {
+	remediationsService := new(services.MockRemediationsService)
+	remediationsService.On("Approve", int64(1), "admin").Return(&models.Remediation{
+		ID:         1,
+		Status:     models.RemediationApproved,
+		ApprovedBy: "admin",
+	}, nil)
+	remediationsService.On("Reject", int64(2), "admin").Return(
+		nil, fmt.Errorf("%w: the remediation 2 is applied, not pending approval", services.ErrRemediationConflict))
+
+	deps := setupTestDependencies()
+	deps.remediationsService = remediationsService
+
+	app, err := NewAppWithDeps(setupAdminTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(&JSONRemediationReview{Actor: "admin"})
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/remediations/1/approve", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	app.webEngine.ServeHTTP(resp, req)
+
+	var remediation models.Remediation
+	json.Unmarshal(resp.Body.Bytes(), &remediation)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, models.RemediationApproved, remediation.Status)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/remediations/2/reject", bytes.NewBuffer(body))
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
+
+func TestApiReviewRemediationHandlerUnauthorized(t *testing.T) {
+	remediationsService := new(services.MockRemediationsService)
+
+	deps := setupTestDependencies()
+	deps.remediationsService = remediationsService
+
+	app, err := NewAppWithDeps(setupAdminTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(&JSONRemediationReview{Actor: "admin"})
+	for _, token := range []string{"", "wrong-token"} {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/remediations/1/approve", bytes.NewBuffer(body))
+		req.Header.Set("Accept", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	}
+
+	remediationsService.AssertNotCalled(t, "Approve")
+}
+
+func TestApiClaimAndUpdateRemediationHandler(t *testing.T) {
+	remediationsService := new(services.MockRemediationsService)
+	remediationsService.On("Claim", "runner1").Return(&models.Remediation{
+		ID:        1,
+		ClusterID: "cluster1",
+		Checks:    []string{"1.1.1"},
+		Status:    models.RemediationPreviewing,
+	}, nil).Once()
+	remediationsService.On("Claim", "runner1").Return(nil, nil)
+
+	diff := models.RemediationDiff{"node1": {"1.1.1": "-token: 5000\n+token: 30000\n"}}
+	remediationsService.On("Complete", int64(1), &models.RemediationResult{Diff: diff}).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.remediationsService = remediationsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/remediations/claim?runner_id=runner1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	var remediation models.Remediation
+	json.Unmarshal(resp.Body.Bytes(), &remediation)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, int64(1), remediation.ID)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/remediations/claim?runner_id=runner1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	body, _ := json.Marshal(&JSONRemediationResult{Diff: diff})
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/api/remediations/1", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	remediationsService.AssertExpectations(t)
+}
@@ -0,0 +1,48 @@
+package reports
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+var csvHeader = []string{
+	"cluster_id", "cluster_name", "sid", "executed_at", "check_id", "check_group", "check_description",
+	"host", "host_reachable", "result", "waived_result", "message", "remediation",
+}
+
+// RenderCSV writes a row for the result of every check on every host of the clusters in the report
+func RenderCSV(w io.Writer, report *models.Report) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, cluster := range report.Clusters {
+		reachable := make(map[string]bool, len(cluster.Hosts))
+		for _, host := range cluster.Hosts {
+			reachable[host.Name] = host.Reachable
+		}
+
+		for _, check := range cluster.Checks {
+			for _, result := range check.Results {
+				err := writer.Write([]string{
+					cluster.ID, cluster.Name, cluster.SID, formatTime(cluster.ExecutedAt),
+					check.ID, check.Group, check.Description,
+					result.Host, strconv.FormatBool(reachable[result.Host]),
+					result.Result, result.WaivedResult, result.Msg, check.Remediation,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,57 @@
+package reports
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/trento-project/trento/web/markdown"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var htmlTemplate = template.Must(template.New("report.html.tmpl").Funcs(template.FuncMap{
+	"title":      Title,
+	"formatTime": formatTime,
+	"join":       strings.Join,
+	"markdown":   markdown.ToHTML,
+}).ParseFS(templatesFS, "templates/report.html.tmpl"))
+
+// RenderHTML writes the report as a self-contained HTML document, with no external resources
+func RenderHTML(w io.Writer, report *models.Report) error {
+	return htmlTemplate.Execute(w, report)
+}
+
+// Title describes what the report covers
+func Title(report *models.Report) string {
+	switch report.Scope {
+	case models.ReportScopeCluster:
+		if len(report.Clusters) == 1 {
+			return fmt.Sprintf("Compliance report of the cluster %s", report.Clusters[0].Name)
+		}
+		return fmt.Sprintf("Compliance report of the cluster %s", report.Target)
+	case models.ReportScopeTag:
+		return fmt.Sprintf("Compliance report of the clusters tagged %s", report.Target)
+	default:
+		return "Compliance report of the landscape"
+	}
+}
+
+func formatTime(t interface{}) string {
+	switch value := t.(type) {
+	case time.Time:
+		return value.UTC().Format("2006-01-02 15:04:05 UTC")
+	case *time.Time:
+		if value == nil {
+			return ""
+		}
+		return value.UTC().Format("2006-01-02 15:04:05 UTC")
+	}
+
+	return ""
+}
@@ -0,0 +1,271 @@
+package reports
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+const (
+	pdfPageWidth  = 595.28 // A4 in points
+	pdfPageHeight = 841.89
+	pdfMargin     = 50.0
+	pdfFontSize   = 9.0
+	// Helvetica glyphs are about half an em wide on average, enough to wrap the lines without the font metrics
+	pdfCharWidth = 0.5
+)
+
+// pdfDocument is a minimal PDF writer laying out lines of text on A4 pages. It only uses the standard
+// Helvetica fonts, that every PDF reader provides, so the document doesn't need to embed any font
+type pdfDocument struct {
+	title string
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func newPDFDocument(title string) *pdfDocument {
+	d := &pdfDocument{title: title}
+	d.newPage()
+	return d
+}
+
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// text writes a paragraph, wrapping it to the page width
+func (d *pdfDocument) text(s string, size float64, bold bool, indent float64) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	maxChars := int((pdfPageWidth - 2*pdfMargin - indent) / (size * pdfCharWidth))
+	lineHeight := size * 1.4
+
+	for _, paragraph := range strings.Split(s, "\n") {
+		for _, line := range wrapText(paragraph, maxChars) {
+			if d.y-lineHeight < pdfMargin {
+				d.newPage()
+			}
+			d.y -= lineHeight
+			fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+				font, size, pdfMargin+indent, d.y, pdfEscape(line))
+		}
+	}
+}
+
+func (d *pdfDocument) space(height float64) {
+	d.y -= height
+}
+
+// WriteTo writes the document, numbering its pages
+func (d *pdfDocument) WriteTo(w io.Writer) (int64, error) {
+	out := &countingWriter{w: bufio.NewWriter(w)}
+	var offsets []int64
+
+	object := func(body string) {
+		offsets = append(offsets, out.count)
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	fmt.Fprint(out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// the page objects follow the catalog, the page tree, the two fonts and the document information
+	firstPage := 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Trento) >>", pdfEscape(d.title)))
+
+	for i, page := range d.pages {
+		content := page.String() + fmt.Sprintf("BT /F1 8.0 Tf %.2f %.2f Td (%s) Tj ET\n",
+			pdfPageWidth-pdfMargin-60, pdfMargin/2, pdfEscape(fmt.Sprintf("Page %d of %d", i+1, len(d.pages))))
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := out.count
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if out.err != nil {
+		return out.count, out.err
+	}
+
+	return out.count, out.w.Flush()
+}
+
+type countingWriter struct {
+	w     *bufio.Writer
+	count int64
+	err   error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.count += int64(n)
+	c.err = err
+	return n, err
+}
+
+// wrapText splits the text in lines of at most maxChars, breaking them on the blanks when possible
+func wrapText(text string, maxChars int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var line []rune
+
+	for _, word := range words {
+		runes := []rune(word)
+		for len(runes) > maxChars {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			lines = append(lines, string(runes[:maxChars]))
+			runes = runes[maxChars:]
+		}
+
+		switch {
+		case len(line) == 0:
+			line = runes
+		case len(line)+1+len(runes) <= maxChars:
+			line = append(append(line, ' '), runes...)
+		default:
+			lines = append(lines, string(line))
+			line = runes
+		}
+	}
+
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+
+	return lines
+}
+
+// pdfEscape encodes the text as a PDF string in the WinAnsi encoding of the fonts,
+// the characters out of Latin-1 are replaced by a question mark
+func pdfEscape(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteRune(' ')
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune('?')
+		}
+	}
+
+	return b.String()
+}
+
+// RenderPDF writes the report as a PDF document
+func RenderPDF(w io.Writer, report *models.Report) error {
+	d := newPDFDocument(Title(report))
+
+	d.text(Title(report), 16, true, 0)
+	d.text("Generated at "+formatTime(report.GeneratedAt), pdfFontSize, false, 0)
+	d.text(fmt.Sprintf("%d clusters - passing: %d, warning: %d, critical: %d", len(report.Clusters),
+		report.Aggregated.PassingCount, report.Aggregated.WarningCount, report.Aggregated.CriticalCount),
+		pdfFontSize, false, 0)
+
+	for _, cluster := range report.Clusters {
+		d.space(12)
+		d.text(fmt.Sprintf("%s - %s", cluster.Name, cluster.Health), 13, true, 0)
+		d.text("Cluster id: "+cluster.ID, pdfFontSize, false, 0)
+		if cluster.SID != "" {
+			d.text("SID: "+cluster.SID, pdfFontSize, false, 0)
+		}
+		if len(cluster.Tags) > 0 {
+			d.text("Tags: "+strings.Join(cluster.Tags, ", "), pdfFontSize, false, 0)
+		}
+		if cluster.ExecutedAt != nil {
+			d.text("Last checks execution: "+formatTime(cluster.ExecutedAt), pdfFontSize, false, 0)
+		} else {
+			d.text("Last checks execution: never", pdfFontSize, false, 0)
+		}
+
+		if len(cluster.Hosts) > 0 {
+			d.space(6)
+			d.text("Hosts", 11, true, 0)
+			for _, host := range cluster.Hosts {
+				line := fmt.Sprintf("%s: reachable", host.Name)
+				if !host.Reachable {
+					line = fmt.Sprintf("%s: unreachable", host.Name)
+				}
+				if host.Msg != "" {
+					line += " - " + host.Msg
+				}
+				d.text(line, pdfFontSize, false, 10)
+			}
+		}
+
+		if len(cluster.Checks) > 0 {
+			d.space(6)
+			d.text("Checks", 11, true, 0)
+		}
+		for _, check := range cluster.Checks {
+			d.space(4)
+			d.text(fmt.Sprintf("%s [%s] %s", check.ID, check.Group, check.Description), pdfFontSize, true, 10)
+			for _, result := range check.Results {
+				line := fmt.Sprintf("%s: %s", result.Host, result.Result)
+				if result.WaivedResult != "" {
+					line += fmt.Sprintf(" (%s)", result.WaivedResult)
+				}
+				if result.Msg != "" {
+					line += " - " + result.Msg
+				}
+				d.text(line, pdfFontSize, false, 20)
+			}
+			if check.Remediation != "" {
+				d.text("Remediation:", pdfFontSize, true, 20)
+				d.text(check.Remediation, 8, false, 20)
+			}
+		}
+
+		if len(cluster.Waivers) > 0 {
+			d.space(6)
+			d.text("Waivers", 11, true, 0)
+			for _, waiver := range cluster.Waivers {
+				d.text(fmt.Sprintf("%s on %s %s, owned by %s until %s: %s", waiver.CheckID, waiver.Scope,
+					waiver.Target, waiver.Owner, formatTime(waiver.ExpiresAt), waiver.Justification), pdfFontSize, false, 10)
+			}
+		}
+	}
+
+	_, err := d.WriteTo(w)
+	return err
+}
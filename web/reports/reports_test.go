@@ -0,0 +1,197 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+func sampleReport() *models.Report {
+	executedAt := time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	return &models.Report{
+		GeneratedAt: time.Date(2022, 3, 2, 8, 0, 0, 0, time.UTC),
+		Scope:       models.ReportScopeTag,
+		Target:      "production",
+		Aggregated:  &models.AggregatedCheckData{PassingCount: 1, WarningCount: 0, CriticalCount: 1},
+		Clusters: []*models.ClusterReport{
+			{
+				ID:         "cluster1",
+				Name:       "hana_cluster",
+				SID:        "PRD",
+				Tags:       []string{"production"},
+				ExecutedAt: &executedAt,
+				Health:     models.CheckCritical,
+				Aggregated: &models.AggregatedCheckData{PassingCount: 1, CriticalCount: 1},
+				Hosts: []*models.ReportHost{
+					{Name: "host1", Reachable: true},
+					{Name: "host2", Reachable: false, Msg: "ssh: connection refused"},
+				},
+				Checks: []*models.ReportCheck{
+					{
+						ID:          "156F64",
+						Group:       "Corosync",
+						Description: "Corosync `token` timeout is set to `30000`",
+						Remediation: "## Remediation\nAdjust the `token` (timeout)",
+						Results: []*models.ReportCheckResult{
+							{Host: "host1", Result: models.CheckPassing},
+							{Host: "host2", Result: models.CheckCritical, Msg: "token is 5000"},
+						},
+					},
+					{
+						ID:    "845CC9",
+						Group: "Pacemaker",
+						Results: []*models.ReportCheckResult{
+							{
+								Host:         "host1",
+								Result:       models.CheckWaived,
+								WaivedResult: models.CheckWarning,
+								Msg:          "Waived by admin until 2022-04-01 00:00: vendor requirement",
+							},
+						},
+					},
+				},
+				Waivers: []*models.Waiver{
+					{
+						CheckID:       "845CC9",
+						Scope:         models.WaiverScopeCluster,
+						Target:        "cluster1",
+						Owner:         "admin",
+						Justification: "vendor requirement",
+						ExpiresAt:     time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			{
+				ID:         "cluster2",
+				Name:       "never_checked",
+				Health:     models.CheckUndefined,
+				Aggregated: &models.AggregatedCheckData{},
+			},
+		},
+	}
+}
+
+func TestTitle(t *testing.T) {
+	report := sampleReport()
+	assert.Equal(t, "Compliance report of the clusters tagged production", Title(report))
+
+	report.Scope = models.ReportScopeLandscape
+	assert.Equal(t, "Compliance report of the landscape", Title(report))
+
+	report.Scope = models.ReportScopeCluster
+	report.Clusters = report.Clusters[:1]
+	assert.Equal(t, "Compliance report of the cluster hana_cluster", Title(report))
+}
+
+func TestRenderHTML(t *testing.T) {
+	var out bytes.Buffer
+
+	err := RenderHTML(&out, sampleReport())
+	assert.NoError(t, err)
+
+	html := out.String()
+	assert.Contains(t, html, "<title>Compliance report of the clusters tagged production</title>")
+	assert.Contains(t, html, "Generated at 2022-03-02 08:00:00 UTC")
+	assert.Contains(t, html, "Last checks execution:\n    2022-03-01 10:30:00 UTC")
+	assert.Contains(t, html, "ssh: connection refused")
+	assert.Contains(t, html, "<h2 id=\"remediation\">Remediation</h2>")
+	assert.Contains(t, html, "vendor requirement")
+	assert.Contains(t, html, "never_checked")
+	assert.NotContains(t, html, "<link")
+	assert.NotContains(t, html, "<script")
+}
+
+func TestRenderCSV(t *testing.T) {
+	var out bytes.Buffer
+
+	err := RenderCSV(&out, sampleReport())
+	assert.NoError(t, err)
+
+	records, err := csv.NewReader(&out).ReadAll()
+	assert.NoError(t, err)
+
+	expected := [][]string{
+		csvHeader,
+		{
+			"cluster1", "hana_cluster", "PRD", "2022-03-01 10:30:00 UTC", "156F64", "Corosync",
+			"Corosync `token` timeout is set to `30000`", "host1", "true", "passing", "", "",
+			"## Remediation\nAdjust the `token` (timeout)",
+		},
+		{
+			"cluster1", "hana_cluster", "PRD", "2022-03-01 10:30:00 UTC", "156F64", "Corosync",
+			"Corosync `token` timeout is set to `30000`", "host2", "false", "critical", "", "token is 5000",
+			"## Remediation\nAdjust the `token` (timeout)",
+		},
+		{
+			"cluster1", "hana_cluster", "PRD", "2022-03-01 10:30:00 UTC", "845CC9", "Pacemaker", "",
+			"host1", "true", "waived", "warning", "Waived by admin until 2022-04-01 00:00: vendor requirement", "",
+		},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestRenderPDF(t *testing.T) {
+	var out bytes.Buffer
+
+	err := RenderPDF(&out, sampleReport())
+	assert.NoError(t, err)
+
+	pdf := out.Bytes()
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "(Compliance report of the clusters tagged production) Tj")
+	assert.Contains(t, string(pdf), "(Adjust the `token` \\(timeout\\)) Tj")
+	assert.Contains(t, string(pdf), "(host2: unreachable - ssh: connection refused) Tj")
+	assert.Contains(t, string(pdf), "(Page 1 of 1) Tj")
+
+	// every object must be found at the offset in the cross-reference table
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	assert.NotNil(t, startxref)
+	xrefOffset, _ := strconv.Atoi(string(startxref[1]))
+	assert.True(t, bytes.HasPrefix(pdf[xrefOffset:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xrefOffset:], -1)
+	assert.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))))
+	}
+}
+
+func TestRenderPDFPagination(t *testing.T) {
+	report := sampleReport()
+	for i := 0; i < 200; i++ {
+		report.Clusters[0].Checks = append(report.Clusters[0].Checks, &models.ReportCheck{
+			ID:          fmt.Sprintf("CHECK%d", i),
+			Description: strings.Repeat("a long description ", 20),
+		})
+	}
+
+	var out bytes.Buffer
+	err := RenderPDF(&out, report)
+	assert.NoError(t, err)
+
+	assert.Regexp(t, `/Count ([2-9]|\d\d+) >>`, out.String())
+	assert.Contains(t, out.String(), "(Page 2 of ")
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{""}, wrapText("", 10))
+	assert.Equal(t, []string{"a short", "text to", "wrap"}, wrapText("a short text to wrap", 8))
+	assert.Equal(t, []string{"a", "verylongwo", "rd"}, wrapText("a verylongword", 10))
+}
+
+func TestPDFEscape(t *testing.T) {
+	assert.Equal(t, "\\(a\\) \\\\ b", pdfEscape("(a) \\ b"))
+	assert.Equal(t, "caf\\351 ?", pdfEscape("café €"))
+}
@@ -0,0 +1,95 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/reports"
+	"github.com/trento-project/trento/web/services"
+)
+
+type reportFormat struct {
+	contentType string
+	render      func(io.Writer, *models.Report) error
+}
+
+var reportFormats = map[string]reportFormat{
+	"html": {"text/html; charset=utf-8", reports.RenderHTML},
+	"csv":  {"text/csv; charset=utf-8", reports.RenderCSV},
+	"pdf":  {"application/pdf", reports.RenderPDF},
+}
+
+var reportFilenameUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// ApiReportHandler godoc
+// @Summary Generate the compliance report of a cluster, of the clusters with a tag or of the whole landscape
+// @Produce json,html,csv,pdf
+// @Param cluster_id query string false "Cluster Id"
+// @Param tag query string false "Tag of the clusters"
+// @Param format query string false "Report format: json (default), html, csv or pdf"
+// @Success 200 {object} models.Report
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reports [get]
+func ApiReportHandler(s services.ReportsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope, target := models.ReportScopeLandscape, ""
+		if clusterID := c.Query("cluster_id"); clusterID != "" {
+			scope, target = models.ReportScopeCluster, clusterID
+		} else if tag := c.Query("tag"); tag != "" {
+			scope, target = models.ReportScopeTag, tag
+		}
+
+		formatName := c.DefaultQuery("format", "json")
+		format, ok := reportFormats[formatName]
+		if !ok && formatName != "json" {
+			_ = c.Error(BadRequestError(fmt.Sprintf("unknown report format: %s", formatName)))
+			return
+		}
+
+		report, err := s.GetReport(scope, target)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			_ = c.Error(NotFoundError("could not find the cluster"))
+			return
+		case errors.Is(err, services.ErrInvalidReportScope):
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		case err != nil:
+			_ = c.Error(err)
+			return
+		}
+
+		if formatName == "json" {
+			c.JSON(http.StatusOK, report)
+			return
+		}
+
+		// render the whole report first, so that a failure can still be reported as an error
+		var body bytes.Buffer
+		if err := format.render(&body, report); err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", reportFilename(report, formatName)))
+		c.Data(http.StatusOK, format.contentType, body.Bytes())
+	}
+}
+
+func reportFilename(report *models.Report, extension string) string {
+	name := "compliance-report-" + report.Scope
+	if report.Target != "" {
+		name += "-" + reportFilenameUnsafeChars.ReplaceAllString(report.Target, "_")
+	}
+
+	return fmt.Sprintf("%s-%s.%s", name, report.GeneratedAt.UTC().Format("20060102-150405"), extension)
+}
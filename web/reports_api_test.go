@@ -0,0 +1,107 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiReportHandler(t *testing.T) {
+	newReport := func(scope, target string) *models.Report {
+		return &models.Report{
+			GeneratedAt: time.Date(2022, 3, 2, 8, 0, 0, 0, time.UTC),
+			Scope:       scope,
+			Target:      target,
+			Aggregated:  &models.AggregatedCheckData{PassingCount: 1},
+			Clusters: []*models.ClusterReport{
+				{
+					ID:         "cluster1",
+					Name:       "hana_cluster",
+					Health:     models.CheckPassing,
+					Aggregated: &models.AggregatedCheckData{PassingCount: 1},
+					Hosts:      []*models.ReportHost{{Name: "host1", Reachable: true}},
+					Checks: []*models.ReportCheck{
+						{
+							ID:      "156F64",
+							Results: []*models.ReportCheckResult{{Host: "host1", Result: models.CheckPassing}},
+						},
+					},
+				},
+			},
+		}
+	}
+	landscapeReport := newReport(models.ReportScopeLandscape, "")
+
+	mockReportsService := new(services.MockReportsService)
+	mockReportsService.On("GetReport", models.ReportScopeLandscape, "").Return(landscapeReport, nil)
+	mockReportsService.On("GetReport", models.ReportScopeCluster, "cluster1").Return(
+		newReport(models.ReportScopeCluster, "cluster1"), nil)
+	mockReportsService.On("GetReport", models.ReportScopeCluster, "other").Return(nil, gorm.ErrRecordNotFound)
+	mockReportsService.On("GetReport", models.ReportScopeTag, "sap/prod").Return(
+		newReport(models.ReportScopeTag, "sap/prod"), nil)
+
+	deps := setupTestDependencies()
+	deps.reportsService = mockReportsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/reports", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedReport, _ := json.Marshal(landscapeReport)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedReport), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/reports?cluster_id=cluster1&format=html", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="compliance-report-cluster-cluster1-20220302-080000.html"`,
+		resp.Header().Get("Content-Disposition"))
+	assert.Contains(t, resp.Body.String(), "Compliance report of the cluster hana_cluster")
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/reports?tag=sap/prod&format=csv", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="compliance-report-tag-sap_prod-20220302-080000.csv"`,
+		resp.Header().Get("Content-Disposition"))
+	assert.True(t, strings.HasPrefix(resp.Body.String(), "cluster_id,cluster_name"))
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/reports?format=pdf", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/pdf", resp.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(resp.Body.String(), "%PDF-"))
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/reports?format=docx", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/reports?cluster_id=other", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
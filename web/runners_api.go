@@ -0,0 +1,107 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// JSONRunnerHeartbeat is the affinity of the runner, sent with every heartbeat
+type JSONRunnerHeartbeat struct {
+	Tags       []string `json:"tags"`
+	ClusterIDs []string `json:"cluster_ids"`
+}
+
+// JSONRunnerLeases are the clusters leased to the runner, valid for LeaseDuration seconds unless renewed
+type JSONRunnerLeases struct {
+	Leases        []*models.RunnerLease `json:"leases"`
+	LeaseDuration int                   `json:"lease_duration"`
+}
+
+// ApiListRunnersHandler godoc
+// @Summary Retrieve the registered runners and the clusters leased to each one
+// @Produce json
+// @Success 200 {array} models.Runner
+// @Failure 500 {object} map[string]string
+// @Router /runners [get]
+func ApiListRunnersHandler(s services.RunnersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runners, err := s.GetAll()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, runners)
+	}
+}
+
+// ApiRunnerHeartbeatHandler godoc
+// @Summary Register a runner as alive, renewing and acquiring its checks executions leases
+// @Accept json
+// @Produce json
+// @Param id path string true "Runner Id"
+// @Param Body body JSONRunnerHeartbeat true "Runner affinity, no tags nor cluster ids to serve any cluster"
+// @Success 200 {object} JSONRunnerLeases
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /runners/{id}/heartbeat [post]
+func ApiRunnerHeartbeatHandler(s services.RunnersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONRunnerHeartbeat
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		leases, err := s.Heartbeat(&models.Runner{
+			ID:         c.Param("id"),
+			Tags:       r.Tags,
+			ClusterIDs: r.ClusterIDs,
+		})
+		if err != nil {
+			_ = c.Error(runnerError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, JSONRunnerLeases{
+			Leases:        leases,
+			LeaseDuration: int(services.RunnerLeaseDuration.Seconds()),
+		})
+	}
+}
+
+// ApiReleaseRunnerLeasesHandler godoc
+// @Summary Release the leases of a stopping runner, so the other runners take over its clusters
+// @Param id path string true "Runner Id"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /runners/{id}/leases [delete]
+func ApiReleaseRunnerLeasesHandler(s services.RunnersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.Release(c.Param("id")); err != nil {
+			_ = c.Error(runnerError(err))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func runnerError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFoundError("could not find the runner")
+	case errors.Is(err, services.ErrInvalidRunner):
+		return BadRequestError(err.Error())
+	default:
+		return err
+	}
+}
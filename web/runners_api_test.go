@@ -0,0 +1,85 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiRunnersHandlers(t *testing.T) {
+	acquiredAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	lease := &models.RunnerLease{
+		ClusterID:  "cluster1",
+		RunnerID:   "runner1",
+		AcquiredAt: acquiredAt,
+		ExpiresAt:  acquiredAt.Add(services.RunnerLeaseDuration),
+	}
+	runner := &models.Runner{
+		ID:          "runner1",
+		Tags:        []string{"dc1"},
+		ClusterIDs:  []string{},
+		HeartbeatAt: acquiredAt,
+		Alive:       true,
+		Leases:      []*models.RunnerLease{lease},
+	}
+
+	mockRunnersService := new(services.MockRunnersService)
+	mockRunnersService.On("GetAll").Return([]*models.Runner{runner}, nil)
+	mockRunnersService.On("Heartbeat", &models.Runner{ID: "runner1", Tags: []string{"dc1"}}).Return(
+		[]*models.RunnerLease{lease}, nil)
+	mockRunnersService.On("Release", "runner1").Return(nil)
+	mockRunnersService.On("Release", "unknown").Return(gorm.ErrRecordNotFound)
+
+	deps := setupTestDependencies()
+	deps.runnersService = mockRunnersService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/runners", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedRunners, _ := json.Marshal([]*models.Runner{runner})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedRunners), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/runners/runner1/heartbeat", bytes.NewBufferString(`{"tags":["dc1"]}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedLeases, _ := json.Marshal(JSONRunnerLeases{Leases: []*models.RunnerLease{lease}, LeaseDuration: 90})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedLeases), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/runners/runner1/heartbeat", bytes.NewBufferString(`{"tags":"dc1"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/runners/runner1/leases", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/runners/unknown/leases", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	mockRunnersService.AssertExpectations(t)
+}
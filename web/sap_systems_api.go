@@ -0,0 +1,109 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiListSAPSystemsHandler godoc
+// @Summary Retrieve the SAP systems, optionally filtered
+// @Produce json
+// @Param sids query []string false "Filter by SIDs" collectionFormat(multi)
+// @Param tags query []string false "Filter by tags" collectionFormat(multi)
+// @Param page query int false "Page number, all the SAP systems are returned when no page is given"
+// @Param per_page query int false "SAP systems per page"
+// @Success 200 {array} models.SAPSystem
+// @Failure 500 {object} map[string]string
+// @Router /sapsystems [get]
+func ApiListSAPSystemsHandler(s services.SAPSystemsService) gin.HandlerFunc {
+	return sapSystemsListHandler(s, models.SAPSystemTypeApplication)
+}
+
+// ApiListDatabasesHandler godoc
+// @Summary Retrieve the HANA databases, optionally filtered
+// @Produce json
+// @Param sids query []string false "Filter by SIDs" collectionFormat(multi)
+// @Param tags query []string false "Filter by tags" collectionFormat(multi)
+// @Param page query int false "Page number, all the databases are returned when no page is given"
+// @Param per_page query int false "Databases per page"
+// @Success 200 {array} models.SAPSystem
+// @Failure 500 {object} map[string]string
+// @Router /databases [get]
+func ApiListDatabasesHandler(s services.SAPSystemsService) gin.HandlerFunc {
+	return sapSystemsListHandler(s, models.SAPSystemTypeDatabase)
+}
+
+func sapSystemsListHandler(s services.SAPSystemsService, systemType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+
+		filter := &services.SAPSystemFilter{
+			SIDs: query["sids"],
+			Tags: query["tags"],
+		}
+
+		var sapSystems models.SAPSystemList
+		var err error
+
+		if systemType == models.SAPSystemTypeDatabase {
+			sapSystems, err = s.GetAllDatabases(filter, queryOptionalPage(c))
+		} else {
+			sapSystems, err = s.GetAllApplications(filter, queryOptionalPage(c))
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if sapSystems == nil {
+			sapSystems = models.SAPSystemList{}
+		}
+
+		c.JSON(http.StatusOK, sapSystems)
+	}
+}
+
+// ApiGetSAPSystemHandler godoc
+// @Summary Retrieve a SAP system
+// @Produce json
+// @Param id path string true "SAP system Id"
+// @Success 200 {object} models.SAPSystem
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sapsystems/{id} [get]
+func ApiGetSAPSystemHandler(s services.SAPSystemsService) gin.HandlerFunc {
+	return sapSystemHandler(s, models.SAPSystemTypeApplication)
+}
+
+// ApiGetDatabaseHandler godoc
+// @Summary Retrieve a HANA database
+// @Produce json
+// @Param id path string true "Database Id"
+// @Success 200 {object} models.SAPSystem
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /databases/{id} [get]
+func ApiGetDatabaseHandler(s services.SAPSystemsService) gin.HandlerFunc {
+	return sapSystemHandler(s, models.SAPSystemTypeDatabase)
+}
+
+func sapSystemHandler(s services.SAPSystemsService, systemType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sapSystem, err := s.GetByID(c.Param("id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if sapSystem == nil || sapSystem.Type != systemType {
+			_ = c.Error(NotFoundError("could not find system"))
+			return
+		}
+
+		c.JSON(http.StatusOK, sapSystem)
+	}
+}
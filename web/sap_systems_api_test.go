@@ -0,0 +1,89 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiListSAPSystemsHandler(t *testing.T) {
+	sapSystemsService := new(services.MockSAPSystemsService)
+	sapSystemsService.On("GetAllApplications", &services.SAPSystemFilter{SIDs: []string{"HA1"}}, (*services.Page)(nil)).Return(models.SAPSystemList{
+		{ID: "sapsystem1", SID: "HA1", Type: models.SAPSystemTypeApplication},
+	}, nil)
+	sapSystemsService.On("GetAllDatabases", &services.SAPSystemFilter{}, &services.Page{Number: 1, Size: 50}).Return(models.SAPSystemList{
+		{ID: "database1", SID: "PRD", Type: models.SAPSystemTypeDatabase},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.sapSystemsService = sapSystemsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		url string
+		id  string
+	}{
+		{"/api/sapsystems?sids=HA1", "sapsystem1"},
+		{"/api/databases?page=1", "database1"},
+	}
+
+	for _, tc := range cases {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", tc.url, nil)
+		app.webEngine.ServeHTTP(resp, req)
+
+		var sapSystems models.SAPSystemList
+		json.Unmarshal(resp.Body.Bytes(), &sapSystems)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Len(t, sapSystems, 1)
+		assert.Equal(t, tc.id, sapSystems[0].ID)
+	}
+
+	sapSystemsService.AssertExpectations(t)
+}
+
+func TestApiGetSAPSystemHandler(t *testing.T) {
+	sapSystemsService := new(services.MockSAPSystemsService)
+	sapSystemsService.On("GetByID", "sapsystem1").Return(&models.SAPSystem{
+		ID: "sapsystem1", SID: "HA1", Type: models.SAPSystemTypeApplication,
+	}, nil)
+	sapSystemsService.On("GetByID", "unknown").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.sapSystemsService = sapSystemsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		url  string
+		code int
+	}{
+		{"/api/sapsystems/sapsystem1", http.StatusOK},
+		// an application is not a database
+		{"/api/databases/sapsystem1", http.StatusNotFound},
+		{"/api/sapsystems/unknown", http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", tc.url, nil)
+		req.Header.Set("Accept", "application/json")
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, tc.code, resp.Code, tc.url)
+	}
+}
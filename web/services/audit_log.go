@@ -0,0 +1,51 @@
+package services
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=AuditLogService --inpackage --filename=audit_log_mock.go
+
+type AuditLogService interface {
+	GetAll(page *Page) ([]*models.AuditLogEntry, error)
+}
+
+type auditLogService struct {
+	db *gorm.DB
+}
+
+func NewAuditLogService(db *gorm.DB) *auditLogService {
+	return &auditLogService{db: db}
+}
+
+// GetAll returns the audit log, the most recent entries first
+func (s *auditLogService) GetAll(page *Page) ([]*models.AuditLogEntry, error) {
+	var entries []*entities.AuditLogEntry
+
+	err := s.db.Scopes(Paginate(page)).
+		Order("id desc").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.AuditLogEntry{}
+	for _, entry := range entries {
+		result = append(result, entry.ToModel())
+	}
+
+	return result, nil
+}
+
+// recordAuditLogEntry appends an entry to the audit log, within the transaction of the audited change
+func recordAuditLogEntry(tx *gorm.DB, action string, resource string, actor string, message string) error {
+	return tx.Create(&entities.AuditLogEntry{
+		Action:   action,
+		Resource: resource,
+		Actor:    actor,
+		Message:  message,
+	}).Error
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var ErrInvalidSelectedChecks = errors.New("invalid selected checks")
+
+//go:generate mockery --name=CheckTargetsService --inpackage --filename=check_targets_mock.go
+
+// CheckTargetsService gathers the checks settings of the clusters, the standalone hosts and the SAP systems
+type CheckTargetsService interface {
+	GetAllSettings() (models.ClustersSettings, error)
+	GetSettingsByID(id string) (*models.ClusterSettings, error)
+	GetTargetType(id string) (string, error)
+	ValidateSelectedChecks(targetType string, checkIDs []string) error
+	GetNotApplicableChecks(id string) ([]string, error)
+}
+
+type checkTargetsService struct {
+	db              *gorm.DB
+	checksService   ChecksService
+	clustersService ClustersService
+}
+
+func NewCheckTargetsService(db *gorm.DB, checksService ChecksService, clustersService ClustersService) *checkTargetsService {
+	return &checkTargetsService{
+		db:              db,
+		checksService:   checksService,
+		clustersService: clustersService,
+	}
+}
+
+// GetAllSettings returns the settings of all the clusters, plus the ones of the standalone hosts
+// and SAP systems having selected checks, the other ones have nothing to run
+func (s *checkTargetsService) GetAllSettings() (models.ClustersSettings, error) {
+	settings, err := s.clustersService.GetAllClustersSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := getTargetsWithSelectedChecks(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := s.getStandaloneHosts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, host := range hosts {
+		if !selected[host.AgentID] {
+			continue
+		}
+
+		hostSettings, err := s.loadHostSettings(host)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, hostSettings)
+	}
+
+	sapSystemIDs, err := s.getSAPSystemIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range sapSystemIDs {
+		if !selected[id] {
+			continue
+		}
+
+		sapSystemSettings, err := s.loadSAPSystemSettings(id)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, sapSystemSettings)
+	}
+
+	return settings, nil
+}
+
+// GetSettingsByID returns the settings of a cluster, a standalone host or a SAP system, nil when there is no such target
+func (s *checkTargetsService) GetSettingsByID(id string) (*models.ClusterSettings, error) {
+	targetType, err := s.GetTargetType(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch targetType {
+	case models.CheckTargetCluster:
+		return s.clustersService.GetClusterSettingsByID(id)
+	case models.CheckTargetHost:
+		var host entities.Host
+		if err := s.db.Preload("Tags").Where("agent_id = ?", id).First(&host).Error; err != nil {
+			return nil, err
+		}
+		return s.loadHostSettings(&host)
+	case models.CheckTargetSAPSystem:
+		return s.loadSAPSystemSettings(id)
+	default:
+		return nil, nil
+	}
+}
+
+// GetTargetType tells what kind of checks target the id belongs to, an empty string is returned when it is none.
+// The hosts of a cluster are not targets by themselves, they are checked with their cluster
+func (s *checkTargetsService) GetTargetType(id string) (string, error) {
+	var count int64
+
+	if err := s.db.Model(&entities.Cluster{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count > 0 {
+		return models.CheckTargetCluster, nil
+	}
+
+	err := s.db.Model(&entities.Host{}).
+		Where("agent_id = ?", id).
+		Scopes(standaloneHosts).
+		Count(&count).Error
+	if err != nil {
+		return "", err
+	}
+	if count > 0 {
+		return models.CheckTargetHost, nil
+	}
+
+	if err := s.db.Model(&entities.SAPSystemInstance{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count > 0 {
+		return models.CheckTargetSAPSystem, nil
+	}
+
+	return "", nil
+}
+
+// ValidateSelectedChecks refuses the checks not applying to the target type, the checks missing
+// from the catalog are accepted, they are reported as removed
+func (s *checkTargetsService) ValidateSelectedChecks(targetType string, checkIDs []string) error {
+	catalog, err := s.checksService.GetChecksCatalog()
+	if err != nil {
+		return err
+	}
+
+	checks := make(map[string]*models.Check, len(catalog))
+	for _, check := range catalog {
+		checks[check.ID] = check
+	}
+
+	for _, id := range checkIDs {
+		if check, ok := checks[id]; ok && !check.AppliesTo(targetType) {
+			return fmt.Errorf("%w: the check %s does not apply to the %s targets", ErrInvalidSelectedChecks, id, targetType)
+		}
+	}
+
+	return nil
+}
+
+// GetNotApplicableChecks returns the ids of the catalog checks not applying to the environment of the target,
+// nil when there is no such target
+func (s *checkTargetsService) GetNotApplicableChecks(id string) ([]string, error) {
+	env, err := s.getEnvironment(id)
+	if err != nil || env == nil {
+		return nil, err
+	}
+
+	catalog, err := s.checksService.GetChecksCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	notApplicable := []string{}
+	for _, check := range catalog {
+		if !check.IsApplicable(env) {
+			notApplicable = append(notApplicable, check.ID)
+		}
+	}
+
+	return notApplicable, nil
+}
+
+func (s *checkTargetsService) getEnvironment(id string) (*models.CheckEnvironment, error) {
+	targetType, err := s.GetTargetType(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch targetType {
+	case models.CheckTargetCluster:
+		var cluster entities.Cluster
+		if err := s.db.Preload("Hosts").Where("id = ?", id).First(&cluster).Error; err != nil {
+			return nil, err
+		}
+		return getCheckTargetEnvironment(s.db, &cluster, cluster.Hosts)
+	case models.CheckTargetHost:
+		var host entities.Host
+		if err := s.db.Where("agent_id = ?", id).First(&host).Error; err != nil {
+			return nil, err
+		}
+		return getCheckTargetEnvironment(s.db, nil, []*entities.Host{&host})
+	case models.CheckTargetSAPSystem:
+		hosts, err := s.getSAPSystemHosts(id)
+		if err != nil {
+			return nil, err
+		}
+		return getCheckTargetEnvironment(s.db, nil, hosts)
+	default:
+		return nil, nil
+	}
+}
+
+func (s *checkTargetsService) loadHostSettings(host *entities.Host) (*models.ClusterSettings, error) {
+	var tags []string
+	for _, tag := range host.Tags {
+		tags = append(tags, tag.Value)
+	}
+
+	hosts := []*entities.Host{host}
+
+	env, err := getCheckTargetEnvironment(s.db, nil, hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadCheckTargetSettings(s.checksService, host.AgentID, models.CheckTargetHost, hosts, tags, env)
+}
+
+// loadSAPSystemSettings returns the settings of a SAP system, checked on the hosts running its instances
+func (s *checkTargetsService) loadSAPSystemSettings(id string) (*models.ClusterSettings, error) {
+	hosts, err := s.getSAPSystemHosts(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	err = s.db.Model(&models.Tag{}).
+		Where("resource_id = ? AND resource_type IN ?", id,
+			[]string{models.TagSAPSystemResourceType, models.TagDatabaseResourceType}).
+		Distinct().
+		Pluck("value", &tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := getCheckTargetEnvironment(s.db, nil, hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadCheckTargetSettings(s.checksService, id, models.CheckTargetSAPSystem, hosts, tags, env)
+}
+
+// getSAPSystemHosts returns the hosts running the instances of a SAP system
+func (s *checkTargetsService) getSAPSystemHosts(id string) ([]*entities.Host, error) {
+	var instances []*entities.SAPSystemInstance
+
+	err := s.db.Preload("Host").Where("id = ?", id).Order("agent_id").Find(&instances).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []*entities.Host
+	seen := make(map[string]bool)
+	for _, instance := range instances {
+		if instance.Host == nil || seen[instance.AgentID] {
+			continue
+		}
+		seen[instance.AgentID] = true
+		hosts = append(hosts, instance.Host)
+	}
+
+	return hosts, nil
+}
+
+func (s *checkTargetsService) getStandaloneHosts() ([]*entities.Host, error) {
+	var hosts []*entities.Host
+
+	err := s.db.Preload("Tags").Scopes(standaloneHosts).Order("agent_id").Find(&hosts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return hosts, nil
+}
+
+func (s *checkTargetsService) getSAPSystemIDs() ([]string, error) {
+	var ids []string
+
+	err := s.db.Model(&entities.SAPSystemInstance{}).Distinct().Order("id").Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func standaloneHosts(db *gorm.DB) *gorm.DB {
+	return db.Where("(cluster_id = '' OR cluster_id IS NULL)")
+}
+
+// getTargetsWithSelectedChecks returns the ids of the targets with at least a selected check
+func getTargetsWithSelectedChecks(db *gorm.DB) (map[string]bool, error) {
+	var selectedChecks []*models.SelectedChecks
+	if err := db.Find(&selectedChecks).Error; err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool)
+	for _, s := range selectedChecks {
+		if len(s.SelectedChecks) > 0 {
+			selected[s.ID] = true
+		}
+	}
+
+	return selected, nil
+}
+
+// getCheckTargetsTags maps the id of every checks target to its tags: all the clusters,
+// and the standalone hosts and SAP systems having selected checks
+func getCheckTargetsTags(db *gorm.DB) (map[string][]string, error) {
+	targetTags := make(map[string][]string)
+
+	var clusterIDs []string
+	if err := db.Model(&entities.Cluster{}).Pluck("id", &clusterIDs).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range clusterIDs {
+		targetTags[id] = []string{}
+	}
+
+	selected, err := getTargetsWithSelectedChecks(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selected) > 0 {
+		ids := make([]string, 0, len(selected))
+		for id := range selected {
+			ids = append(ids, id)
+		}
+
+		var hostIDs []string
+		err := db.Model(&entities.Host{}).Scopes(standaloneHosts).Where("agent_id IN ?", ids).Pluck("agent_id", &hostIDs).Error
+		if err != nil {
+			return nil, err
+		}
+
+		var sapSystemIDs []string
+		err = db.Model(&entities.SAPSystemInstance{}).Distinct().Where("id IN ?", ids).Pluck("id", &sapSystemIDs).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range append(hostIDs, sapSystemIDs...) {
+			targetTags[id] = []string{}
+		}
+	}
+
+	var tags []*models.Tag
+	if err := db.Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if _, ok := targetTags[tag.ResourceID]; ok && !internal.Contains(targetTags[tag.ResourceID], tag.Value) {
+			targetTags[tag.ResourceID] = append(targetTags[tag.ResourceID], tag.Value)
+		}
+	}
+
+	return targetTags, nil
+}
+
+// getCheckTargetEnvironment describes the environment of a checks target from its hosts and, for the clusters,
+// the cluster type and fencing agent discovered
+func getCheckTargetEnvironment(db *gorm.DB, cluster *entities.Cluster, hosts []*entities.Host) (*models.CheckEnvironment, error) {
+	env := &models.CheckEnvironment{}
+
+	var agentIDs []string
+	for _, host := range hosts {
+		agentIDs = append(agentIDs, host.AgentID)
+		if host.CloudProvider != "" && !internal.Contains(env.Providers, host.CloudProvider) {
+			env.Providers = append(env.Providers, host.CloudProvider)
+		}
+	}
+
+	if len(agentIDs) > 0 {
+		err := db.Model(&entities.HostTelemetry{}).
+			Where("agent_id IN ? AND sles_version != ''", agentIDs).
+			Distinct().
+			Order("sles_version").
+			Pluck("sles_version", &env.OSVersions).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cluster != nil {
+		env.ClusterType = cluster.ClusterType
+
+		if len(cluster.Details) > 0 {
+			var details entities.HANAClusterDetails
+			if err := json.Unmarshal(cluster.Details, &details); err != nil {
+				return nil, err
+			}
+			env.FencingType = details.FencingType
+		}
+	}
+
+	return env, nil
+}
+
+// splitApplicableChecks sets apart the selected checks not applying to the environment,
+// the checks missing from the catalog are kept as selected
+func splitApplicableChecks(
+	checksService ChecksService, selectedChecks []string, env *models.CheckEnvironment,
+) ([]string, []string, error) {
+	if len(selectedChecks) == 0 {
+		return selectedChecks, nil, nil
+	}
+
+	catalog, err := checksService.GetChecksCatalog()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notApplicable := make(map[string]bool)
+	for _, check := range catalog {
+		if !check.IsApplicable(env) {
+			notApplicable[check.ID] = true
+		}
+	}
+
+	applicableChecks := []string{}
+	var notApplicableChecks []string
+	for _, id := range selectedChecks {
+		if notApplicable[id] {
+			notApplicableChecks = append(notApplicableChecks, id)
+			continue
+		}
+		applicableChecks = append(applicableChecks, id)
+	}
+
+	return applicableChecks, notApplicableChecks, nil
+}
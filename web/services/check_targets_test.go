@@ -0,0 +1,203 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+type CheckTargetsServiceTestSuite struct {
+	suite.Suite
+	db                  *gorm.DB
+	tx                  *gorm.DB
+	checksService       *MockChecksService
+	clustersService     *MockClustersService
+	checkTargetsService *checkTargetsService
+}
+
+func TestCheckTargetsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CheckTargetsServiceTestSuite))
+}
+
+func (suite *CheckTargetsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Cluster{}, entities.Host{}, entities.SAPSystemInstance{}, models.Tag{}, models.SelectedChecks{}, entities.HostTelemetry{})
+}
+
+func (suite *CheckTargetsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Cluster{}, entities.Host{}, entities.SAPSystemInstance{}, models.Tag{}, models.SelectedChecks{}, entities.HostTelemetry{})
+}
+
+func (suite *CheckTargetsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.checksService = new(MockChecksService)
+	suite.clustersService = new(MockClustersService)
+	suite.checkTargetsService = NewCheckTargetsService(suite.tx, suite.checksService, suite.clustersService)
+
+	suite.tx.Create(&entities.Cluster{ID: "cluster1"})
+	suite.tx.Create(&entities.Host{AgentID: "node1", Name: "node1", ClusterID: "cluster1"})
+	suite.tx.Create(&entities.Host{AgentID: "host1", Name: "host1", SSHAddress: "10.74.2.10", CloudProvider: "aws"})
+	suite.tx.Create(&entities.Host{AgentID: "host2", Name: "host2", SSHAddress: "10.74.2.11"})
+	suite.tx.Create(&entities.Host{AgentID: "host3", Name: "host3"})
+	suite.tx.Create(&entities.SAPSystemInstance{ID: "sapsystem1", AgentID: "host2", InstanceNumber: "00"})
+	suite.tx.Create(&entities.SAPSystemInstance{ID: "sapsystem1", AgentID: "host2", InstanceNumber: "01"})
+	suite.tx.Create(&entities.SAPSystemInstance{ID: "sapsystem2", AgentID: "host3", InstanceNumber: "00"})
+	suite.tx.Create(&entities.HostTelemetry{AgentID: "host2", SLESVersion: "12.5"})
+	suite.tx.Create(&models.Tag{Value: "tag1", ResourceID: "host1", ResourceType: models.TagHostResourceType})
+	suite.tx.Create(&models.Tag{Value: "tag2", ResourceID: "sapsystem1", ResourceType: models.TagSAPSystemResourceType})
+	suite.tx.Create(&models.SelectedChecks{ID: "host1", SelectedChecks: []string{"A"}})
+	suite.tx.Create(&models.SelectedChecks{ID: "sapsystem1", SelectedChecks: []string{"B"}})
+	suite.tx.Create(&models.SelectedChecks{ID: "sapsystem2", SelectedChecks: []string{}})
+}
+
+func (suite *CheckTargetsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *CheckTargetsServiceTestSuite) mockSettings(id string, selectedChecks []string, tags []string) {
+	suite.checksService.On("GetSelectedChecksById", id).Return(models.SelectedChecks{
+		ID:             id,
+		SelectedChecks: selectedChecks,
+	}, nil)
+	suite.checksService.On("GetConnectionSettingsById", id).Return(map[string]models.ConnectionSettings{}, nil)
+	suite.checksService.On("GetClusterCheckParameters", id, tags).Return(map[string]string{}, nil)
+}
+
+func (suite *CheckTargetsServiceTestSuite) TestCheckTargetsService_GetAllSettings() {
+	suite.clustersService.On("GetAllClustersSettings").Return(models.ClustersSettings{
+		{ID: "cluster1", TargetType: models.CheckTargetCluster},
+	}, nil)
+	suite.mockSettings("host1", []string{"A"}, []string{"tag1"})
+	suite.mockSettings("sapsystem1", []string{"B"}, []string{"tag2"})
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{{ID: "A"}, {ID: "B"}}, nil)
+
+	settings, err := suite.checkTargetsService.GetAllSettings()
+	suite.NoError(err)
+
+	suite.EqualValues(models.ClustersSettings{
+		{
+			ID:         "cluster1",
+			TargetType: models.CheckTargetCluster,
+		},
+		{
+			ID:             "host1",
+			TargetType:     models.CheckTargetHost,
+			SelectedChecks: []string{"A"},
+			Hosts: []*models.HostConnection{
+				{Name: "host1", Address: "10.74.2.10", User: "root"},
+			},
+			CheckParameters: map[string]string{},
+		},
+		{
+			ID:             "sapsystem1",
+			TargetType:     models.CheckTargetSAPSystem,
+			SelectedChecks: []string{"B"},
+			Hosts: []*models.HostConnection{
+				{Name: "host2", Address: "10.74.2.11", User: "root"},
+			},
+			CheckParameters: map[string]string{},
+		},
+	}, settings)
+}
+
+func (suite *CheckTargetsServiceTestSuite) TestCheckTargetsService_GetSettingsByID() {
+	suite.clustersService.On("GetClusterSettingsByID", "cluster1").Return(&models.ClusterSettings{
+		ID:         "cluster1",
+		TargetType: models.CheckTargetCluster,
+	}, nil)
+	suite.mockSettings("host3", []string{}, nil)
+
+	settings, err := suite.checkTargetsService.GetSettingsByID("cluster1")
+	suite.NoError(err)
+	suite.Equal(models.CheckTargetCluster, settings.TargetType)
+
+	settings, err = suite.checkTargetsService.GetSettingsByID("host3")
+	suite.NoError(err)
+	suite.Equal(models.CheckTargetHost, settings.TargetType)
+	suite.Len(settings.Hosts, 1)
+
+	settings, err = suite.checkTargetsService.GetSettingsByID("node1")
+	suite.NoError(err)
+	suite.Nil(settings)
+}
+
+func (suite *CheckTargetsServiceTestSuite) TestCheckTargetsService_GetTargetType() {
+	for id, expected := range map[string]string{
+		"cluster1":   models.CheckTargetCluster,
+		"host1":      models.CheckTargetHost,
+		"node1":      "",
+		"sapsystem1": models.CheckTargetSAPSystem,
+		"unknown":    "",
+	} {
+		targetType, err := suite.checkTargetsService.GetTargetType(id)
+		suite.NoError(err)
+		suite.Equal(expected, targetType, id)
+	}
+}
+
+func (suite *CheckTargetsServiceTestSuite) TestCheckTargetsService_ValidateSelectedChecks() {
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{
+		{ID: "A"},
+		{ID: "B", Targets: []string{models.CheckTargetCluster, models.CheckTargetHost}},
+	}, nil)
+
+	suite.NoError(suite.checkTargetsService.ValidateSelectedChecks(models.CheckTargetCluster, []string{"A", "B"}))
+	suite.NoError(suite.checkTargetsService.ValidateSelectedChecks(models.CheckTargetHost, []string{"B", "removed"}))
+
+	err := suite.checkTargetsService.ValidateSelectedChecks(models.CheckTargetSAPSystem, []string{"B"})
+	suite.ErrorIs(err, ErrInvalidSelectedChecks)
+}
+
+func (suite *CheckTargetsServiceTestSuite) TestCheckTargetsService_GetNotApplicableChecks() {
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{
+		{ID: "A"},
+		{ID: "B", Applicability: &models.CheckApplicability{Providers: []string{"aws"}}},
+		{ID: "C", Applicability: &models.CheckApplicability{OSVersions: []string{"15"}}},
+		{ID: "D", Applicability: &models.CheckApplicability{ClusterTypes: []string{models.ClusterTypeHANAScaleUp}}},
+	}, nil)
+
+	for id, expected := range map[string][]string{
+		"cluster1":   {"B", "C", "D"},
+		"host1":      {"C", "D"},
+		"sapsystem1": {"B", "C", "D"},
+		"node1":      nil,
+	} {
+		notApplicable, err := suite.checkTargetsService.GetNotApplicableChecks(id)
+		suite.NoError(err)
+		suite.Equal(expected, notApplicable, id)
+	}
+}
+
+func (suite *CheckTargetsServiceTestSuite) TestCheckTargetsService_SplitApplicableChecks() {
+	suite.checksService.On("GetSelectedChecksById", "host1").Return(models.SelectedChecks{
+		ID:             "host1",
+		SelectedChecks: []string{"A", "B", "removed"},
+	}, nil)
+	suite.checksService.On("GetConnectionSettingsById", "host1").Return(map[string]models.ConnectionSettings{}, nil)
+	suite.checksService.On("GetClusterCheckParameters", "host1", []string{"tag1"}).Return(map[string]string{}, nil)
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{
+		{ID: "A", Applicability: &models.CheckApplicability{Providers: []string{"aws", "gcp"}}},
+		{ID: "B", Applicability: &models.CheckApplicability{Providers: []string{"azure"}}},
+	}, nil)
+
+	settings, err := suite.checkTargetsService.GetSettingsByID("host1")
+	suite.NoError(err)
+	suite.Equal([]string{"A", "removed"}, settings.SelectedChecks)
+	suite.Equal([]string{"B"}, settings.NotApplicableChecks)
+}
+
+func (suite *CheckTargetsServiceTestSuite) TestCheckTargetsService_GetCheckTargetsTags() {
+	tags, err := getCheckTargetsTags(suite.tx)
+	suite.NoError(err)
+	suite.Equal(map[string][]string{
+		"cluster1":   {},
+		"host1":      {"tag1"},
+		"sapsystem1": {"tag2"},
+	}, tags)
+}
@@ -1,17 +1,35 @@
 package services
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	log "github.com/sirupsen/logrus"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/trento-project/trento/internal"
+	trentoDB "github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 )
 
+var ErrInvalidCheckParameters = errors.New("invalid check parameters")
+
+var checkParameterNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+var ErrInvalidConnectionSettings = errors.New("invalid connection settings")
+
+// becomeMethods are the privilege escalation methods supported by ansible
+var becomeMethods = []string{"sudo", "su", "pbrun", "pfexec", "doas", "dzdo", "ksu", "machinectl", "sesu"}
+
 //go:generate mockery --name=ChecksService --inpackage --filename=checks_mock.go
 
 type ChecksService interface {
@@ -20,6 +38,8 @@ type ChecksService interface {
 	GetChecksCatalogByGroup() (models.GroupedCheckList, error)
 	CreateChecksCatalogEntry(check *models.Check) error // seems to be never used
 	CreateChecksCatalog(checkList models.ChecksCatalog) error
+	GetChecksCatalogVersions(page *Page) ([]*models.ChecksCatalogVersion, error)
+	GetChecksCatalogVersion(id int64) (*models.ChecksCatalogVersion, error)
 	// Check result services
 	CreateChecksResult(checksResult *models.ChecksResult) error
 	GetLastExecutionByGroup() ([]*models.ChecksResult, error)
@@ -27,13 +47,19 @@ type ChecksService interface {
 	GetChecksResultAndMetadataByCluster(clusterId string) (*models.ChecksResultAsList, error)
 	GetAggregatedChecksResultByHost(clusterId string) (map[string]*models.AggregatedCheckData, error)
 	GetAggregatedChecksResultByCluster(clusterId string) (*models.AggregatedCheckData, error)
+	GetChecksResultsHistoryByCluster(clusterId string, page *Page) ([]*models.ChecksResultSummary, error)
+	GetChecksResultsDiff(clusterId string, fromId int64, toId int64) (*models.ChecksResultDiff, error)
 	// Selected checks services
 	GetSelectedChecksById(id string) (models.SelectedChecks, error)
 	CreateSelectedChecks(id string, selectedChecksList []string) error
+	// Check parameters services
+	GetCheckParameters(scope string, target string) (map[string]string, error)
+	CreateCheckParameters(scope string, target string, parameters map[string]string) error
+	GetClusterCheckParameters(clusterID string, tags []string) (map[string]string, error)
 	// Connection data services
 	GetConnectionSettingsById(id string) (map[string]models.ConnectionSettings, error)
 	GetConnectionSettingsByNode(node string) (models.ConnectionSettings, error)
-	CreateConnectionSettings(node, cluster, user string) error
+	CreateConnectionSettings(settings models.ConnectionSettings) error
 }
 
 type checksService struct {
@@ -57,7 +83,7 @@ func (c *checksService) GetChecksCatalog() (models.ChecksCatalog, error) {
 	isPremiumActive, _ := c.premiumDetectionService.IsPremiumActive()
 
 	var result *gorm.DB
-	qb := c.db.Order("payload->>'name'")
+	qb := c.db.Clauses(clause.OrderBy{Expression: trentoDB.JSONText("payload", "name")})
 
 	if isPremiumActive {
 		result = qb.Find(&checksEntity)
@@ -69,7 +95,43 @@ func (c *checksService) GetChecksCatalog() (models.ChecksCatalog, error) {
 		return nil, result.Error
 	}
 
-	return checksEntity.ToModel()
+	catalog, err := checksEntity.ToModel()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mergeCustomChecks(catalog)
+}
+
+// mergeCustomChecks adds the custom checks to the built-in catalog, keeping it sorted by name.
+// The built-in checks take precedence over a custom check with the same id
+func (c *checksService) mergeCustomChecks(catalog models.ChecksCatalog) (models.ChecksCatalog, error) {
+	var customChecks []*entities.CustomCheck
+	if err := c.db.Find(&customChecks).Error; err != nil {
+		return nil, err
+	}
+
+	if len(customChecks) == 0 {
+		return catalog, nil
+	}
+
+	builtIn := make(map[string]struct{}, len(catalog))
+	for _, check := range catalog {
+		builtIn[check.ID] = struct{}{}
+	}
+
+	for _, customCheck := range customChecks {
+		if _, ok := builtIn[customCheck.ID]; ok {
+			continue
+		}
+		catalog = append(catalog, customCheck.ToModel().ToCheck())
+	}
+
+	sort.SliceStable(catalog, func(i, j int) bool {
+		return catalog[i].Name < catalog[j].Name
+	})
+
+	return catalog, nil
 }
 
 func (c *checksService) GetChecksCatalogByGroup() (models.GroupedCheckList, error) {
@@ -111,8 +173,10 @@ func (c *checksService) CreateChecksCatalogEntry(check *models.Check) error {
 	return result.Error
 }
 
+// CreateChecksCatalog replaces the catalog with the given checks. When the catalog changes, a new version
+// is stored with the added, removed and changed checks, and the selections of the removed checks are
+// migrated to the checks superseding them
 func (c *checksService) CreateChecksCatalog(checkList models.ChecksCatalog) error {
-
 	var checkEntityList entities.CheckList
 	for _, check := range checkList {
 		checkJson, err := json.Marshal(&check)
@@ -122,16 +186,196 @@ func (c *checksService) CreateChecksCatalog(checkList models.ChecksCatalog) erro
 		checkEntityList = append(checkEntityList, &entities.Check{ID: check.ID, Payload: checkJson})
 	}
 
-	result := c.db.Clauses(clause.OnConflict{
-		UpdateAll: true,
-	}).Create(&checkEntityList)
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		var current entities.CheckList
+		if err := tx.Find(&current).Error; err != nil {
+			return err
+		}
 
-	if result.Error != nil {
-		return result.Error
+		previous, err := current.ToModel()
+		if err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{
+			UpdateAll: true,
+		}).Create(&checkEntityList)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// Remove old not updated checks
+		if err := tx.Not(&checkEntityList).Delete(entities.CheckList{}).Error; err != nil {
+			return err
+		}
+
+		version := diffChecksCatalog(previous, checkList)
+		if len(previous) > 0 && len(version.Added) == 0 && len(version.Removed) == 0 && len(version.Changed) == 0 {
+			return nil
+		}
+
+		if err := migrateSupersededChecks(tx, version.Superseded); err != nil {
+			return err
+		}
+
+		return storeChecksCatalogVersion(tx, version, checkList)
+	})
+}
+
+// diffChecksCatalog compares two catalogs, the removed checks superseded by a check of the new catalog are
+// reported as superseded as well
+func diffChecksCatalog(previous, next models.ChecksCatalog) *models.ChecksCatalogVersion {
+	version := &models.ChecksCatalogVersion{
+		Added:      []string{},
+		Removed:    []string{},
+		Changed:    []string{},
+		Superseded: make(map[string]string),
+	}
+
+	previousChecks := make(map[string]*models.Check, len(previous))
+	for _, check := range previous {
+		previousChecks[check.ID] = check
+	}
+
+	nextChecks := make(map[string]*models.Check, len(next))
+	for _, check := range next {
+		nextChecks[check.ID] = check
+
+		previousCheck, ok := previousChecks[check.ID]
+		switch {
+		case !ok:
+			version.Added = append(version.Added, check.ID)
+		case !sameCheck(previousCheck, check):
+			version.Changed = append(version.Changed, check.ID)
+		}
+	}
+
+	for _, check := range previous {
+		if _, ok := nextChecks[check.ID]; !ok {
+			version.Removed = append(version.Removed, check.ID)
+		}
+	}
+
+	for _, check := range next {
+		for _, superseded := range check.Supersedes {
+			if _, ok := nextChecks[superseded]; !ok {
+				version.Superseded[superseded] = check.ID
+			}
+		}
+	}
+
+	sort.Strings(version.Added)
+	sort.Strings(version.Removed)
+	sort.Strings(version.Changed)
+
+	return version
+}
+
+// sameCheck compares the checks as they are stored, so unset and empty fields are equivalent
+func sameCheck(a, b *models.Check) bool {
+	aJson, errA := json.Marshal(a)
+	bJson, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(aJson, bJson)
+}
+
+// migrateSupersededChecks replaces the superseded checks with the new ones in all the clusters selections
+func migrateSupersededChecks(tx *gorm.DB, superseded map[string]string) error {
+	if len(superseded) == 0 {
+		return nil
+	}
+
+	var allSelectedChecks []*models.SelectedChecks
+	if err := tx.Find(&allSelectedChecks).Error; err != nil {
+		return err
 	}
 
-	// Remove old not updated checks
-	return c.db.Not(&checkEntityList).Delete(entities.CheckList{}).Error
+	for _, selectedChecks := range allSelectedChecks {
+		migrated := trentoDB.StringArray{}
+		changed := false
+		seen := make(map[string]struct{})
+
+		for _, checkID := range selectedChecks.SelectedChecks {
+			if replacement, ok := superseded[checkID]; ok {
+				checkID = replacement
+				changed = true
+			}
+			if _, ok := seen[checkID]; ok {
+				continue
+			}
+			seen[checkID] = struct{}{}
+			migrated = append(migrated, checkID)
+		}
+
+		if !changed {
+			continue
+		}
+
+		log.Infof("Migrating the selected checks of %s to the superseding checks", selectedChecks.ID)
+		selectedChecks.SelectedChecks = migrated
+		if err := tx.Save(selectedChecks).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func storeChecksCatalogVersion(tx *gorm.DB, version *models.ChecksCatalogVersion, catalog models.ChecksCatalog) error {
+	entity := &entities.ChecksCatalogVersion{}
+
+	fields := map[*datatypes.JSON]interface{}{
+		&entity.Added:      version.Added,
+		&entity.Removed:    version.Removed,
+		&entity.Changed:    version.Changed,
+		&entity.Superseded: version.Superseded,
+		&entity.Catalog:    catalog,
+	}
+
+	for field, value := range fields {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		*field = data
+	}
+
+	return tx.Create(entity).Error
+}
+
+// GetChecksCatalogVersions returns the versions of the catalog, the newest first, without their checks
+func (c *checksService) GetChecksCatalogVersions(page *Page) ([]*models.ChecksCatalogVersion, error) {
+	var versions []*entities.ChecksCatalogVersion
+
+	err := c.db.Scopes(Paginate(page)).
+		Omit("catalog").
+		Order("id desc").
+		Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.ChecksCatalogVersion{}
+	for _, version := range versions {
+		modeled, err := version.ToModel(false)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, modeled)
+	}
+
+	return result, nil
+}
+
+// GetChecksCatalogVersion returns a version of the catalog with all its checks
+func (c *checksService) GetChecksCatalogVersion(id int64) (*models.ChecksCatalogVersion, error) {
+	var version entities.ChecksCatalogVersion
+	if err := c.db.Where("id = ?", id).First(&version).Error; err != nil {
+		return nil, err
+	}
+
+	return version.ToModel(true)
 }
 
 /*
@@ -160,15 +404,32 @@ func (c *checksService) GetLastExecutionByGroup() ([]*models.ChecksResult, error
 		return nil, err
 	}
 
+	waivers, err := c.getActiveWaivers()
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []*entities.Cluster
+	if err := c.db.Select("id", "sid").Find(&clusters).Error; err != nil {
+		return nil, err
+	}
+
+	sids := make(map[string]string, len(clusters))
+	for _, cluster := range clusters {
+		sids[cluster.ID] = cluster.SID
+	}
+
 	var checksResultModels []*models.ChecksResult
 	for _, checksResult := range checksResults {
 		cModel, _ := checksResult.ToModel()
+		cModel.ApplyWaivers(sids[cModel.ID], waivers)
 		checksResultModels = append(checksResultModels, cModel)
 	}
 
 	return checksResultModels, nil
 }
 
+// GetChecksResultByCluster returns the last result of the cluster as it was stored, without applying the waivers
 func (c *checksService) GetChecksResultByCluster(clusterId string) (*models.ChecksResult, error) {
 	var checksResult entities.ChecksResult
 	result := c.db.Where("group_id", clusterId).Last(&checksResult)
@@ -181,7 +442,7 @@ func (c *checksService) GetChecksResultByCluster(clusterId string) (*models.Chec
 }
 
 func (c *checksService) GetChecksResultAndMetadataByCluster(clusterId string) (*models.ChecksResultAsList, error) {
-	cResultByCluster, err := c.GetChecksResultByCluster(clusterId)
+	cResultByCluster, err := c.getWaivedChecksResultByCluster(clusterId)
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +475,7 @@ func (c *checksService) GetChecksResultAndMetadataByCluster(clusterId string) (*
 }
 
 func (c *checksService) GetAggregatedChecksResultByHost(clusterId string) (map[string]*models.AggregatedCheckData, error) {
-	cResultByCluster, err := c.GetChecksResultByCluster(clusterId)
+	cResultByCluster, err := c.getWaivedChecksResultByCluster(clusterId)
 	if err != nil {
 		return nil, err
 	}
@@ -223,7 +484,7 @@ func (c *checksService) GetAggregatedChecksResultByHost(clusterId string) (map[s
 }
 
 func (c *checksService) GetAggregatedChecksResultByCluster(clusterId string) (*models.AggregatedCheckData, error) {
-	cResultByCluster, err := c.GetChecksResultByCluster(clusterId)
+	cResultByCluster, err := c.getWaivedChecksResultByCluster(clusterId)
 	if err != nil {
 		return nil, err
 	}
@@ -231,6 +492,122 @@ func (c *checksService) GetAggregatedChecksResultByCluster(clusterId string) (*m
 	return cResultByCluster.GetAggregatedChecksResultByCluster(), nil
 }
 
+// getWaivedChecksResultByCluster returns the last result of the cluster, with the failing results covered by
+// an active waiver reported as waived
+func (c *checksService) getWaivedChecksResultByCluster(clusterId string) (*models.ChecksResult, error) {
+	checksResult, err := c.GetChecksResultByCluster(clusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	waivers, err := c.getActiveWaivers()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(waivers) == 0 {
+		return checksResult, nil
+	}
+
+	var cluster entities.Cluster
+	err = c.db.Select("id", "sid").Where("id = ?", clusterId).Limit(1).Find(&cluster).Error
+	if err != nil {
+		return nil, err
+	}
+
+	checksResult.ApplyWaivers(cluster.SID, waivers)
+
+	return checksResult, nil
+}
+
+// getActiveWaivers returns the waivers not expired yet, even if the expiration was not recorded
+func (c *checksService) getActiveWaivers() ([]*models.Waiver, error) {
+	var waivers []*entities.Waiver
+	if err := c.db.Where("expires_at > ?", time.Now()).Find(&waivers).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Waiver, 0, len(waivers))
+	for _, waiver := range waivers {
+		result = append(result, waiver.ToModel())
+	}
+
+	return result, nil
+}
+
+// GetChecksResultsHistoryByCluster returns the past executions of a cluster, the most recent first
+func (c *checksService) GetChecksResultsHistoryByCluster(clusterId string, page *Page) ([]*models.ChecksResultSummary, error) {
+	var checksResults []entities.ChecksResult
+
+	err := c.db.Scopes(Paginate(page)).
+		Where("group_id = ?", clusterId).
+		Order("id desc").
+		Find(&checksResults).Error
+	if err != nil {
+		return nil, err
+	}
+
+	history := []*models.ChecksResultSummary{}
+	for _, checksResult := range checksResults {
+		summary, err := checksResult.ToSummary()
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, summary)
+	}
+
+	return history, nil
+}
+
+// GetChecksResultsDiff compares two executions of a cluster, listing the check results that changed
+func (c *checksService) GetChecksResultsDiff(clusterId string, fromId int64, toId int64) (*models.ChecksResultDiff, error) {
+	var from, to entities.ChecksResult
+
+	if err := c.db.Where("group_id = ? AND id = ?", clusterId, fromId).First(&from).Error; err != nil {
+		return nil, err
+	}
+
+	if err := c.db.Where("group_id = ? AND id = ?", clusterId, toId).First(&to).Error; err != nil {
+		return nil, err
+	}
+
+	fromModel, err := from.ToModel()
+	if err != nil {
+		return nil, err
+	}
+
+	toModel, err := to.ToModel()
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &models.ChecksResultDiff{Changes: fromModel.Diff(toModel)}
+
+	if diff.From, err = from.ToSummary(); err != nil {
+		return nil, err
+	}
+
+	if diff.To, err = to.ToSummary(); err != nil {
+		return nil, err
+	}
+
+	checkList, err := c.GetChecksCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range diff.Changes {
+		for _, check := range checkList {
+			if check.ID == change.CheckID {
+				change.Description = check.Description
+				break
+			}
+		}
+	}
+
+	return diff, nil
+}
+
 /*
 Selected checks services
 */
@@ -267,11 +644,49 @@ func (c *checksService) GetSelectedChecksById(id string) (models.SelectedChecks,
 		}
 	}
 
+	removedChecks, err := c.getRemovedChecks(selectedChecks.SelectedChecks)
+	if err != nil {
+		return selectedChecks, err
+	}
+
 	selectedChecks.SelectedChecks = filteredChecks
+	selectedChecks.RemovedChecks = removedChecks
 
 	return selectedChecks, err
 }
 
+// getRemovedChecks returns the checks that are in neither the built-in catalog nor the custom checks.
+// Unlike the catalog, premium checks are taken into account, as they are not removed but unavailable
+func (c *checksService) getRemovedChecks(checkIDs []string) ([]string, error) {
+	if len(checkIDs) == 0 {
+		return nil, nil
+	}
+
+	var existing []string
+	if err := c.db.Model(&entities.Check{}).Where("id IN ?", checkIDs).Pluck("id", &existing).Error; err != nil {
+		return nil, err
+	}
+
+	var custom []string
+	if err := c.db.Model(&entities.CustomCheck{}).Where("id IN ?", checkIDs).Pluck("id", &custom).Error; err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]struct{})
+	for _, checkID := range append(existing, custom...) {
+		set[checkID] = struct{}{}
+	}
+
+	var removed []string
+	for _, checkID := range checkIDs {
+		if _, ok := set[checkID]; !ok {
+			removed = append(removed, checkID)
+		}
+	}
+
+	return removed, nil
+}
+
 func (c *checksService) CreateSelectedChecks(id string, selectedChecksList []string) error {
 	selectedChecks := models.SelectedChecks{
 		ID:             id,
@@ -285,6 +700,113 @@ func (c *checksService) CreateSelectedChecks(id string, selectedChecksList []str
 	return result.Error
 }
 
+/*
+Check parameters services
+*/
+
+// GetCheckParameters returns the parameters overridden for a cluster or a tag
+func (c *checksService) GetCheckParameters(scope string, target string) (map[string]string, error) {
+	var checkParameters entities.CheckParameters
+
+	err := c.db.Where("scope = ? AND target = ?", scope, target).First(&checkParameters).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	modeled, err := checkParameters.ToModel()
+	if err != nil {
+		return nil, err
+	}
+
+	return modeled.Parameters, nil
+}
+
+// CreateCheckParameters replaces the parameters overridden for a cluster or a tag, no parameters removes them
+func (c *checksService) CreateCheckParameters(scope string, target string, parameters map[string]string) error {
+	if err := validateCheckParameters(scope, target, parameters); err != nil {
+		return err
+	}
+
+	if len(parameters) == 0 {
+		return c.db.Where("scope = ? AND target = ?", scope, target).Delete(&entities.CheckParameters{}).Error
+	}
+
+	payload, err := json.Marshal(parameters)
+	if err != nil {
+		return err
+	}
+
+	return c.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&entities.CheckParameters{Scope: scope, Target: target, Parameters: payload}).Error
+}
+
+// GetClusterCheckParameters returns the parameters applying to a cluster: the ones of its tags, in alphabetical
+// order when more tags override the same parameter, overridden by the ones of the cluster itself
+func (c *checksService) GetClusterCheckParameters(clusterID string, tags []string) (map[string]string, error) {
+	var checkParametersList []*entities.CheckParameters
+
+	err := c.db.
+		Where("scope = ? AND target = ?", models.CheckParametersClusterScope, clusterID).
+		Or("scope = ? AND target IN ?", models.CheckParametersTagScope, tags).
+		Find(&checkParametersList).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(checkParametersList, func(i, j int) bool {
+		a, b := checkParametersList[i], checkParametersList[j]
+		if a.Scope != b.Scope {
+			return a.Scope == models.CheckParametersTagScope
+		}
+		return a.Target < b.Target
+	})
+
+	parameters := make(map[string]string)
+	for _, checkParameters := range checkParametersList {
+		modeled, err := checkParameters.ToModel()
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range modeled.Parameters {
+			parameters[name] = value
+		}
+	}
+
+	return parameters, nil
+}
+
+func validateCheckParameters(scope string, target string, parameters map[string]string) error {
+	if scope != models.CheckParametersClusterScope && scope != models.CheckParametersTagScope {
+		return fmt.Errorf("%w: the scope must be %s or %s", ErrInvalidCheckParameters,
+			models.CheckParametersClusterScope, models.CheckParametersTagScope)
+	}
+	if target == "" {
+		return fmt.Errorf("%w: the target is required", ErrInvalidCheckParameters)
+	}
+
+	for name, value := range parameters {
+		if !checkParameterNamePattern.MatchString(name) {
+			return fmt.Errorf("%w: the parameter %q must only contain letters, digits, dots, dashes and underscores",
+				ErrInvalidCheckParameters, name)
+		}
+		// the parameters are passed to ansible as a single quoted JSON inventory variable
+		if strings.ContainsAny(value, "'\n") {
+			return fmt.Errorf("%w: the value of %s can't contain quotes nor new lines", ErrInvalidCheckParameters, name)
+		}
+		// ansible would render the templates in the values on the cluster nodes
+		if delimiter := templateDelimiter(value); delimiter != "" {
+			return fmt.Errorf("%w: the value of %s can't contain the template delimiter %s",
+				ErrInvalidCheckParameters, name, delimiter)
+		}
+	}
+
+	return nil
+}
+
 /*
 Checks connection user services
 */
@@ -314,16 +836,57 @@ func (c *checksService) GetConnectionSettingsById(id string) (map[string]models.
 	return connUsersMap, nil
 }
 
-func (c *checksService) CreateConnectionSettings(id, node, user string) error {
-	connUser := models.ConnectionSettings{
-		ID:   id,
-		Node: node,
-		User: user,
+// CreateConnectionSettings stores the connection profile of a host, or of all the hosts of a cluster
+// when the node is models.ClusterConnectionNode, replacing the existing one
+func (c *checksService) CreateConnectionSettings(settings models.ConnectionSettings) error {
+	if err := validateConnectionSettings(settings); err != nil {
+		return err
 	}
 
 	result := c.db.Clauses(clause.OnConflict{
 		UpdateAll: true,
-	}).Create(&connUser)
+	}).Create(&settings)
 
 	return result.Error
 }
+
+func validateConnectionSettings(settings models.ConnectionSettings) error {
+	if settings.Port < 0 || settings.Port > 65535 {
+		return fmt.Errorf("%w: the port %d is out of range", ErrInvalidConnectionSettings, settings.Port)
+	}
+
+	if settings.BecomeMethod != "" && !internal.Contains(becomeMethods, settings.BecomeMethod) {
+		return fmt.Errorf("%w: the become method must be one of %s", ErrInvalidConnectionSettings,
+			strings.Join(becomeMethods, ", "))
+	}
+
+	// the values are written as they are in the ansible inventory
+	for name, value := range map[string]string{
+		"user":        settings.User,
+		"private key": settings.PrivateKey,
+		"proxy jump":  settings.ProxyJump,
+		"become user": settings.BecomeUser,
+	} {
+		if strings.ContainsAny(value, " \t\n'\"") {
+			return fmt.Errorf("%w: the %s can't contain blanks nor quotes", ErrInvalidConnectionSettings, name)
+		}
+		// ansible renders the templates of the inventory variables on the runner
+		if delimiter := templateDelimiter(value); delimiter != "" {
+			return fmt.Errorf("%w: the %s can't contain the template delimiter %s",
+				ErrInvalidConnectionSettings, name, delimiter)
+		}
+	}
+
+	return nil
+}
+
+// templateDelimiter returns the first ansible template delimiter found in the value, if any
+func templateDelimiter(value string) string {
+	for _, delimiter := range []string{"{{", "{%", "{#"} {
+		if strings.Contains(value, delimiter) {
+			return delimiter
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=ChecksExecutionsService --inpackage --filename=checks_executions_mock.go
+
+type ChecksExecutionsService interface {
+	Enqueue(clusterID string) (*models.ChecksExecution, error)
+	Start(clusterID string, trigger string) (*models.ChecksExecution, error)
+	GetLastByCluster(clusterID string) (*models.ChecksExecution, error)
+	GetAllByCluster(clusterID string, status []string, page *Page) ([]*models.ChecksExecution, error)
+	Claim(runnerID string) (*models.ChecksExecution, error)
+	Complete(id int64, result *models.ChecksExecutionResult) error
+}
+
+type checksExecutionsService struct {
+	db *gorm.DB
+}
+
+func NewChecksExecutionsService(db *gorm.DB) *checksExecutionsService {
+	return &checksExecutionsService{db: db}
+}
+
+// Enqueue requests a checks execution for the given cluster.
+// A cluster has at most one queued execution, so requesting it again returns the pending one.
+// The uniqueness is enforced by a partial unique index, so concurrent requests never queue duplicates
+func (s *checksExecutionsService) Enqueue(clusterID string) (*models.ChecksExecution, error) {
+	execution := entities.ChecksExecution{
+		ClusterID: clusterID,
+		Status:    models.ChecksExecutionQueued,
+		Trigger:   models.ChecksExecutionManual,
+	}
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&execution)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		return execution.ToModel(), nil
+	}
+
+	var queued entities.ChecksExecution
+	err := s.db.
+		Where("cluster_id = ? AND status = ?", clusterID, models.ChecksExecutionQueued).
+		First(&queued).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return queued.ToModel(), nil
+}
+
+// Start records an execution that the runner is already running, e.g. a scheduled one
+func (s *checksExecutionsService) Start(clusterID string, trigger string) (*models.ChecksExecution, error) {
+	if trigger != models.ChecksExecutionScheduled && trigger != models.ChecksExecutionManual {
+		return nil, fmt.Errorf("invalid checks execution trigger: %s", trigger)
+	}
+
+	now := time.Now()
+	execution := entities.ChecksExecution{
+		ClusterID: clusterID,
+		Status:    models.ChecksExecutionRunning,
+		Trigger:   trigger,
+		StartedAt: &now,
+	}
+
+	if err := s.db.Create(&execution).Error; err != nil {
+		return nil, err
+	}
+
+	return execution.ToModel(), nil
+}
+
+// GetAllByCluster returns the executions of a cluster, the most recent first, optionally filtered by status
+func (s *checksExecutionsService) GetAllByCluster(clusterID string, status []string, page *Page) ([]*models.ChecksExecution, error) {
+	var executions []entities.ChecksExecution
+
+	db := s.db.Scopes(Paginate(page)).Where("cluster_id = ?", clusterID)
+	if len(status) > 0 {
+		db = db.Where("status IN ?", status)
+	}
+
+	if err := db.Order("id desc").Find(&executions).Error; err != nil {
+		return nil, err
+	}
+
+	executionList := []*models.ChecksExecution{}
+	for _, execution := range executions {
+		executionList = append(executionList, execution.ToModel())
+	}
+
+	return executionList, nil
+}
+
+func (s *checksExecutionsService) GetLastByCluster(clusterID string) (*models.ChecksExecution, error) {
+	var execution entities.ChecksExecution
+
+	err := s.db.
+		Where("cluster_id = ?", clusterID).
+		Order("id desc").
+		First(&execution).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return execution.ToModel(), nil
+}
+
+// Claim marks the oldest queued execution as running and returns it, nil is returned when the queue is empty.
+// The status is swapped with a conditional update, so concurrent runners never claim the same execution.
+// A runner only claims the executions of the clusters it holds a lease of, unless no runner id is given.
+// The executions left running by a dead runner are queued again first
+func (s *checksExecutionsService) Claim(runnerID string) (*models.ChecksExecution, error) {
+	if err := s.requeueOrphaned(); err != nil {
+		return nil, err
+	}
+
+	for {
+		var execution entities.ChecksExecution
+
+		db := s.db.Where("status = ?", models.ChecksExecutionQueued)
+		if runnerID != "" {
+			leased := s.db.Model(&entities.RunnerLease{}).
+				Select("cluster_id").
+				Where("runner_id = ? AND expires_at > ?", runnerID, time.Now())
+			db = db.Where("cluster_id IN (?)", leased)
+		}
+
+		err := db.Order("id").First(&execution).Error
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		now := time.Now()
+		result := s.db.Model(&entities.ChecksExecution{}).
+			Where("id = ? AND status = ?", execution.ID, models.ChecksExecutionQueued).
+			Updates(map[string]interface{}{
+				"status":     models.ChecksExecutionRunning,
+				"started_at": now,
+				"runner_id":  runnerID,
+			})
+
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			// claimed by someone else in the meantime, try with the next one
+			continue
+		}
+
+		execution.Status = models.ChecksExecutionRunning
+		execution.StartedAt = &now
+
+		return execution.ToModel(), nil
+	}
+}
+
+// requeueOrphaned fails the running executions whose runner stopped sending heartbeats, and so lost its leases,
+// queueing them again for the runner taking over the cluster. The executions claimed without a runner id are kept
+func (s *checksExecutionsService) requeueOrphaned() error {
+	now := time.Now()
+
+	alive := s.db.Model(&entities.Runner{}).
+		Select("1").
+		Where("runners.id = checks_executions.runner_id AND runners.heartbeat_at > ?", now.Add(-RunnerLeaseDuration))
+
+	var orphaned []entities.ChecksExecution
+	err := s.db.
+		Where("status = ? AND runner_id <> ''", models.ChecksExecutionRunning).
+		Where("NOT EXISTS (?)", alive).
+		Find(&orphaned).Error
+	if err != nil {
+		return err
+	}
+
+	for _, execution := range orphaned {
+		result := s.db.Model(&entities.ChecksExecution{}).
+			Where("id = ? AND status = ?", execution.ID, models.ChecksExecutionRunning).
+			Updates(map[string]interface{}{
+				"status":       models.ChecksExecutionFailed,
+				"completed_at": now,
+				"stderr":       fmt.Sprintf("the runner %s stopped while running the checks", execution.RunnerID),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// requeued by someone else in the meantime
+			continue
+		}
+
+		log.Warnf("Runner %s stopped while running the checks execution %d, queueing the checks of cluster %s again",
+			execution.RunnerID, execution.ID, execution.ClusterID)
+		if _, err := s.Enqueue(execution.ClusterID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Complete stores the outcome of a running execution
+func (s *checksExecutionsService) Complete(id int64, executionResult *models.ChecksExecutionResult) error {
+	if executionResult.Status != models.ChecksExecutionCompleted && executionResult.Status != models.ChecksExecutionFailed {
+		return fmt.Errorf("invalid checks execution final status: %s", executionResult.Status)
+	}
+
+	hostErrors, err := json.Marshal(executionResult.HostErrors)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.Model(&entities.ChecksExecution{}).
+		Where("id = ? AND status = ?", id, models.ChecksExecutionRunning).
+		Updates(map[string]interface{}{
+			"status":       executionResult.Status,
+			"completed_at": time.Now(),
+			"exit_code":    executionResult.ExitCode,
+			"stderr":       executionResult.Stderr,
+			"host_errors":  datatypes.JSON(hostErrors),
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
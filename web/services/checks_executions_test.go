@@ -0,0 +1,250 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type ChecksExecutionsServiceTestSuite struct {
+	suite.Suite
+	db                      *gorm.DB
+	tx                      *gorm.DB
+	checksExecutionsService *checksExecutionsService
+}
+
+func TestChecksExecutionsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ChecksExecutionsServiceTestSuite))
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.ChecksExecution{}, entities.Runner{}, entities.RunnerLease{})
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.ChecksExecution{}, entities.Runner{}, entities.RunnerLease{})
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.checksExecutionsService = NewChecksExecutionsService(suite.tx)
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_Enqueue() {
+	execution, err := suite.checksExecutionsService.Enqueue("cluster1")
+	suite.NoError(err)
+	suite.Equal("cluster1", execution.ClusterID)
+	suite.Equal(models.ChecksExecutionQueued, execution.Status)
+	suite.Equal(models.ChecksExecutionManual, execution.Trigger)
+	suite.NotZero(execution.ID)
+
+	again, err := suite.checksExecutionsService.Enqueue("cluster1")
+	suite.NoError(err)
+	suite.Equal(execution.ID, again.ID)
+
+	other, err := suite.checksExecutionsService.Enqueue("cluster2")
+	suite.NoError(err)
+	suite.NotEqual(execution.ID, other.ID)
+
+	var count int64
+	suite.tx.Model(&entities.ChecksExecution{}).Count(&count)
+	suite.EqualValues(2, count)
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_EnqueueUnique() {
+	queued := entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionQueued}
+	suite.NoError(suite.tx.Create(&queued).Error)
+
+	// the partial unique index refuses a second queued execution, only the queued ones are unique
+	suite.Error(suite.tx.SavePoint("duplicate").Create(&entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionQueued}).Error)
+	suite.tx.RollbackTo("duplicate")
+	suite.NoError(suite.tx.Create(&entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionCompleted}).Error)
+
+	execution, err := suite.checksExecutionsService.Enqueue("cluster1")
+	suite.NoError(err)
+	suite.Equal(queued.ID, execution.ID)
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_GetLastByCluster() {
+	execution, err := suite.checksExecutionsService.GetLastByCluster("cluster1")
+	suite.NoError(err)
+	suite.Nil(execution)
+
+	suite.tx.Create(&entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionCompleted})
+	suite.tx.Create(&entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionRunning})
+	suite.tx.Create(&entities.ChecksExecution{ClusterID: "cluster2", Status: models.ChecksExecutionQueued})
+
+	execution, err = suite.checksExecutionsService.GetLastByCluster("cluster1")
+	suite.NoError(err)
+	suite.Equal("cluster1", execution.ClusterID)
+	suite.Equal(models.ChecksExecutionRunning, execution.Status)
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_ClaimAndComplete() {
+	execution, err := suite.checksExecutionsService.Claim("")
+	suite.NoError(err)
+	suite.Nil(execution)
+
+	first, _ := suite.checksExecutionsService.Enqueue("cluster1")
+	second, _ := suite.checksExecutionsService.Enqueue("cluster2")
+
+	execution, err = suite.checksExecutionsService.Claim("")
+	suite.NoError(err)
+	suite.Equal(first.ID, execution.ID)
+	suite.Equal(models.ChecksExecutionRunning, execution.Status)
+	suite.NotNil(execution.StartedAt)
+
+	execution, err = suite.checksExecutionsService.Claim("")
+	suite.NoError(err)
+	suite.Equal(second.ID, execution.ID)
+
+	execution, err = suite.checksExecutionsService.Claim("")
+	suite.NoError(err)
+	suite.Nil(execution)
+
+	exitCode := 4
+	suite.NoError(suite.checksExecutionsService.Complete(first.ID, &models.ChecksExecutionResult{
+		Status: models.ChecksExecutionCompleted,
+	}))
+	suite.NoError(suite.checksExecutionsService.Complete(second.ID, &models.ChecksExecutionResult{
+		Status:     models.ChecksExecutionFailed,
+		ExitCode:   &exitCode,
+		Stderr:     "fatal: host unreachable",
+		HostErrors: map[string]string{"host1": "unreachable"},
+	}))
+
+	last, _ := suite.checksExecutionsService.GetLastByCluster("cluster1")
+	suite.Equal(models.ChecksExecutionCompleted, last.Status)
+	suite.NotNil(last.CompletedAt)
+	suite.Nil(last.ExitCode)
+	suite.Empty(last.HostErrors)
+
+	last, _ = suite.checksExecutionsService.GetLastByCluster("cluster2")
+	suite.Equal(models.ChecksExecutionFailed, last.Status)
+	suite.Equal(4, *last.ExitCode)
+	suite.Equal("fatal: host unreachable", last.Stderr)
+	suite.Equal(map[string]string{"host1": "unreachable"}, last.HostErrors)
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_ClaimLeased() {
+	now := time.Now()
+	suite.tx.Create(&entities.Runner{ID: "runner1", HeartbeatAt: now})
+	suite.tx.Create(&entities.Runner{ID: "runner2", HeartbeatAt: now})
+	suite.tx.Create(&entities.RunnerLease{ClusterID: "cluster1", RunnerID: "runner1", ExpiresAt: now.Add(time.Minute)})
+	suite.tx.Create(&entities.RunnerLease{ClusterID: "cluster2", RunnerID: "runner2", ExpiresAt: now.Add(time.Minute)})
+	suite.tx.Create(&entities.RunnerLease{ClusterID: "cluster3", RunnerID: "runner1", ExpiresAt: now.Add(-time.Minute)})
+
+	suite.checksExecutionsService.Enqueue("cluster2")
+	suite.checksExecutionsService.Enqueue("cluster3")
+	leased, _ := suite.checksExecutionsService.Enqueue("cluster1")
+
+	// the executions of the other runners clusters and of the expired leases are skipped
+	execution, err := suite.checksExecutionsService.Claim("runner1")
+	suite.NoError(err)
+	suite.Equal(leased.ID, execution.ID)
+
+	execution, err = suite.checksExecutionsService.Claim("runner1")
+	suite.NoError(err)
+	suite.Nil(execution)
+
+	execution, err = suite.checksExecutionsService.Claim("runner2")
+	suite.NoError(err)
+	suite.Equal("cluster2", execution.ClusterID)
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_ClaimRequeuesOrphaned() {
+	now := time.Now()
+	suite.tx.Create(&entities.Runner{ID: "crashed", HeartbeatAt: now.Add(-time.Hour)})
+	suite.tx.Create(&entities.Runner{ID: "runner1", HeartbeatAt: now})
+	suite.tx.Create(&entities.RunnerLease{ClusterID: "cluster1", RunnerID: "runner1", ExpiresAt: now.Add(time.Minute)})
+	suite.tx.Create(&entities.RunnerLease{ClusterID: "cluster2", RunnerID: "runner1", ExpiresAt: now.Add(time.Minute)})
+
+	orphaned := entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionRunning, RunnerID: "crashed"}
+	suite.tx.Create(&orphaned)
+	running := entities.ChecksExecution{ClusterID: "cluster2", Status: models.ChecksExecutionRunning, RunnerID: "runner1"}
+	suite.tx.Create(&running)
+	unknown := entities.ChecksExecution{ClusterID: "cluster3", Status: models.ChecksExecutionRunning}
+	suite.tx.Create(&unknown)
+
+	// the execution of the crashed runner is failed and queued again for the runner taking its cluster over
+	execution, err := suite.checksExecutionsService.Claim("runner1")
+	suite.NoError(err)
+	suite.Equal("cluster1", execution.ClusterID)
+	suite.NotEqual(orphaned.ID, execution.ID)
+
+	var stored entities.ChecksExecution
+	suite.tx.First(&stored, orphaned.ID)
+	suite.Equal(models.ChecksExecutionFailed, stored.Status)
+	suite.Equal("the runner crashed stopped while running the checks", stored.Stderr)
+	suite.NotNil(stored.CompletedAt)
+
+	// the executions of the live runners and the ones claimed without a runner id are left running
+	for _, id := range []int64{running.ID, unknown.ID} {
+		var stored entities.ChecksExecution
+		suite.tx.First(&stored, id)
+		suite.Equal(models.ChecksExecutionRunning, stored.Status)
+	}
+
+	execution, err = suite.checksExecutionsService.Claim("runner1")
+	suite.NoError(err)
+	suite.Nil(execution)
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_CompleteErrors() {
+	execution, _ := suite.checksExecutionsService.Enqueue("cluster1")
+
+	err := suite.checksExecutionsService.Complete(execution.ID, &models.ChecksExecutionResult{Status: models.ChecksExecutionCompleted})
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	err = suite.checksExecutionsService.Complete(execution.ID, &models.ChecksExecutionResult{Status: models.ChecksExecutionRunning})
+	suite.EqualError(err, "invalid checks execution final status: running")
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_Start() {
+	execution, err := suite.checksExecutionsService.Start("cluster1", models.ChecksExecutionScheduled)
+	suite.NoError(err)
+	suite.Equal(models.ChecksExecutionRunning, execution.Status)
+	suite.Equal(models.ChecksExecutionScheduled, execution.Trigger)
+	suite.NotNil(execution.StartedAt)
+
+	// a started execution is not claimable
+	claimed, err := suite.checksExecutionsService.Claim("")
+	suite.NoError(err)
+	suite.Nil(claimed)
+
+	_, err = suite.checksExecutionsService.Start("cluster1", "other")
+	suite.EqualError(err, "invalid checks execution trigger: other")
+}
+
+func (suite *ChecksExecutionsServiceTestSuite) TestChecksExecutionsService_GetAllByCluster() {
+	suite.tx.Create(&entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionFailed})
+	suite.tx.Create(&entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionCompleted})
+	suite.tx.Create(&entities.ChecksExecution{ClusterID: "cluster1", Status: models.ChecksExecutionFailed})
+	suite.tx.Create(&entities.ChecksExecution{ClusterID: "cluster2", Status: models.ChecksExecutionFailed})
+
+	executions, err := suite.checksExecutionsService.GetAllByCluster("cluster1", nil, nil)
+	suite.NoError(err)
+	suite.Len(executions, 3)
+	suite.Greater(executions[0].ID, executions[1].ID)
+
+	executions, err = suite.checksExecutionsService.GetAllByCluster("cluster1", []string{models.ChecksExecutionFailed}, &Page{Number: 1, Size: 1})
+	suite.NoError(err)
+	suite.Len(executions, 1)
+	suite.Equal(models.ChecksExecutionFailed, executions[0].Status)
+	suite.Equal("cluster1", executions[0].ClusterID)
+
+	executions, err = suite.checksExecutionsService.GetAllByCluster("other", nil, nil)
+	suite.NoError(err)
+	suite.Empty(executions)
+}
@@ -12,6 +12,20 @@ type MockChecksService struct {
 	mock.Mock
 }
 
+// CreateCheckParameters provides a mock function with given fields: scope, target, parameters
+func (_m *MockChecksService) CreateCheckParameters(scope string, target string, parameters map[string]string) error {
+	ret := _m.Called(scope, target, parameters)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, map[string]string) error); ok {
+		r0 = rf(scope, target, parameters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateChecksCatalog provides a mock function with given fields: checkList
 func (_m *MockChecksService) CreateChecksCatalog(checkList models.ChecksCatalog) error {
 	ret := _m.Called(checkList)
@@ -54,13 +68,13 @@ func (_m *MockChecksService) CreateChecksResult(checksResult *models.ChecksResul
 	return r0
 }
 
-// CreateConnectionSettings provides a mock function with given fields: node, cluster, user
-func (_m *MockChecksService) CreateConnectionSettings(node string, cluster string, user string) error {
-	ret := _m.Called(node, cluster, user)
+// CreateConnectionSettings provides a mock function with given fields: settings
+func (_m *MockChecksService) CreateConnectionSettings(settings models.ConnectionSettings) error {
+	ret := _m.Called(settings)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(node, cluster, user)
+	if rf, ok := ret.Get(0).(func(models.ConnectionSettings) error); ok {
+		r0 = rf(settings)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -128,6 +142,29 @@ func (_m *MockChecksService) GetAggregatedChecksResultByHost(clusterId string) (
 	return r0, r1
 }
 
+// GetCheckParameters provides a mock function with given fields: scope, target
+func (_m *MockChecksService) GetCheckParameters(scope string, target string) (map[string]string, error) {
+	ret := _m.Called(scope, target)
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string, string) map[string]string); ok {
+		r0 = rf(scope, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(scope, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChecksCatalog provides a mock function with given fields:
 func (_m *MockChecksService) GetChecksCatalog() (models.ChecksCatalog, error) {
 	ret := _m.Called()
@@ -174,6 +211,52 @@ func (_m *MockChecksService) GetChecksCatalogByGroup() (models.GroupedCheckList,
 	return r0, r1
 }
 
+// GetChecksCatalogVersion provides a mock function with given fields: id
+func (_m *MockChecksService) GetChecksCatalogVersion(id int64) (*models.ChecksCatalogVersion, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.ChecksCatalogVersion
+	if rf, ok := ret.Get(0).(func(int64) *models.ChecksCatalogVersion); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChecksCatalogVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChecksCatalogVersions provides a mock function with given fields: page
+func (_m *MockChecksService) GetChecksCatalogVersions(page *Page) ([]*models.ChecksCatalogVersion, error) {
+	ret := _m.Called(page)
+
+	var r0 []*models.ChecksCatalogVersion
+	if rf, ok := ret.Get(0).(func(*Page) []*models.ChecksCatalogVersion); ok {
+		r0 = rf(page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ChecksCatalogVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Page) error); ok {
+		r1 = rf(page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChecksResultAndMetadataByCluster provides a mock function with given fields: clusterId
 func (_m *MockChecksService) GetChecksResultAndMetadataByCluster(clusterId string) (*models.ChecksResultAsList, error) {
 	ret := _m.Called(clusterId)
@@ -220,6 +303,75 @@ func (_m *MockChecksService) GetChecksResultByCluster(clusterId string) (*models
 	return r0, r1
 }
 
+// GetChecksResultsDiff provides a mock function with given fields: clusterId, fromId, toId
+func (_m *MockChecksService) GetChecksResultsDiff(clusterId string, fromId int64, toId int64) (*models.ChecksResultDiff, error) {
+	ret := _m.Called(clusterId, fromId, toId)
+
+	var r0 *models.ChecksResultDiff
+	if rf, ok := ret.Get(0).(func(string, int64, int64) *models.ChecksResultDiff); ok {
+		r0 = rf(clusterId, fromId, toId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChecksResultDiff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64) error); ok {
+		r1 = rf(clusterId, fromId, toId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChecksResultsHistoryByCluster provides a mock function with given fields: clusterId, page
+func (_m *MockChecksService) GetChecksResultsHistoryByCluster(clusterId string, page *Page) ([]*models.ChecksResultSummary, error) {
+	ret := _m.Called(clusterId, page)
+
+	var r0 []*models.ChecksResultSummary
+	if rf, ok := ret.Get(0).(func(string, *Page) []*models.ChecksResultSummary); ok {
+		r0 = rf(clusterId, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ChecksResultSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *Page) error); ok {
+		r1 = rf(clusterId, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClusterCheckParameters provides a mock function with given fields: clusterID, tags
+func (_m *MockChecksService) GetClusterCheckParameters(clusterID string, tags []string) (map[string]string, error) {
+	ret := _m.Called(clusterID, tags)
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(string, []string) map[string]string); ok {
+		r0 = rf(clusterID, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(clusterID, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConnectionSettingsById provides a mock function with given fields: id
 func (_m *MockChecksService) GetConnectionSettingsById(id string) (map[string]models.ConnectionSettings, error) {
 	ret := _m.Called(id)
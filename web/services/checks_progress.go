@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=ChecksProgressService --inpackage --filename=checks_progress_mock.go
+
+var ErrInvalidChecksProgressEvent = errors.New("invalid checks progress event")
+
+// checksProgressBufferSize is the number of updates a slow subscriber can miss before they are dropped
+const checksProgressBufferSize = 64
+
+type ChecksProgressService interface {
+	Publish(targetID string, event *models.ChecksProgressEvent) error
+	GetProgress(targetID string) *models.ChecksProgress
+	Subscribe(targetID string) (<-chan *models.ChecksProgress, func())
+}
+
+// checksProgressService keeps the progress of the checks runs in memory, as it is only relevant while they run.
+// Every event published updates the progress of the target, and the subscribers receive the new state
+type checksProgressService struct {
+	lock        sync.Mutex
+	progress    map[string]*models.ChecksProgress
+	subscribers map[string]map[chan *models.ChecksProgress]struct{}
+}
+
+func NewChecksProgressService() *checksProgressService {
+	return &checksProgressService{
+		progress:    make(map[string]*models.ChecksProgress),
+		subscribers: make(map[string]map[chan *models.ChecksProgress]struct{}),
+	}
+}
+
+// Publish applies an event to the progress of the target and notifies the subscribers.
+// The events received before a run is started begin a new one
+func (s *checksProgressService) Publish(targetID string, event *models.ChecksProgressEvent) error {
+	if !models.IsValidChecksProgressEvent(event) {
+		return fmt.Errorf("%w: %s", ErrInvalidChecksProgressEvent, event.Type)
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	progress, ok := s.progress[targetID]
+	if !ok || event.Type == models.ChecksProgressStarted {
+		progress = models.NewChecksProgress(targetID, event.Time)
+		s.progress[targetID] = progress
+	}
+	progress.Apply(event)
+
+	for subscriber := range s.subscribers[targetID] {
+		select {
+		case subscriber <- progress.Copy():
+		default:
+			log.Warnf("Dropping a checks progress update of %s, the subscriber is too slow", targetID)
+		}
+	}
+
+	return nil
+}
+
+// GetProgress returns the progress of the current or last checks run of the target, nil when there is none
+func (s *checksProgressService) GetProgress(targetID string) *models.ChecksProgress {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	progress, ok := s.progress[targetID]
+	if !ok {
+		return nil
+	}
+
+	return progress.Copy()
+}
+
+// Subscribe returns the channel receiving the progress updates of the target,
+// and the function to call to stop receiving them
+func (s *checksProgressService) Subscribe(targetID string) (<-chan *models.ChecksProgress, func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	subscriber := make(chan *models.ChecksProgress, checksProgressBufferSize)
+	if _, ok := s.subscribers[targetID]; !ok {
+		s.subscribers[targetID] = make(map[chan *models.ChecksProgress]struct{})
+	}
+	s.subscribers[targetID][subscriber] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+
+			delete(s.subscribers[targetID], subscriber)
+			if len(s.subscribers[targetID]) == 0 {
+				delete(s.subscribers, targetID)
+			}
+			close(subscriber)
+		})
+	}
+
+	return subscriber, unsubscribe
+}
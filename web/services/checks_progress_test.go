@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestChecksProgressServicePublish(t *testing.T) {
+	progressService := NewChecksProgressService()
+	assert.Nil(t, progressService.GetProgress("cluster1"))
+
+	updates, unsubscribe := progressService.Subscribe("cluster1")
+	otherUpdates, unsubscribeOther := progressService.Subscribe("cluster2")
+	defer unsubscribeOther()
+
+	err := progressService.Publish("cluster1", &models.ChecksProgressEvent{Type: models.ChecksProgressStarted})
+	assert.NoError(t, err)
+	err = progressService.Publish("cluster1", &models.ChecksProgressEvent{
+		Type: models.ChecksProgressTask, Host: "node1", CheckID: "156F64", Task: "check corosync token",
+	})
+	assert.NoError(t, err)
+
+	started := <-updates
+	assert.Equal(t, models.ChecksExecutionRunning, started.Status)
+	assert.Empty(t, started.Hosts)
+	assert.False(t, started.UpdatedAt.IsZero())
+
+	task := <-updates
+	assert.Equal(t, "156F64", task.Hosts["node1"].CheckID)
+	assert.Equal(t, task, progressService.GetProgress("cluster1"))
+	assert.Len(t, otherUpdates, 0)
+
+	unsubscribe()
+	unsubscribe()
+	_, ok := <-updates
+	assert.False(t, ok)
+
+	// a new run resets the progress
+	err = progressService.Publish("cluster1", &models.ChecksProgressEvent{Type: models.ChecksProgressStarted})
+	assert.NoError(t, err)
+	assert.Empty(t, progressService.GetProgress("cluster1").Hosts)
+}
+
+func TestChecksProgressServicePublishInvalidEvent(t *testing.T) {
+	progressService := NewChecksProgressService()
+
+	err := progressService.Publish("cluster1", &models.ChecksProgressEvent{Type: models.ChecksProgressResult, Host: "node1"})
+	assert.ErrorIs(t, err, ErrInvalidChecksProgressEvent)
+	assert.Nil(t, progressService.GetProgress("cluster1"))
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
@@ -68,7 +69,9 @@ func (suite *ChecksServiceTestSuite) SetupSuite() {
 	suite.premiumDetection.On("IsPremiumActive").Return(false, nil)
 
 	suite.db.AutoMigrate(
-		entities.Check{}, entities.ChecksResult{}, models.SelectedChecks{}, models.ConnectionSettings{})
+		entities.Check{}, entities.ChecksResult{}, models.SelectedChecks{}, models.ConnectionSettings{},
+		entities.CustomCheck{}, entities.ChecksCatalogVersion{}, entities.Cluster{}, entities.Waiver{},
+		entities.CheckParameters{})
 	loadChecksCatalogFixtures(suite.db)
 	loadChecksResultFixtures(suite.db)
 	loadSelectedChecksFixtures(suite.db)
@@ -80,6 +83,11 @@ func (suite *ChecksServiceTestSuite) TearDownSuite() {
 	suite.db.Migrator().DropTable(entities.ChecksResult{})
 	suite.db.Migrator().DropTable(models.SelectedChecks{})
 	suite.db.Migrator().DropTable(models.ConnectionSettings{})
+	suite.db.Migrator().DropTable(entities.CustomCheck{})
+	suite.db.Migrator().DropTable(entities.ChecksCatalogVersion{})
+	suite.db.Migrator().DropTable(entities.Cluster{})
+	suite.db.Migrator().DropTable(entities.Waiver{})
+	suite.db.Migrator().DropTable(entities.CheckParameters{})
 }
 
 func (suite *ChecksServiceTestSuite) SetupTest() {
@@ -203,6 +211,40 @@ func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksCatalog() {
 	suite.ElementsMatch(expectedCatalog, catalog)
 }
 
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksCatalogWithCustomChecks() {
+	suite.tx.Create(&entities.CustomCheck{
+		ID:             "custom1",
+		Version:        2,
+		Name:           "name15",
+		Group:          "custom",
+		Description:    "custom description",
+		Implementation: "- debug: msg=custom",
+	})
+	suite.tx.Create(&entities.CustomCheck{
+		ID:    "check1",
+		Name:  "shadowed",
+		Group: "custom",
+	})
+
+	catalog, err := suite.checksService.GetChecksCatalog()
+	suite.NoError(err)
+
+	ids := []string{}
+	for _, check := range catalog {
+		ids = append(ids, check.ID)
+	}
+	suite.Equal([]string{"check1", "custom1", "check2", "check3"}, ids)
+	suite.Equal(&models.Check{
+		ID:             "custom1",
+		Name:           "name15",
+		Group:          "custom",
+		Description:    "custom description",
+		Implementation: "- debug: msg=custom",
+		Custom:         true,
+	}, catalog[1])
+	suite.Equal("name1", catalog[0].Name)
+}
+
 func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksCatalogByGroup() {
 	catalog, err := suite.checksService.GetChecksCatalogByGroup()
 	expectedCatalog := models.GroupedCheckList{
@@ -319,6 +361,62 @@ func (suite *ChecksServiceTestSuite) TestChecksService_CreateChecksCatalog() {
 	suite.Equal(int64(2), count)
 }
 
+func (suite *ChecksServiceTestSuite) TestChecksService_CreateChecksCatalogVersions() {
+	catalog := models.ChecksCatalog{
+		{ID: "check1", Name: "name1", Group: "group1", Description: "description1"},
+		{ID: "check2", Name: "name2", Group: "group1", Description: "updated description"},
+		{ID: "check4", Name: "name4", Group: "group2", Description: "description4", Supersedes: []string{"check3"}},
+	}
+
+	suite.NoError(suite.checksService.CreateChecksCatalog(catalog))
+
+	versions, err := suite.checksService.GetChecksCatalogVersions(&Page{Number: 1, Size: 10})
+	suite.NoError(err)
+	suite.Len(versions, 1)
+	suite.Equal([]string{"check4"}, versions[0].Added)
+	suite.Equal([]string{"check3"}, versions[0].Removed)
+	suite.Equal([]string{"check2"}, versions[0].Changed)
+	suite.Equal(map[string]string{"check3": "check4"}, versions[0].Superseded)
+	suite.Nil(versions[0].Catalog)
+
+	version, err := suite.checksService.GetChecksCatalogVersion(versions[0].ID)
+	suite.NoError(err)
+	suite.Equal(catalog, version.Catalog)
+
+	// the selections of the superseded check are migrated, without duplicates
+	selectedChecks, err := suite.checksService.GetSelectedChecksById("group2")
+	suite.NoError(err)
+	suite.Equal([]string{"check4", "check1"}, []string(selectedChecks.SelectedChecks))
+	suite.Empty(selectedChecks.RemovedChecks)
+
+	selectedChecks, err = suite.checksService.GetSelectedChecksById("group3")
+	suite.NoError(err)
+	suite.Equal([]string{"check2", "check4"}, []string(selectedChecks.SelectedChecks))
+
+	// publishing the same catalog again doesn't create a new version
+	catalog[0].Supersedes = []string{}
+	suite.NoError(suite.checksService.CreateChecksCatalog(catalog))
+
+	versions, err = suite.checksService.GetChecksCatalogVersions(&Page{Number: 1, Size: 10})
+	suite.NoError(err)
+	suite.Len(versions, 1)
+
+	_, err = suite.checksService.GetChecksCatalogVersion(versions[0].ID + 1)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_GetSelectedChecksByIdRemovedChecks() {
+	suite.NoError(suite.checksService.CreateChecksCatalog(models.ChecksCatalog{
+		{ID: "check2", Name: "name2", Group: "group1", Description: "description2"},
+	}))
+
+	selectedChecks, err := suite.checksService.GetSelectedChecksById("group1")
+
+	suite.NoError(err)
+	suite.Equal([]string{"check2"}, []string(selectedChecks.SelectedChecks))
+	suite.Equal([]string{"check1"}, selectedChecks.RemovedChecks)
+}
+
 func (suite *ChecksServiceTestSuite) TestChecksService_GetLastExecutionByGroup() {
 	results, err := suite.checksService.GetLastExecutionByGroup()
 
@@ -431,6 +529,49 @@ func (suite *ChecksServiceTestSuite) TestChecksService_GetAggregatedChecksResult
 	suite.Equal(expectedResults, results)
 }
 
+func (suite *ChecksServiceTestSuite) TestChecksService_Waivers() {
+	suite.tx.Create(&entities.Cluster{ID: "group2", SID: "PRD"})
+	suite.tx.Create(&entities.Waiver{
+		CheckID: "check2", Scope: models.WaiverScopeHost, Target: "host2",
+		Owner: "admin", Justification: "accepted", ExpiresAt: time.Now().Add(time.Hour),
+	})
+	suite.tx.Create(&entities.Waiver{
+		CheckID: "check1", Scope: models.WaiverScopeSID, Target: "PRD",
+		Owner: "admin", Justification: "accepted", ExpiresAt: time.Now().Add(time.Hour),
+	})
+	suite.tx.Create(&entities.Waiver{
+		CheckID: "check2", Scope: models.WaiverScopeCluster, Target: "group1",
+		Owner: "admin", Justification: "expired", ExpiresAt: time.Now().Add(-time.Hour),
+	})
+
+	aggregated, err := suite.checksService.GetAggregatedChecksResultByCluster("group1")
+	suite.NoError(err)
+	suite.Equal(&models.AggregatedCheckData{PassingCount: 2, WarningCount: 1}, aggregated)
+
+	results, err := suite.checksService.GetChecksResultAndMetadataByCluster("group1")
+	suite.NoError(err)
+	for _, check := range results.Checks {
+		if check.ID == "check2" {
+			suite.Equal(models.CheckWaived, check.Hosts["host2"].Result)
+			suite.Equal(models.CheckCritical, check.Hosts["host2"].WaivedResult)
+			suite.Equal(models.CheckWarning, check.Hosts["host1"].Result)
+		}
+	}
+
+	stored, err := suite.checksService.GetChecksResultByCluster("group1")
+	suite.NoError(err)
+	suite.Equal(models.CheckCritical, stored.Checks["check2"].Hosts["host2"].Result)
+
+	lastResults, err := suite.checksService.GetLastExecutionByGroup()
+	suite.NoError(err)
+	suite.Len(lastResults, 2)
+	for _, lastResult := range lastResults {
+		if lastResult.ID == "group2" {
+			suite.Equal(&models.AggregatedCheckData{PassingCount: 1, WarningCount: 1}, lastResult.GetAggregatedChecksResultByCluster())
+		}
+	}
+}
+
 func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksResultAndMetadataByCluster() {
 	results, err := suite.checksService.GetChecksResultAndMetadataByCluster("group1")
 
@@ -479,6 +620,48 @@ func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksResultAndMetadat
 	suite.Equal(expectedResults, results)
 }
 
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksResultsHistoryByCluster() {
+	history, err := suite.checksService.GetChecksResultsHistoryByCluster("group1", nil)
+
+	suite.NoError(err)
+	suite.Len(history, 2)
+	suite.Greater(history[0].ID, history[1].ID)
+	suite.Equal(models.CheckCritical, history[0].Health)
+	suite.Equal(&models.AggregatedCheckData{PassingCount: 2, WarningCount: 1, CriticalCount: 1}, history[0].Aggregated)
+	suite.Equal(&models.AggregatedCheckData{CriticalCount: 4}, history[1].Aggregated)
+
+	history, err = suite.checksService.GetChecksResultsHistoryByCluster("group1", &Page{Number: 2, Size: 1})
+
+	suite.NoError(err)
+	suite.Len(history, 1)
+	suite.Equal(&models.AggregatedCheckData{CriticalCount: 4}, history[0].Aggregated)
+
+	history, err = suite.checksService.GetChecksResultsHistoryByCluster("other", nil)
+
+	suite.NoError(err)
+	suite.Empty(history)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksResultsDiff() {
+	history, _ := suite.checksService.GetChecksResultsHistoryByCluster("group1", nil)
+
+	diff, err := suite.checksService.GetChecksResultsDiff("group1", history[1].ID, history[0].ID)
+
+	suite.NoError(err)
+	suite.Equal(history[1].ID, diff.From.ID)
+	suite.Equal(history[0].ID, diff.To.ID)
+	suite.Equal([]*models.CheckResultChange{
+		{CheckID: "check1", Description: "description1", Host: "host1", From: "critical", To: "passing"},
+		{CheckID: "check1", Description: "description1", Host: "host2", From: "critical", To: "passing"},
+		{CheckID: "check2", Description: "description2", Host: "host1", From: "critical", To: "warning"},
+	}, diff.Changes)
+
+	groups, _ := suite.checksService.GetChecksResultsHistoryByCluster("group2", nil)
+	_, err = suite.checksService.GetChecksResultsDiff("group1", history[1].ID, groups[0].ID)
+
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
 func (suite *ChecksServiceTestSuite) TestChecksService_GetSelectedChecksById() {
 	selectedChecks, err := suite.checksService.GetSelectedChecksById("group1")
 
@@ -525,6 +708,61 @@ func (suite *ChecksServiceTestSuite) TestChecksService_CreateSelectedChecks() {
 	suite.Equal(expectedValue, selectedChecks)
 }
 
+func (suite *ChecksServiceTestSuite) TestChecksService_CheckParameters() {
+	parameters, err := suite.checksService.GetCheckParameters(models.CheckParametersClusterScope, "group1")
+	suite.NoError(err)
+	suite.Equal(map[string]string{}, parameters)
+
+	err = suite.checksService.CreateCheckParameters(
+		models.CheckParametersTagScope, "azure", map[string]string{"1.1.1": "30000", "1.1.2": "36000"})
+	suite.NoError(err)
+	err = suite.checksService.CreateCheckParameters(
+		models.CheckParametersTagScope, "production", map[string]string{"1.1.2": "40000", "1.1.3": "20"})
+	suite.NoError(err)
+	err = suite.checksService.CreateCheckParameters(
+		models.CheckParametersClusterScope, "group1", map[string]string{"1.1.3": "30"})
+	suite.NoError(err)
+
+	parameters, err = suite.checksService.GetCheckParameters(models.CheckParametersTagScope, "azure")
+	suite.NoError(err)
+	suite.Equal(map[string]string{"1.1.1": "30000", "1.1.2": "36000"}, parameters)
+
+	parameters, err = suite.checksService.GetClusterCheckParameters("group1", []string{"production", "azure"})
+	suite.NoError(err)
+	suite.Equal(map[string]string{"1.1.1": "30000", "1.1.2": "40000", "1.1.3": "30"}, parameters)
+
+	parameters, err = suite.checksService.GetClusterCheckParameters("group2", nil)
+	suite.NoError(err)
+	suite.Equal(map[string]string{}, parameters)
+
+	err = suite.checksService.CreateCheckParameters(models.CheckParametersClusterScope, "group1", map[string]string{})
+	suite.NoError(err)
+
+	parameters, err = suite.checksService.GetClusterCheckParameters("group1", []string{"azure"})
+	suite.NoError(err)
+	suite.Equal(map[string]string{"1.1.1": "30000", "1.1.2": "36000"}, parameters)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_CreateCheckParametersInvalid() {
+	for _, c := range []struct {
+		scope      string
+		target     string
+		parameters map[string]string
+	}{
+		{"host", "host1", map[string]string{"1.1.1": "30000"}},
+		{models.CheckParametersClusterScope, "", map[string]string{"1.1.1": "30000"}},
+		{models.CheckParametersClusterScope, "group1", map[string]string{"1.1.1 ": "30000"}},
+		{models.CheckParametersClusterScope, "group1", map[string]string{"1.1.1": "30'000"}},
+		{models.CheckParametersClusterScope, "group1", map[string]string{"1.1.1": "{{ lookup(\"env\", \"HOME\") }}"}},
+		{models.CheckParametersClusterScope, "group1", map[string]string{"1.1.1": "{{ansible_env}}"}},
+		{models.CheckParametersTagScope, "production", map[string]string{"1.1.1": "{% if true %}1{% endif %}"}},
+		{models.CheckParametersTagScope, "production", map[string]string{"1.1.1": "30000{# comment #}"}},
+	} {
+		err := suite.checksService.CreateCheckParameters(c.scope, c.target, c.parameters)
+		suite.ErrorIs(err, ErrInvalidCheckParameters, c.parameters)
+	}
+}
+
 func (suite *ChecksServiceTestSuite) TestChecksService_GetConnectionSettingsByNode() {
 	data, err := suite.checksService.GetConnectionSettingsByNode("node1")
 
@@ -569,7 +807,7 @@ func (suite *ChecksServiceTestSuite) TestChecksService_GetConnectionSettingsById
 }
 
 func (suite *ChecksServiceTestSuite) TestChecksService_CreateConnectionSettings() {
-	err := suite.checksService.CreateConnectionSettings("group4", "node4", "user4")
+	err := suite.checksService.CreateConnectionSettings(models.ConnectionSettings{ID: "group4", Node: "node4", User: "user4"})
 
 	var data models.ConnectionSettings
 
@@ -580,11 +818,48 @@ func (suite *ChecksServiceTestSuite) TestChecksService_CreateConnectionSettings(
 	suite.Equal(expectedValue, data)
 
 	// Check if an update works
-	err = suite.checksService.CreateConnectionSettings("group4", "node4", "user5")
+	profile := models.ConnectionSettings{
+		ID:           "group4",
+		Node:         "node4",
+		User:         "user5",
+		Port:         2222,
+		PrivateKey:   "/etc/trento/keys/node4",
+		ProxyJump:    "admin@bastion:22",
+		BecomeMethod: "sudo",
+		BecomeUser:   "root",
+	}
+	err = suite.checksService.CreateConnectionSettings(profile)
 
 	suite.tx.Where("id", "group4").First(&data)
-	expectedValue = models.ConnectionSettings{ID: "group4", Node: "node4", User: "user5"}
 
 	suite.NoError(err)
-	suite.Equal(expectedValue, data)
+	suite.Equal(profile, data)
+
+	// the cluster profile has no node
+	err = suite.checksService.CreateConnectionSettings(models.ConnectionSettings{
+		ID: "group4", Node: models.ClusterConnectionNode, Port: 2200,
+	})
+	suite.NoError(err)
+
+	settings, err := suite.checksService.GetConnectionSettingsById("group4")
+	suite.NoError(err)
+	suite.Len(settings, 2)
+	suite.Equal(2200, settings[models.ClusterConnectionNode].Port)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_CreateConnectionSettingsInvalid() {
+	for _, settings := range []models.ConnectionSettings{
+		{ID: "group4", Node: "node4", Port: 70000},
+		{ID: "group4", Node: "node4", BecomeMethod: "runas"},
+		{ID: "group4", Node: "node4", User: "two words"},
+		{ID: "group4", Node: "node4", ProxyJump: "'bastion'"},
+		{ID: "group4", Node: "node4", User: "{{lookup(pipe,id)}}"},
+		{ID: "group4", Node: "node4", PrivateKey: "/etc/trento/{%if%}key"},
+		{ID: "group4", Node: "node4", ProxyJump: "admin@{{bastion}}"},
+		{ID: "group4", Node: "node4", BecomeUser: "root{#comment#}"},
+		{ID: "group4", Node: models.ClusterConnectionNode, User: "{{ansible_env}}"},
+	} {
+		err := suite.checksService.CreateConnectionSettings(settings)
+		suite.ErrorIs(err, ErrInvalidConnectionSettings, settings)
+	}
 }
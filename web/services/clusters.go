@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 
-	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 	"github.com/trento-project/trento/internal"
 	"github.com/trento-project/trento/internal/cloud"
@@ -184,7 +183,7 @@ func (s *clustersService) GetAllClusterTypes() ([]string, error) {
 }
 
 func (s *clustersService) GetAllSIDs() ([]string, error) {
-	var sids pq.StringArray
+	var sids []string
 
 	err := s.db.Model(&entities.Cluster{}).
 		Distinct().
@@ -197,7 +196,7 @@ func (s *clustersService) GetAllSIDs() ([]string, error) {
 		return nil, err
 	}
 
-	return []string(sids), nil
+	return sids, nil
 }
 
 func (s *clustersService) GetAllTags() ([]string, error) {
@@ -223,6 +222,7 @@ func (s *clustersService) GetAllClustersSettings() (models.ClustersSettings, err
 
 	err := s.db.
 		Preload("Hosts").
+		Preload("Tags").
 		Find(&clusters).
 		Error
 
@@ -262,6 +262,7 @@ func (s *clustersService) GetClusterSettingsByID(id string) (*models.ClusterSett
 
 	err := s.db.
 		Preload("Hosts").
+		Preload("Tags").
 		Where("id = ?", id).
 		First(&cluster).
 		Error
@@ -277,46 +278,83 @@ func (s *clustersService) GetClusterSettingsByID(id string) (*models.ClusterSett
 }
 
 func (s *clustersService) loadSettings(cluster *entities.Cluster) (*models.ClusterSettings, error) {
+	var tags []string
+	for _, tag := range cluster.Tags {
+		tags = append(tags, tag.Value)
+	}
+
+	env, err := getCheckTargetEnvironment(s.db, cluster, cluster.Hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadCheckTargetSettings(s.checksService, cluster.ID, models.CheckTargetCluster, cluster.Hosts, tags, env)
+}
+
+// loadCheckTargetSettings builds the settings of a checks target from its selected checks, check parameters
+// and the connection profiles of its hosts. The selected checks not applying to the environment are set apart
+func loadCheckTargetSettings(
+	checksService ChecksService, id string, targetType string, targetHosts []*entities.Host, tags []string,
+	env *models.CheckEnvironment,
+) (*models.ClusterSettings, error) {
 	var hosts []*models.HostConnection
 
-	selectedChecks, err := s.checksService.GetSelectedChecksById(cluster.ID)
+	selectedChecks, err := checksService.GetSelectedChecksById(id)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	applicableChecks, notApplicableChecks, err := splitApplicableChecks(checksService, selectedChecks.SelectedChecks, env)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	connectionSettings, err := checksService.GetConnectionSettingsById(id)
 	if err != nil {
 		log.Error(err)
 		return nil, err
 	}
 
-	connectionSettings, err := s.checksService.GetConnectionSettingsById(cluster.ID)
+	checkParameters, err := checksService.GetClusterCheckParameters(id, tags)
 	if err != nil {
 		log.Error(err)
 		return nil, err
 	}
 
-	for _, host := range cluster.Hosts {
-		var username string
+	clusterConnectionSettings := connectionSettings[models.ClusterConnectionNode]
 
-		hostConnectionSettings, found := connectionSettings[host.Name]
-		if found {
-			username = hostConnectionSettings.User
-		}
+	for _, host := range targetHosts {
+		hostConnectionSettings := connectionSettings[host.Name].WithDefaults(clusterConnectionSettings)
 
-		if username == "" {
-			username, err = getDefaultUserName(host)
+		if hostConnectionSettings.User == "" {
+			hostConnectionSettings.User, err = getDefaultUserName(host)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		hosts = append(hosts, &models.HostConnection{
-			Name:    host.Name,
-			Address: host.SSHAddress,
-			User:    username,
+			Name:         host.Name,
+			Address:      host.SSHAddress,
+			User:         hostConnectionSettings.User,
+			Port:         hostConnectionSettings.Port,
+			PrivateKey:   hostConnectionSettings.PrivateKey,
+			ProxyJump:    hostConnectionSettings.ProxyJump,
+			BecomeMethod: hostConnectionSettings.BecomeMethod,
+			BecomeUser:   hostConnectionSettings.BecomeUser,
 		})
 	}
 
 	return &models.ClusterSettings{
-		ID:             cluster.ID,
-		SelectedChecks: selectedChecks.SelectedChecks,
-		Hosts:          hosts,
+		ID:                  id,
+		TargetType:          targetType,
+		SelectedChecks:      applicableChecks,
+		RemovedChecks:       selectedChecks.RemovedChecks,
+		NotApplicableChecks: notApplicableChecks,
+		Hosts:               hosts,
+		CheckParameters:     checkParameters,
 	}, nil
 }
 
@@ -4,8 +4,8 @@ import (
 	"encoding/json"
 	"testing"
 
-	"github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
+	trentoDB "github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -27,12 +27,12 @@ func TestClustersServiceTestSuite(t *testing.T) {
 func (suite *ClustersServiceTestSuite) SetupSuite() {
 	suite.db = helpers.SetupTestDatabase(suite.T())
 
-	suite.db.AutoMigrate(entities.Cluster{}, entities.Host{}, models.Tag{}, models.SelectedChecks{}, models.ConnectionSettings{}, entities.ChecksResult{})
+	suite.db.AutoMigrate(entities.Cluster{}, entities.Host{}, models.Tag{}, models.SelectedChecks{}, entities.HostTelemetry{}, models.ConnectionSettings{}, entities.ChecksResult{})
 	loadClustersFixtures(suite.db)
 }
 
 func (suite *ClustersServiceTestSuite) TearDownSuite() {
-	suite.db.Migrator().DropTable(entities.Cluster{}, entities.Host{}, models.Tag{}, models.SelectedChecks{}, models.ConnectionSettings{}, entities.ChecksResult{})
+	suite.db.Migrator().DropTable(entities.Cluster{}, entities.Host{}, models.Tag{}, models.SelectedChecks{}, entities.HostTelemetry{}, models.ConnectionSettings{}, entities.ChecksResult{})
 }
 
 func (suite *ClustersServiceTestSuite) SetupTest() {
@@ -102,7 +102,7 @@ func loadClustersFixtures(db *gorm.DB) {
 				SSHAddress:  "10.74.2.11",
 				ClusterID:   "2",
 				Name:        "host2",
-				IPAddresses: pq.StringArray{"10.74.1.11"},
+				IPAddresses: trentoDB.StringArray{"10.74.1.11"},
 			},
 		},
 	})
@@ -131,7 +131,7 @@ func loadClustersFixtures(db *gorm.DB) {
 				SSHAddress:    "10.74.2.12",
 				ClusterID:     "3",
 				Name:          "host3",
-				IPAddresses:   pq.StringArray{"10.74.1.12"},
+				IPAddresses:   trentoDB.StringArray{"10.74.1.12"},
 				CloudProvider: "azure",
 				CloudData:     cloudData,
 			},
@@ -303,15 +303,13 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetAllSIDs() {
 func (suite *ClustersServiceTestSuite) TestClustersService_GetAllClustersSettingsReturnsNoSettings() {
 	mockPremiumDetection := new(MockPremiumDetectionService)
 
-	tx := suite.tx.Raw("TRUNCATE TABLE clusters")
-	checksService := NewChecksService(tx, mockPremiumDetection)
-	suite.clustersService = NewClustersService(tx, checksService)
+	suite.NoError(trentoDB.TruncateTable(suite.tx, "clusters"))
+	checksService := NewChecksService(suite.tx, mockPremiumDetection)
+	suite.clustersService = NewClustersService(suite.tx, checksService)
 
 	clustersSettings, err := suite.clustersService.GetAllClustersSettings()
 	suite.NoError(err)
 	suite.Empty(clustersSettings)
-
-	tx.Rollback()
 }
 
 func (suite *ClustersServiceTestSuite) TestClustersService_GetAllClustersSettingsReturnsExpectedSettings() {
@@ -336,10 +334,19 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetAllClustersSetting
 		},
 	}, nil)
 	suite.checksService.On("GetConnectionSettingsById", "2").Return(map[string]models.ConnectionSettings{
+		models.ClusterConnectionNode: {
+			ID:           "2",
+			Node:         models.ClusterConnectionNode,
+			User:         "clusteruser",
+			Port:         2222,
+			ProxyJump:    "bastion",
+			BecomeMethod: "sudo",
+		},
 		"host2": {
-			ID:   "2",
-			Node: "host2",
-			User: "root",
+			ID:         "2",
+			Node:       "host2",
+			User:       "root",
+			PrivateKey: "/etc/trento/keys/host2",
 		},
 	}, nil)
 	suite.checksService.On("GetConnectionSettingsById", "3").Return(map[string]models.ConnectionSettings{
@@ -350,6 +357,11 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetAllClustersSetting
 		},
 	}, nil)
 
+	suite.checksService.On("GetClusterCheckParameters", "1", []string{"tag1"}).Return(map[string]string{"1.1.1": "5000"}, nil)
+	suite.checksService.On("GetClusterCheckParameters", "2", []string{"tag2"}).Return(map[string]string{}, nil)
+	suite.checksService.On("GetClusterCheckParameters", "3", []string{"tag3"}).Return(map[string]string{}, nil)
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{{ID: "A"}, {ID: "B"}, {ID: "C"}}, nil)
+
 	clustersSettings, err := suite.clustersService.GetAllClustersSettings()
 	suite.NoError(err)
 	suite.NotEmpty(clustersSettings)
@@ -358,6 +370,7 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetAllClustersSetting
 	suite.EqualValues(models.ClustersSettings{
 		{
 			ID:             "1",
+			TargetType:     models.CheckTargetCluster,
 			SelectedChecks: []string{"A", "B", "C"},
 			Hosts: []*models.HostConnection{
 				{
@@ -366,20 +379,28 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetAllClustersSetting
 					User:    "theuser",
 				},
 			},
+			CheckParameters: map[string]string{"1.1.1": "5000"},
 		},
 		{
 			ID:             "2",
+			TargetType:     models.CheckTargetCluster,
 			SelectedChecks: []string{},
 			Hosts: []*models.HostConnection{
 				{
-					Name:    "host2",
-					Address: "10.74.2.11",
-					User:    "root",
+					Name:         "host2",
+					Address:      "10.74.2.11",
+					User:         "root",
+					Port:         2222,
+					PrivateKey:   "/etc/trento/keys/host2",
+					ProxyJump:    "bastion",
+					BecomeMethod: "sudo",
 				},
 			},
+			CheckParameters: map[string]string{},
 		},
 		{
 			ID:             "3",
+			TargetType:     models.CheckTargetCluster,
 			SelectedChecks: []string{},
 			Hosts: []*models.HostConnection{
 				{
@@ -388,6 +409,7 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetAllClustersSetting
 					User:    "cloudadmin",
 				},
 			},
+			CheckParameters: map[string]string{},
 		},
 	}, clustersSettings)
 }
@@ -405,11 +427,20 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetClusterSettings()
 		},
 	}, nil)
 
+	suite.checksService.On("GetClusterCheckParameters", "1", []string{"tag1"}).Return(map[string]string{"1.1.1": "5000"}, nil)
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{
+		{ID: "A", Applicability: &models.CheckApplicability{ClusterTypes: []string{models.ClusterTypeHANAScaleUp}}},
+		{ID: "B", Applicability: &models.CheckApplicability{OSVersions: []string{"15"}}},
+		{ID: "C", Applicability: &models.CheckApplicability{ClusterTypes: []string{models.ClusterTypeHANAScaleOut}}},
+	}, nil)
+	suite.tx.Create(&entities.HostTelemetry{AgentID: "1", SLESVersion: "15.3"})
+
 	clusterSettings, err := suite.clustersService.GetClusterSettingsByID("1")
 	suite.NoError(err)
 
 	suite.EqualValues("1", clusterSettings.ID)
-	suite.EqualValues([]string{"A", "B", "C"}, clusterSettings.SelectedChecks)
+	suite.EqualValues([]string{"A", "B"}, clusterSettings.SelectedChecks)
+	suite.EqualValues([]string{"C"}, clusterSettings.NotApplicableChecks)
 	suite.EqualValues([]*models.HostConnection{
 		{
 			Name:    "host1",
@@ -417,6 +448,7 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetClusterSettings()
 			User:    "theuser",
 		},
 	}, clusterSettings.Hosts)
+	suite.EqualValues(map[string]string{"1.1.1": "5000"}, clusterSettings.CheckParameters)
 }
 
 func (suite *ClustersServiceTestSuite) TestClustersService_GetClusterSettings_NotFound() {
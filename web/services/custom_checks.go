@@ -0,0 +1,321 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var (
+	ErrInvalidCustomCheck  = errors.New("invalid custom check")
+	ErrCustomCheckConflict = errors.New("the check id is already used")
+)
+
+// the id names the ansible role of the check, so it must be a valid directory name
+var customCheckIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// The custom checks are run as root on the cluster nodes, so they can only use the modules reading
+// the state of the hosts, and the keywords not changing where and how the tasks are run
+var (
+	customCheckModules = map[string]bool{
+		"assert":        true,
+		"debug":         true,
+		"fail":          true,
+		"getent":        true,
+		"package_facts": true,
+		"service_facts": true,
+		"set_fact":      true,
+		"setup":         true,
+		"slurp":         true,
+		"stat":          true,
+	}
+	customCheckKeywords = map[string]bool{
+		"name":          true,
+		"register":      true,
+		"when":          true,
+		"changed_when":  true,
+		"failed_when":   true,
+		"ignore_errors": true,
+		"vars":          true,
+		"tags":          true,
+		"no_log":        true,
+		"loop":          true,
+		"loop_control":  true,
+		"with_items":    true,
+		"until":         true,
+		"retries":       true,
+		"delay":         true,
+	}
+	// the roles a custom check can import, to report its result
+	customCheckRoles = map[string]bool{
+		"post-results": true,
+	}
+	forbiddenCustomCheckKeyword = regexp.MustCompile(`^(become.*|check_mode|diff|delegate_to|delegate_facts|local_action|connection|environment|remote_user|run_once)$`)
+	// the lookups are run on the runner, and the python internals reached from the templates can run any code
+	forbiddenCustomCheckTemplate = regexp.MustCompile(`\b(lookup|query|q)\s*\(|__`)
+)
+
+//go:generate mockery --name=CustomChecksService --inpackage --filename=custom_checks_mock.go
+
+type CustomChecksService interface {
+	GetAll() ([]*models.CustomCheck, error)
+	GetByID(id string) (*models.CustomCheck, error)
+	GetVersions(id string) ([]*models.CustomCheck, error)
+	Create(check *models.CustomCheck) (*models.CustomCheck, error)
+	Update(check *models.CustomCheck) (*models.CustomCheck, error)
+	Delete(id string) error
+}
+
+type customChecksService struct {
+	db *gorm.DB
+}
+
+func NewCustomChecksService(db *gorm.DB) *customChecksService {
+	return &customChecksService{db: db}
+}
+
+func (s *customChecksService) GetAll() ([]*models.CustomCheck, error) {
+	var customChecks []*entities.CustomCheck
+	if err := s.db.Order("name").Find(&customChecks).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.CustomCheck, 0, len(customChecks))
+	for _, customCheck := range customChecks {
+		result = append(result, customCheck.ToModel())
+	}
+
+	return result, nil
+}
+
+// GetByID returns the custom check, gorm.ErrRecordNotFound is returned when it does not exist
+func (s *customChecksService) GetByID(id string) (*models.CustomCheck, error) {
+	var customCheck entities.CustomCheck
+	if err := s.db.Where("id = ?", id).First(&customCheck).Error; err != nil {
+		return nil, err
+	}
+
+	return customCheck.ToModel(), nil
+}
+
+// GetVersions returns every revision of the custom check, the newest first
+func (s *customChecksService) GetVersions(id string) ([]*models.CustomCheck, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	var versions []*entities.CustomCheckVersion
+	if err := s.db.Where("check_id = ?", id).Order("version DESC").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.CustomCheck, 0, len(versions))
+	for _, version := range versions {
+		check, err := version.ToModel()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, check)
+	}
+
+	return result, nil
+}
+
+// Create stores a new custom check as its first version.
+// The id must not be used by another custom check nor by a check of the built-in catalog
+func (s *customChecksService) Create(check *models.CustomCheck) (*models.CustomCheck, error) {
+	if err := validateCustomCheck(check); err != nil {
+		return nil, err
+	}
+
+	customCheck := &entities.CustomCheck{
+		ID:             check.ID,
+		Version:        1,
+		Name:           check.Name,
+		Group:          check.Group,
+		Description:    check.Description,
+		Remediation:    check.Remediation,
+		Labels:         check.Labels,
+		Implementation: check.Implementation,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entities.CustomCheck{}).Where("id = ?", check.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			if err := tx.Model(&entities.Check{}).Where("id = ?", check.ID).Count(&count).Error; err != nil {
+				return err
+			}
+		}
+		if count > 0 {
+			return ErrCustomCheckConflict
+		}
+
+		if err := tx.Create(customCheck).Error; err != nil {
+			return err
+		}
+
+		return storeCustomCheckVersion(tx, customCheck)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return customCheck.ToModel(), nil
+}
+
+// Update stores a new version of an existing custom check
+func (s *customChecksService) Update(check *models.CustomCheck) (*models.CustomCheck, error) {
+	if err := validateCustomCheck(check); err != nil {
+		return nil, err
+	}
+
+	var customCheck entities.CustomCheck
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", check.ID).First(&customCheck).Error; err != nil {
+			return err
+		}
+
+		customCheck.Version++
+		customCheck.Name = check.Name
+		customCheck.Group = check.Group
+		customCheck.Description = check.Description
+		customCheck.Remediation = check.Remediation
+		customCheck.Labels = check.Labels
+		customCheck.Implementation = check.Implementation
+
+		if err := tx.Save(&customCheck).Error; err != nil {
+			return err
+		}
+
+		return storeCustomCheckVersion(tx, &customCheck)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return customCheck.ToModel(), nil
+}
+
+// Delete removes the custom check and its versions. The clusters selecting it stop running it,
+// as the selections are filtered by the catalog
+func (s *customChecksService) Delete(id string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&entities.CustomCheck{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Where("check_id = ?", id).Delete(&entities.CustomCheckVersion{}).Error
+	})
+}
+
+func storeCustomCheckVersion(tx *gorm.DB, customCheck *entities.CustomCheck) error {
+	payload, err := json.Marshal(customCheck.ToModel())
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&entities.CustomCheckVersion{
+		CheckID: customCheck.ID,
+		Version: customCheck.Version,
+		Payload: payload,
+	}).Error
+}
+
+func validateCustomCheck(check *models.CustomCheck) error {
+	if !customCheckIDPattern.MatchString(check.ID) {
+		return fmt.Errorf("%w: the id must only contain letters, digits, dashes and underscores", ErrInvalidCustomCheck)
+	}
+	if check.Name == "" {
+		return fmt.Errorf("%w: the name is required", ErrInvalidCustomCheck)
+	}
+	if check.Group == "" {
+		return fmt.Errorf("%w: the group is required", ErrInvalidCustomCheck)
+	}
+
+	return ValidateCustomCheckImplementation(check.Implementation)
+}
+
+// ValidateCustomCheckImplementation checks that the implementation is a list of ansible tasks
+// only reading the state of the hosts, see customCheckModules and customCheckKeywords
+func ValidateCustomCheckImplementation(implementation string) error {
+	var tasks []map[string]interface{}
+	if err := yaml.Unmarshal([]byte(implementation), &tasks); err != nil {
+		return fmt.Errorf("%w: the implementation must be a list of ansible tasks: %s", ErrInvalidCustomCheck, err)
+	}
+	if len(tasks) == 0 {
+		return fmt.Errorf("%w: the implementation must contain at least one ansible task", ErrInvalidCustomCheck)
+	}
+
+	if match := forbiddenCustomCheckTemplate.FindString(implementation); match != "" {
+		return fmt.Errorf("%w: %s is not allowed in the implementation", ErrInvalidCustomCheck, match)
+	}
+
+	return validateCustomCheckTasks(tasks)
+}
+
+func validateCustomCheckTasks(tasks []map[string]interface{}) error {
+	for _, task := range tasks {
+		modules := 0
+
+		for key, value := range task {
+			switch {
+			case forbiddenCustomCheckKeyword.MatchString(key):
+				return fmt.Errorf("%w: the %s keyword is not allowed", ErrInvalidCustomCheck, key)
+			case customCheckKeywords[key]:
+				continue
+			case key == "block" || key == "rescue" || key == "always":
+				var blockTasks []map[string]interface{}
+				if err := decodeTaskValue(value, &blockTasks); err != nil {
+					return fmt.Errorf("%w: the %s must be a list of ansible tasks", ErrInvalidCustomCheck, key)
+				}
+				if err := validateCustomCheckTasks(blockTasks); err != nil {
+					return err
+				}
+			case key == "import_role" || key == "include_role":
+				var role struct {
+					Name string `yaml:"name"`
+				}
+				if err := decodeTaskValue(value, &role); err != nil || !customCheckRoles[role.Name] {
+					return fmt.Errorf("%w: only the post-results role can be imported", ErrInvalidCustomCheck)
+				}
+				modules++
+			case customCheckModules[strings.TrimPrefix(key, "ansible.builtin.")]:
+				modules++
+			default:
+				return fmt.Errorf("%w: the %s module is not allowed, only the modules reading the hosts state can be used", ErrInvalidCustomCheck, key)
+			}
+		}
+
+		if modules > 1 {
+			return fmt.Errorf("%w: a task can only use one module", ErrInvalidCustomCheck)
+		}
+	}
+
+	return nil
+}
+
+// decodeTaskValue decodes a value of a parsed task into a typed one
+func decodeTaskValue(value interface{}, out interface{}) error {
+	content, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(content, out)
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+const customCheckImplementation = `
+- name: "{{ name }}.check"
+  slurp:
+    src: /etc/custom.conf
+  register: config
+
+- block:
+    - name: Post results
+      import_role:
+        name: post-results
+  vars:
+    status: "{{ config.content | b64decode is search('^max_connections', multiline=True) }}"
+`
+
+type CustomChecksServiceTestSuite struct {
+	suite.Suite
+	db                  *gorm.DB
+	tx                  *gorm.DB
+	customChecksService *customChecksService
+}
+
+func TestCustomChecksServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CustomChecksServiceTestSuite))
+}
+
+func (suite *CustomChecksServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Check{}, entities.CustomCheck{}, entities.CustomCheckVersion{})
+}
+
+func (suite *CustomChecksServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Check{}, entities.CustomCheck{}, entities.CustomCheckVersion{})
+}
+
+func (suite *CustomChecksServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.customChecksService = NewCustomChecksService(suite.tx)
+
+	suite.tx.Create(&entities.Check{ID: "156F64", Payload: datatypes.JSON(`{"id":"156F64","name":"1.1.1"}`)})
+}
+
+func (suite *CustomChecksServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func newCustomCheck(id string) *models.CustomCheck {
+	return &models.CustomCheck{
+		ID:             id,
+		Name:           "9.1.1",
+		Group:          "Site",
+		Description:    "max_connections is configured",
+		Implementation: customCheckImplementation,
+	}
+}
+
+func (suite *CustomChecksServiceTestSuite) TestCustomChecksService_Create() {
+	created, err := suite.customChecksService.Create(newCustomCheck("SITE01"))
+	suite.NoError(err)
+	suite.Equal(1, created.Version)
+	suite.Equal("9.1.1", created.Name)
+
+	stored, err := suite.customChecksService.GetByID("SITE01")
+	suite.NoError(err)
+	suite.Equal(customCheckImplementation, stored.Implementation)
+
+	all, err := suite.customChecksService.GetAll()
+	suite.NoError(err)
+	suite.Len(all, 1)
+}
+
+func (suite *CustomChecksServiceTestSuite) TestCustomChecksService_CreateConflict() {
+	_, err := suite.customChecksService.Create(newCustomCheck("SITE01"))
+	suite.NoError(err)
+
+	_, err = suite.customChecksService.Create(newCustomCheck("SITE01"))
+	suite.ErrorIs(err, ErrCustomCheckConflict)
+
+	_, err = suite.customChecksService.Create(newCustomCheck("156F64"))
+	suite.ErrorIs(err, ErrCustomCheckConflict)
+}
+
+func (suite *CustomChecksServiceTestSuite) TestCustomChecksService_CreateInvalid() {
+	invalidID := newCustomCheck("../etc")
+
+	noName := newCustomCheck("SITE01")
+	noName.Name = ""
+
+	invalidImplementation := newCustomCheck("SITE01")
+	invalidImplementation.Implementation = "name: not a list"
+
+	emptyImplementation := newCustomCheck("SITE01")
+	emptyImplementation.Implementation = ""
+
+	for _, check := range []*models.CustomCheck{invalidID, noName, invalidImplementation, emptyImplementation} {
+		_, err := suite.customChecksService.Create(check)
+		suite.ErrorIs(err, ErrInvalidCustomCheck)
+	}
+
+	for implementation, expectedError := range map[string]string{
+		"- shell: id":                                                  "the shell module is not allowed",
+		"- ansible.builtin.command: id":                                "the ansible.builtin.command module is not allowed",
+		"- block:\n    - lineinfile: {path: /etc/x}":                   "the lineinfile module is not allowed",
+		"- stat: {path: /etc/x}\n  check_mode: false":                  "the check_mode keyword is not allowed",
+		"- stat: {path: /etc/x}\n  delegate_to: localhost":             "the delegate_to keyword is not allowed",
+		"- stat: {path: /etc/x}\n  become_user: sidadm":                "the become_user keyword is not allowed",
+		"- local_action: {module: stat, path: /etc/x}":                 "the local_action keyword is not allowed",
+		"- debug: msg=\"{{ lookup('env', 'TRENTO_WEB_API_TOKEN') }}\"": "lookup( is not allowed",
+		"- debug: msg=\"{{ ''.__class__ }}\"":                          "__ is not allowed",
+		"- import_role: {name: checks/1.1.1}":                          "only the post-results role can be imported",
+		"- stat: {path: /etc/x}\n  debug: msg=x":                       "a task can only use one module",
+	} {
+		check := newCustomCheck("SITE01")
+		check.Implementation = implementation
+
+		_, err := suite.customChecksService.Create(check)
+		suite.ErrorIs(err, ErrInvalidCustomCheck, implementation)
+		suite.Contains(err.Error(), expectedError, implementation)
+	}
+
+	all, err := suite.customChecksService.GetAll()
+	suite.NoError(err)
+	suite.Empty(all)
+}
+
+func (suite *CustomChecksServiceTestSuite) TestCustomChecksService_Update() {
+	_, err := suite.customChecksService.Create(newCustomCheck("SITE01"))
+	suite.NoError(err)
+
+	check := newCustomCheck("SITE01")
+	check.Description = "max_connections is set"
+	updated, err := suite.customChecksService.Update(check)
+	suite.NoError(err)
+	suite.Equal(2, updated.Version)
+	suite.Equal("max_connections is set", updated.Description)
+
+	versions, err := suite.customChecksService.GetVersions("SITE01")
+	suite.NoError(err)
+	suite.Len(versions, 2)
+	suite.Equal(2, versions[0].Version)
+	suite.Equal("max_connections is set", versions[0].Description)
+	suite.Equal(1, versions[1].Version)
+	suite.Equal("max_connections is configured", versions[1].Description)
+
+	_, err = suite.customChecksService.Update(newCustomCheck("other"))
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (suite *CustomChecksServiceTestSuite) TestCustomChecksService_Delete() {
+	_, err := suite.customChecksService.Create(newCustomCheck("SITE01"))
+	suite.NoError(err)
+
+	suite.NoError(suite.customChecksService.Delete("SITE01"))
+
+	_, err = suite.customChecksService.GetByID("SITE01")
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	var versions int64
+	suite.tx.Model(&entities.CustomCheckVersion{}).Count(&versions)
+	suite.Zero(versions)
+
+	suite.ErrorIs(suite.customChecksService.Delete("SITE01"), gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/gorm"
+)
+
+// FactsSources maps the discovery types published by the agents to the facts sources used by the native checks
+var FactsSources = map[string]string{
+	datapipeline.ClusterDiscovery:      checks.ClusterFacts,
+	datapipeline.HostDiscovery:         checks.HostFacts,
+	datapipeline.SAPsystemDiscovery:    checks.SAPSystemsFacts,
+	datapipeline.SubscriptionDiscovery: checks.SubscriptionsFacts,
+	datapipeline.CloudDiscovery:        checks.CloudFacts,
+}
+
+//go:generate mockery --name=FactsService --inpackage --filename=facts_mock.go
+
+type FactsService interface {
+	GetClusterFacts(clusterID string) (map[string]checks.Facts, error)
+}
+
+type factsService struct {
+	db *gorm.DB
+}
+
+func NewFactsService(db *gorm.DB) *factsService {
+	return &factsService{db: db}
+}
+
+// GetClusterFacts returns the facts of each host of the cluster, keyed by host name,
+// built from the last discovery of each type published by the host agent
+func (s *factsService) GetClusterFacts(clusterID string) (map[string]checks.Facts, error) {
+	var hosts []*entities.Host
+	if err := s.db.Where("cluster_id = ?", clusterID).Find(&hosts).Error; err != nil {
+		return nil, err
+	}
+
+	clusterFacts := make(map[string]checks.Facts)
+	for _, host := range hosts {
+		facts := checks.Facts{}
+
+		for discoveryType, source := range FactsSources {
+			var event datapipeline.DataCollectedEvent
+			err := s.db.
+				Where("agent_id = ? AND discovery_type = ?", host.AgentID, discoveryType).
+				Order("id desc").
+				First(&event).
+				Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if err := facts.Add(source, event.Payload); err != nil {
+				return nil, err
+			}
+		}
+
+		clusterFacts[host.Name] = facts
+	}
+
+	return clusterFacts, nil
+}
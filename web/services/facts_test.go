@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/internal/checks"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/gorm"
+)
+
+type FactsServiceTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	tx           *gorm.DB
+	factsService *factsService
+}
+
+func TestFactsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FactsServiceTestSuite))
+}
+
+func (suite *FactsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Host{}, datapipeline.DataCollectedEvent{})
+}
+
+func (suite *FactsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Host{}, datapipeline.DataCollectedEvent{})
+}
+
+func (suite *FactsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.factsService = NewFactsService(suite.tx)
+}
+
+func (suite *FactsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *FactsServiceTestSuite) TestFactsService_GetClusterFacts() {
+	suite.tx.Create(&entities.Host{AgentID: "agent1", Name: "node1", ClusterID: "cluster1"})
+	suite.tx.Create(&entities.Host{AgentID: "agent2", Name: "node2", ClusterID: "cluster1"})
+	suite.tx.Create(&entities.Host{AgentID: "agent3", Name: "node3", ClusterID: "cluster2"})
+
+	suite.tx.Create(&datapipeline.DataCollectedEvent{
+		AgentID: "agent1", DiscoveryType: datapipeline.ClusterDiscovery, Payload: []byte(`{"Name": "old"}`),
+	})
+	suite.tx.Create(&datapipeline.DataCollectedEvent{
+		AgentID: "agent1", DiscoveryType: datapipeline.ClusterDiscovery, Payload: []byte(`{"Name": "hana_cluster"}`),
+	})
+	suite.tx.Create(&datapipeline.DataCollectedEvent{
+		AgentID: "agent1", DiscoveryType: datapipeline.SubscriptionDiscovery, Payload: []byte(`[{"identifier": "SLES_SAP"}]`),
+	})
+	suite.tx.Create(&datapipeline.DataCollectedEvent{
+		AgentID: "agent3", DiscoveryType: datapipeline.ClusterDiscovery, Payload: []byte(`{"Name": "other"}`),
+	})
+
+	facts, err := suite.factsService.GetClusterFacts("cluster1")
+
+	suite.NoError(err)
+	suite.Equal(map[string]checks.Facts{
+		"node1": {
+			"cluster":       map[string]interface{}{"Name": "hana_cluster"},
+			"subscriptions": []interface{}{map[string]interface{}{"identifier": "SLES_SAP"}},
+		},
+		"node2": {},
+	}, facts)
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	trentoDB "github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var ErrHostWithoutCluster = errors.New("the host does not belong to any cluster")
+
+//go:generate mockery --name=HostChecksResultsService --inpackage --filename=host_checks_results_mock.go
+
+type HostChecksResultsService interface {
+	Store(agentID string, results map[string]*models.Check) error
+}
+
+type hostChecksResultsService struct {
+	db *gorm.DB
+}
+
+func NewHostChecksResultsService(db *gorm.DB) *hostChecksResultsService {
+	return &hostChecksResultsService{db: db}
+}
+
+// Store saves the check results evaluated by the agent of a host, and merges them with the ones of the
+// other hosts in a new checks result of the cluster. The cluster result is only stored when it changed.
+// Everything is stored in a single transaction, so the readers never see the host results without the
+// cluster result merging them, and a failure leaves the previous results untouched
+func (s *hostChecksResultsService) Store(agentID string, results map[string]*models.Check) error {
+	payload, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var host entities.Host
+		if err := tx.Where("agent_id = ?", agentID).First(&host).Error; err != nil {
+			return err
+		}
+
+		if host.ClusterID == "" {
+			return ErrHostWithoutCluster
+		}
+
+		err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&entities.HostChecksResult{
+			AgentID:   agentID,
+			ClusterID: host.ClusterID,
+			Payload:   payload,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		merged, err := mergeClusterResults(tx, host.ClusterID)
+		if err != nil {
+			return err
+		}
+
+		var last entities.ChecksResult
+		err = tx.Where("group_id = ?", host.ClusterID).Last(&last).Error
+		switch {
+		case err == nil:
+			lastResult, err := last.ToModel()
+			if err != nil {
+				return err
+			}
+			if reflect.DeepEqual(lastResult.Hosts, merged.Hosts) && len(lastResult.Diff(merged)) == 0 {
+				return nil
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		mergedPayload, err := json.Marshal(merged)
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&entities.ChecksResult{GroupID: host.ClusterID, Payload: mergedPayload}).Error
+	})
+}
+
+func mergeClusterResults(tx *gorm.DB, clusterID string) (*models.ChecksResult, error) {
+	// the hosts of the cluster are locked, so that the results stored concurrently by the other agents
+	// of the cluster are merged one after the other, and none of them is missed
+	hostsQuery := tx
+	if tx.Dialector.Name() != trentoDB.SQLiteDriver {
+		hostsQuery = tx.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var hosts []*entities.Host
+	if err := hostsQuery.Where("cluster_id = ?", clusterID).Order("agent_id").Find(&hosts).Error; err != nil {
+		return nil, err
+	}
+
+	var hostsResults []*entities.HostChecksResult
+	if err := tx.Where("cluster_id = ?", clusterID).Find(&hostsResults).Error; err != nil {
+		return nil, err
+	}
+
+	resultsByAgent := make(map[string]*entities.HostChecksResult)
+	for _, hostResults := range hostsResults {
+		resultsByAgent[hostResults.AgentID] = hostResults
+	}
+
+	merged := &models.ChecksResult{
+		ID:     clusterID,
+		Hosts:  make(map[string]*models.HostState),
+		Checks: make(map[string]*models.ChecksByHost),
+	}
+
+	for _, host := range hosts {
+		hostResults, ok := resultsByAgent[host.AgentID]
+		if !ok {
+			merged.Hosts[host.Name] = &models.HostState{Reachable: false, Msg: "No checks results received from the agent"}
+			continue
+		}
+		merged.Hosts[host.Name] = &models.HostState{Reachable: true}
+
+		results, err := hostResults.ToModel()
+		if err != nil {
+			return nil, err
+		}
+
+		for checkID, result := range results {
+			if _, ok := merged.Checks[checkID]; !ok {
+				merged.Checks[checkID] = &models.ChecksByHost{ID: checkID, Hosts: make(map[string]*models.Check)}
+			}
+			merged.Checks[checkID].Hosts[host.Name] = result
+		}
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type HostChecksResultsServiceTestSuite struct {
+	suite.Suite
+	db                       *gorm.DB
+	tx                       *gorm.DB
+	hostChecksResultsService *hostChecksResultsService
+}
+
+func TestHostChecksResultsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HostChecksResultsServiceTestSuite))
+}
+
+func (suite *HostChecksResultsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Host{}, entities.HostChecksResult{}, entities.ChecksResult{})
+}
+
+func (suite *HostChecksResultsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Host{}, entities.HostChecksResult{}, entities.ChecksResult{})
+}
+
+func (suite *HostChecksResultsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.hostChecksResultsService = NewHostChecksResultsService(suite.tx)
+
+	suite.tx.Create(&entities.Host{AgentID: "agent1", Name: "node1", ClusterID: "cluster1"})
+	suite.tx.Create(&entities.Host{AgentID: "agent2", Name: "node2", ClusterID: "cluster1"})
+	suite.tx.Create(&entities.Host{AgentID: "agent3", Name: "node3"})
+}
+
+func (suite *HostChecksResultsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *HostChecksResultsServiceTestSuite) TestHostChecksResultsService_Store() {
+	err := suite.hostChecksResultsService.Store("agent1", map[string]*models.Check{
+		"156F64": {Result: models.CheckPassing},
+	})
+	suite.NoError(err)
+
+	suite.Equal(&models.ChecksResult{
+		ID: "cluster1",
+		Hosts: map[string]*models.HostState{
+			"node1": {Reachable: true},
+			"node2": {Reachable: false, Msg: "No checks results received from the agent"},
+		},
+		Checks: map[string]*models.ChecksByHost{
+			"156F64": {ID: "156F64", Hosts: map[string]*models.Check{"node1": {Result: models.CheckPassing}}},
+		},
+	}, suite.lastClusterResult())
+
+	err = suite.hostChecksResultsService.Store("agent2", map[string]*models.Check{
+		"156F64": {Result: models.CheckCritical, Msg: "cluster.Corosync.totem.token is 5000, expected eq 30000"},
+	})
+	suite.NoError(err)
+
+	merged := &models.ChecksResult{
+		ID: "cluster1",
+		Hosts: map[string]*models.HostState{
+			"node1": {Reachable: true},
+			"node2": {Reachable: true},
+		},
+		Checks: map[string]*models.ChecksByHost{
+			"156F64": {ID: "156F64", Hosts: map[string]*models.Check{
+				"node1": {Result: models.CheckPassing},
+				"node2": {Result: models.CheckCritical, Msg: "cluster.Corosync.totem.token is 5000, expected eq 30000"},
+			}},
+		},
+	}
+	suite.Equal(merged, suite.lastClusterResult())
+
+	// the same results again don't change the cluster result
+	err = suite.hostChecksResultsService.Store("agent2", map[string]*models.Check{
+		"156F64": {Result: models.CheckCritical, Msg: "cluster.Corosync.totem.token is 5000, expected eq 30000"},
+	})
+	suite.NoError(err)
+
+	var count int64
+	suite.tx.Model(&entities.HostChecksResult{}).Count(&count)
+	suite.EqualValues(2, count)
+
+	suite.tx.Model(&entities.ChecksResult{}).Count(&count)
+	suite.EqualValues(2, count)
+}
+
+func (suite *HostChecksResultsServiceTestSuite) TestHostChecksResultsService_StoreRollback() {
+	suite.NoError(suite.hostChecksResultsService.Store("agent1", map[string]*models.Check{
+		"156F64": {Result: models.CheckPassing},
+	}))
+
+	// a failure storing the cluster result leaves the previous host results untouched
+	suite.tx.Migrator().RenameTable(entities.ChecksResult{}, "checks_results_unavailable")
+	err := suite.hostChecksResultsService.Store("agent1", map[string]*models.Check{
+		"156F64": {Result: models.CheckCritical},
+	})
+	suite.Error(err)
+	suite.tx.Migrator().RenameTable("checks_results_unavailable", entities.ChecksResult{})
+
+	var hostResults entities.HostChecksResult
+	suite.tx.Where("agent_id = ?", "agent1").First(&hostResults)
+	results, _ := hostResults.ToModel()
+	suite.Equal(models.CheckPassing, results["156F64"].Result)
+}
+
+func (suite *HostChecksResultsServiceTestSuite) lastClusterResult() *models.ChecksResult {
+	var last entities.ChecksResult
+	suite.tx.Where("group_id = ?", "cluster1").Last(&last)
+	result, _ := last.ToModel()
+
+	return result
+}
+
+func (suite *HostChecksResultsServiceTestSuite) TestHostChecksResultsService_StoreErrors() {
+	err := suite.hostChecksResultsService.Store("unknown", map[string]*models.Check{})
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	err = suite.hostChecksResultsService.Store("agent3", map[string]*models.Check{})
+	suite.ErrorIs(err, ErrHostWithoutCluster)
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/lib/pq"
 	"github.com/trento-project/trento/internal"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -171,7 +170,7 @@ func (s *hostsService) GetCount() (int, error) {
 }
 
 func (s *hostsService) GetAllSIDs() ([]string, error) {
-	var sids pq.StringArray
+	var sids []string
 
 	err := s.db.
 		Model(&entities.Host{}).
@@ -185,7 +184,7 @@ func (s *hostsService) GetAllSIDs() ([]string, error) {
 		return nil, err
 	}
 
-	return []string(sids), nil
+	return sids, nil
 }
 
 func (s *hostsService) GetAllTags() ([]string, error) {
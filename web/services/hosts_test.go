@@ -4,8 +4,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
+	trentoDB "github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -21,7 +21,7 @@ func hostsFixtures() []entities.Host {
 			ClusterName:   "cluster_1",
 			ClusterType:   models.ClusterTypeHANAScaleOut,
 			CloudProvider: "azure",
-			IPAddresses:   pq.StringArray{"10.74.1.5"},
+			IPAddresses:   trentoDB.StringArray{"10.74.1.5"},
 			SAPSystemInstances: []*entities.SAPSystemInstance{
 				{
 					AgentID:        "1",
@@ -48,7 +48,7 @@ func hostsFixtures() []entities.Host {
 			ClusterName:   "cluster_2",
 			CloudProvider: "azure",
 			ClusterType:   models.ClusterTypeUnknown,
-			IPAddresses:   pq.StringArray{"10.74.1.10"},
+			IPAddresses:   trentoDB.StringArray{"10.74.1.10"},
 			SAPSystemInstances: []*entities.SAPSystemInstance{
 				{
 					AgentID:        "2",
@@ -0,0 +1,454 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var (
+	ErrInvalidRemediation = errors.New("invalid remediation")
+	// ErrRemediationConflict is returned when the remediation status doesn't allow the change
+	ErrRemediationConflict = errors.New("remediation conflict")
+)
+
+//go:generate mockery --name=RemediationsService --inpackage --filename=remediations_mock.go
+
+type RemediationsService interface {
+	GetAllByCluster(clusterID string, page *Page) ([]*models.Remediation, error)
+	GetByID(id int64) (*models.Remediation, error)
+	Create(clusterID string, checks []string, requestedBy string) (*models.Remediation, error)
+	Approve(id int64, approvedBy string) (*models.Remediation, error)
+	Reject(id int64, rejectedBy string) (*models.Remediation, error)
+	Claim(runnerID string) (*models.Remediation, error)
+	Complete(id int64, result *models.RemediationResult) error
+}
+
+type remediationsService struct {
+	db                      *gorm.DB
+	checksService           ChecksService
+	checksExecutionsService ChecksExecutionsService
+}
+
+func NewRemediationsService(
+	db *gorm.DB, checksService ChecksService, checksExecutionsService ChecksExecutionsService,
+) *remediationsService {
+	return &remediationsService{
+		db:                      db,
+		checksService:           checksService,
+		checksExecutionsService: checksExecutionsService,
+	}
+}
+
+// activeRemediationStatus are the status of the remediations not finished yet, a cluster has one at most
+var activeRemediationStatus = []string{
+	models.RemediationPreviewQueued,
+	models.RemediationPreviewing,
+	models.RemediationPendingApproval,
+	models.RemediationApproved,
+	models.RemediationApplying,
+}
+
+// GetAllByCluster returns the remediations of a cluster, the most recent first
+func (s *remediationsService) GetAllByCluster(clusterID string, page *Page) ([]*models.Remediation, error) {
+	var remediations []*entities.Remediation
+
+	err := s.db.Scopes(Paginate(page)).
+		Where("cluster_id = ?", clusterID).
+		Order("id desc").
+		Find(&remediations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Remediation{}
+	for _, remediation := range remediations {
+		result = append(result, remediation.ToModel())
+	}
+
+	return result, nil
+}
+
+// GetByID returns the remediation, gorm.ErrRecordNotFound is returned when it does not exist
+func (s *remediationsService) GetByID(id int64) (*models.Remediation, error) {
+	var remediation entities.Remediation
+	if err := s.db.Where("id = ?", id).First(&remediation).Error; err != nil {
+		return nil, err
+	}
+
+	return remediation.ToModel(), nil
+}
+
+// Create requests the remediation of failing checks of a cluster, queueing the preview of its changes.
+// Only the remediable checks failing in the last results can be remediated
+func (s *remediationsService) Create(clusterID string, checks []string, requestedBy string) (*models.Remediation, error) {
+	if err := s.validateChecks(clusterID, checks); err != nil {
+		return nil, err
+	}
+
+	entity := &entities.Remediation{
+		ClusterID:   clusterID,
+		Checks:      checks,
+		Status:      models.RemediationPreviewQueued,
+		RequestedBy: requestedBy,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var active int64
+		err := tx.Model(&entities.Remediation{}).
+			Where("cluster_id = ? AND status IN ?", clusterID, activeRemediationStatus).
+			Count(&active).Error
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			return fmt.Errorf("%w: the cluster %s has a remediation in progress", ErrRemediationConflict, clusterID)
+		}
+
+		if err := tx.Create(entity).Error; err != nil {
+			return err
+		}
+
+		return recordAuditLogEntry(tx, models.AuditRemediationRequested, remediationResource(entity.ID), requestedBy,
+			fmt.Sprintf("Remediation of the checks %s requested on cluster %s", strings.Join(checks, ", "), clusterID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ToModel(), nil
+}
+
+// Approve allows the runner to apply the previewed changes, the approver must not be the requester
+func (s *remediationsService) Approve(id int64, approvedBy string) (*models.Remediation, error) {
+	if approvedBy == "" {
+		return nil, fmt.Errorf("%w: the approver is required", ErrInvalidRemediation)
+	}
+
+	now := time.Now()
+	return s.review(id, models.RemediationApproved, map[string]interface{}{
+		"status":      models.RemediationApproved,
+		"approved_by": approvedBy,
+		"approved_at": now,
+	}, models.AuditRemediationApproved, approvedBy)
+}
+
+// Reject discards the previewed changes, they are not applied
+func (s *remediationsService) Reject(id int64, rejectedBy string) (*models.Remediation, error) {
+	now := time.Now()
+	return s.review(id, models.RemediationRejected, map[string]interface{}{
+		"status":       models.RemediationRejected,
+		"completed_at": now,
+	}, models.AuditRemediationRejected, rejectedBy)
+}
+
+func (s *remediationsService) review(
+	id int64, status string, updates map[string]interface{}, action string, actor string,
+) (*models.Remediation, error) {
+	var remediation entities.Remediation
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).First(&remediation).Error; err != nil {
+			return err
+		}
+
+		// the changes are reviewed by a second person, although the actors are only declared by the clients
+		if status == models.RemediationApproved &&
+			strings.EqualFold(strings.TrimSpace(remediation.RequestedBy), strings.TrimSpace(actor)) {
+			return fmt.Errorf("%w: the remediation %d can't be approved by its requester %s", ErrInvalidRemediation, id, actor)
+		}
+
+		result := tx.Model(&entities.Remediation{}).
+			Where("id = ? AND status = ?", id, models.RemediationPendingApproval).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: the remediation %d is %s, not pending approval", ErrRemediationConflict, id, remediation.Status)
+		}
+
+		return recordAuditLogEntry(tx, action, remediationResource(id), actor,
+			fmt.Sprintf("Remediation of the checks %s on cluster %s %s",
+				strings.Join(remediation.Checks, ", "), remediation.ClusterID, status))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(id)
+}
+
+// Claim assigns the next remediation to preview or to apply to a runner. When a runner id is given,
+// only the remediations of the clusters leased to it are considered.
+// The remediations left running by a dead runner are recovered first
+func (s *remediationsService) Claim(runnerID string) (*models.Remediation, error) {
+	if err := s.recoverOrphaned(); err != nil {
+		return nil, err
+	}
+
+	for {
+		var remediation entities.Remediation
+
+		db := s.db.Where("status IN ?", []string{models.RemediationPreviewQueued, models.RemediationApproved})
+		if runnerID != "" {
+			leased := s.db.Model(&entities.RunnerLease{}).
+				Select("cluster_id").
+				Where("runner_id = ? AND expires_at > ?", runnerID, time.Now())
+			db = db.Where("cluster_id IN (?)", leased)
+		}
+
+		err := db.Order("id").First(&remediation).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		status := models.RemediationPreviewing
+		if remediation.Status == models.RemediationApproved {
+			status = models.RemediationApplying
+		}
+
+		result := s.db.Model(&entities.Remediation{}).
+			Where("id = ? AND status = ?", remediation.ID, remediation.Status).
+			Updates(map[string]interface{}{
+				"status":    status,
+				"runner_id": runnerID,
+			})
+
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			// claimed by someone else in the meantime, try with the next one
+			continue
+		}
+
+		remediation.Status = status
+
+		return remediation.ToModel(), nil
+	}
+}
+
+// recoverOrphaned releases the remediations whose runner stopped sending heartbeats, and so lost its leases,
+// while previewing or applying them. The previews are queued again, as they don't change anything, while the apply
+// runs are failed: the changes might be partially applied, they have to be checked before requesting them again.
+// The remediations claimed without a runner id are kept
+func (s *remediationsService) recoverOrphaned() error {
+	now := time.Now()
+
+	alive := s.db.Model(&entities.Runner{}).
+		Select("1").
+		Where("runners.id = remediations.runner_id AND runners.heartbeat_at > ?", now.Add(-RunnerLeaseDuration))
+
+	var orphaned []entities.Remediation
+	err := s.db.
+		Where("status IN ? AND runner_id <> ''", []string{models.RemediationPreviewing, models.RemediationApplying}).
+		Where("NOT EXISTS (?)", alive).
+		Find(&orphaned).Error
+	if err != nil {
+		return err
+	}
+
+	for _, remediation := range orphaned {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			updates := map[string]interface{}{
+				"status":    models.RemediationPreviewQueued,
+				"runner_id": "",
+			}
+			stderr := fmt.Sprintf("the runner %s stopped while applying the changes", remediation.RunnerID)
+			if remediation.Status == models.RemediationApplying {
+				updates = map[string]interface{}{
+					"status":       models.RemediationFailed,
+					"stderr":       stderr,
+					"completed_at": now,
+				}
+			}
+
+			result := tx.Model(&entities.Remediation{}).
+				Where("id = ? AND status = ?", remediation.ID, remediation.Status).
+				Updates(updates)
+			if result.Error != nil || result.RowsAffected == 0 {
+				// recovered by someone else in the meantime
+				return result.Error
+			}
+
+			if remediation.Status != models.RemediationApplying {
+				log.Warnf("Runner %s stopped while previewing the remediation %d, queueing it again",
+					remediation.RunnerID, remediation.ID)
+				return nil
+			}
+
+			log.Warnf("Runner %s stopped while applying the remediation %d", remediation.RunnerID, remediation.ID)
+			return recordAuditLogEntry(tx, models.AuditRemediationFailed, remediationResource(remediation.ID), "",
+				fmt.Sprintf("Remediation of the checks %s failed on cluster %s: %s",
+					strings.Join(remediation.Checks, ", "), remediation.ClusterID, stderr))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Complete stores the outcome of a preview or of an apply run. A checks execution is requested
+// once the changes are applied, so that the results reflect them
+func (s *remediationsService) Complete(id int64, remediationResult *models.RemediationResult) error {
+	diff, err := json.Marshal(remediationResult.Diff)
+	if err != nil {
+		return err
+	}
+
+	var remediation entities.Remediation
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).First(&remediation).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"exit_code": remediationResult.ExitCode,
+			"stderr":    remediationResult.Stderr,
+		}
+
+		switch remediation.Status {
+		case models.RemediationPreviewing:
+			updates["preview_diff"] = diff
+			updates["status"] = models.RemediationPendingApproval
+		case models.RemediationApplying:
+			updates["applied_diff"] = diff
+			updates["status"] = models.RemediationApplied
+		default:
+			return fmt.Errorf("%w: the remediation %d is %s, not running", ErrRemediationConflict, id, remediation.Status)
+		}
+
+		if remediationResult.Failed {
+			updates["status"] = models.RemediationFailed
+		}
+		if updates["status"] != models.RemediationPendingApproval {
+			updates["completed_at"] = time.Now()
+		}
+
+		err := tx.Model(&entities.Remediation{}).
+			Where("id = ? AND status = ?", id, remediation.Status).
+			Updates(updates).Error
+		if err != nil {
+			return err
+		}
+
+		switch updates["status"] {
+		case models.RemediationApplied:
+			return recordAuditLogEntry(tx, models.AuditRemediationApplied, remediationResource(id), remediation.ApprovedBy,
+				fmt.Sprintf("Remediation of the checks %s applied on cluster %s, changed hosts: %s",
+					strings.Join(remediation.Checks, ", "), remediation.ClusterID, changedHosts(remediationResult.Diff)))
+		case models.RemediationFailed:
+			return recordAuditLogEntry(tx, models.AuditRemediationFailed, remediationResource(id), "",
+				fmt.Sprintf("Remediation of the checks %s failed on cluster %s: %s",
+					strings.Join(remediation.Checks, ", "), remediation.ClusterID, remediationResult.Stderr))
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if remediation.Status == models.RemediationApplying && !remediationResult.Failed {
+		if _, err := s.checksExecutionsService.Enqueue(remediation.ClusterID); err != nil {
+			log.Errorf("Error requesting the checks execution of cluster %s after its remediation: %s", remediation.ClusterID, err)
+		}
+	}
+
+	return nil
+}
+
+// validateChecks verifies that the checks are remediable and that they fail on the cluster
+func (s *remediationsService) validateChecks(clusterID string, checks []string) error {
+	if len(checks) == 0 {
+		return fmt.Errorf("%w: no checks to remediate", ErrInvalidRemediation)
+	}
+
+	catalog, err := s.checksService.GetChecksCatalog()
+	if err != nil {
+		return err
+	}
+
+	var remediable []string
+	for _, check := range catalog {
+		if check.Remediable {
+			remediable = append(remediable, check.ID)
+		}
+	}
+
+	results, err := s.checksService.GetChecksResultByCluster(clusterID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: the cluster %s has no checks results", ErrInvalidRemediation, clusterID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, checkID := range checks {
+		if !internal.Contains(remediable, checkID) {
+			return fmt.Errorf("%w: the check %s is not remediable", ErrInvalidRemediation, checkID)
+		}
+		if !isFailing(results, checkID) {
+			return fmt.Errorf("%w: the check %s is not failing on the cluster %s", ErrInvalidRemediation, checkID, clusterID)
+		}
+	}
+
+	return nil
+}
+
+func isFailing(results *models.ChecksResult, checkID string) bool {
+	if results == nil {
+		return false
+	}
+
+	checkResults, ok := results.Checks[checkID]
+	if !ok {
+		return false
+	}
+
+	for _, hostResult := range checkResults.Hosts {
+		if hostResult.Result == models.CheckCritical || hostResult.Result == models.CheckWarning {
+			return true
+		}
+	}
+
+	return false
+}
+
+func changedHosts(diff models.RemediationDiff) string {
+	var hosts []string
+	for host, checks := range diff {
+		if len(checks) > 0 {
+			hosts = append(hosts, host)
+		}
+	}
+
+	if len(hosts) == 0 {
+		return "none"
+	}
+
+	sort.Strings(hosts)
+	return strings.Join(hosts, ", ")
+}
+
+func remediationResource(id int64) string {
+	return fmt.Sprintf("remediation/%d", id)
+}
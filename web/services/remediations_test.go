@@ -0,0 +1,248 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+type RemediationsServiceTestSuite struct {
+	suite.Suite
+	db                      *gorm.DB
+	tx                      *gorm.DB
+	checksService           *MockChecksService
+	checksExecutionsService *MockChecksExecutionsService
+	remediationsService     *remediationsService
+	auditLogService         *auditLogService
+}
+
+func TestRemediationsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RemediationsServiceTestSuite))
+}
+
+func (suite *RemediationsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Remediation{}, entities.Runner{}, entities.RunnerLease{}, entities.AuditLogEntry{})
+}
+
+func (suite *RemediationsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Remediation{}, entities.Runner{}, entities.RunnerLease{}, entities.AuditLogEntry{})
+}
+
+func (suite *RemediationsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.checksService = new(MockChecksService)
+	suite.checksExecutionsService = new(MockChecksExecutionsService)
+	suite.remediationsService = NewRemediationsService(suite.tx, suite.checksService, suite.checksExecutionsService)
+	suite.auditLogService = NewAuditLogService(suite.tx)
+
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{
+		&models.Check{ID: "1.1.1", Remediable: true},
+		&models.Check{ID: "1.1.2", Remediable: true},
+		&models.Check{ID: "1.2.1"},
+	}, nil)
+	suite.checksService.On("GetChecksResultByCluster", "cluster1").Return(&models.ChecksResult{
+		Checks: map[string]*models.ChecksByHost{
+			"1.1.1": {Hosts: map[string]*models.Check{
+				"host1": {Result: models.CheckPassing},
+				"host2": {Result: models.CheckCritical},
+			}},
+			"1.1.2": {Hosts: map[string]*models.Check{
+				"host1": {Result: models.CheckPassing},
+				"host2": {Result: models.CheckPassing},
+			}},
+			"1.2.1": {Hosts: map[string]*models.Check{
+				"host1": {Result: models.CheckWarning},
+			}},
+		},
+	}, nil)
+}
+
+func (suite *RemediationsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *RemediationsServiceTestSuite) TestRemediationsService_Create() {
+	created, err := suite.remediationsService.Create("cluster1", []string{"1.1.1"}, "admin")
+	suite.NoError(err)
+	suite.NotZero(created.ID)
+	suite.Equal(models.RemediationPreviewQueued, created.Status)
+	suite.Equal([]string{"1.1.1"}, created.Checks)
+
+	_, err = suite.remediationsService.Create("cluster1", []string{"1.1.1"}, "admin")
+	suite.True(errors.Is(err, ErrRemediationConflict))
+
+	remediations, err := suite.remediationsService.GetAllByCluster("cluster1", nil)
+	suite.NoError(err)
+	suite.Len(remediations, 1)
+
+	entries, _ := suite.auditLogService.GetAll(nil)
+	suite.Len(entries, 1)
+	suite.Equal(models.AuditRemediationRequested, entries[0].Action)
+	suite.Equal(remediationResource(created.ID), entries[0].Resource)
+	suite.Equal("admin", entries[0].Actor)
+}
+
+func (suite *RemediationsServiceTestSuite) TestRemediationsService_CreateInvalid() {
+	invalidChecks := [][]string{
+		{},
+		// not failing
+		{"1.1.2"},
+		// not remediable
+		{"1.2.1"},
+		{"1.1.1", "unknown"},
+	}
+
+	for _, checks := range invalidChecks {
+		_, err := suite.remediationsService.Create("cluster1", checks, "admin")
+		suite.True(errors.Is(err, ErrInvalidRemediation), checks)
+	}
+
+	remediations, _ := suite.remediationsService.GetAllByCluster("cluster1", nil)
+	suite.Empty(remediations)
+}
+
+func (suite *RemediationsServiceTestSuite) TestRemediationsService_PreviewApproveAndApply() {
+	created, _ := suite.remediationsService.Create("cluster1", []string{"1.1.1"}, "operator")
+
+	_, err := suite.remediationsService.Approve(created.ID, "admin")
+	suite.True(errors.Is(err, ErrRemediationConflict))
+
+	claimed, err := suite.remediationsService.Claim("")
+	suite.NoError(err)
+	suite.Equal(created.ID, claimed.ID)
+	suite.Equal(models.RemediationPreviewing, claimed.Status)
+
+	claimed, err = suite.remediationsService.Claim("")
+	suite.NoError(err)
+	suite.Nil(claimed)
+
+	diff := models.RemediationDiff{"host2": {"1.1.1": "-token: 5000\n+token: 30000\n"}}
+	suite.NoError(suite.remediationsService.Complete(created.ID, &models.RemediationResult{Diff: diff}))
+
+	previewed, _ := suite.remediationsService.GetByID(created.ID)
+	suite.Equal(models.RemediationPendingApproval, previewed.Status)
+	suite.Equal(diff, previewed.PreviewDiff)
+	suite.Nil(previewed.CompletedAt)
+
+	_, err = suite.remediationsService.Approve(created.ID, "")
+	suite.True(errors.Is(err, ErrInvalidRemediation))
+
+	for _, requester := range []string{"operator", " Operator "} {
+		_, err = suite.remediationsService.Approve(created.ID, requester)
+		suite.True(errors.Is(err, ErrInvalidRemediation), requester)
+	}
+
+	approved, err := suite.remediationsService.Approve(created.ID, "admin")
+	suite.NoError(err)
+	suite.Equal(models.RemediationApproved, approved.Status)
+	suite.Equal("admin", approved.ApprovedBy)
+	suite.NotNil(approved.ApprovedAt)
+
+	claimed, _ = suite.remediationsService.Claim("")
+	suite.Equal(created.ID, claimed.ID)
+	suite.Equal(models.RemediationApplying, claimed.Status)
+
+	suite.checksExecutionsService.On("Enqueue", "cluster1").Return(&models.ChecksExecution{ID: 1}, nil)
+	suite.NoError(suite.remediationsService.Complete(created.ID, &models.RemediationResult{Diff: diff}))
+
+	applied, _ := suite.remediationsService.GetByID(created.ID)
+	suite.Equal(models.RemediationApplied, applied.Status)
+	suite.Equal(diff, applied.AppliedDiff)
+	suite.NotNil(applied.CompletedAt)
+	suite.checksExecutionsService.AssertExpectations(suite.T())
+
+	err = suite.remediationsService.Complete(created.ID, &models.RemediationResult{})
+	suite.True(errors.Is(err, ErrRemediationConflict))
+
+	entries, _ := suite.auditLogService.GetAll(nil)
+	suite.Len(entries, 3)
+	suite.Equal(models.AuditRemediationApplied, entries[0].Action)
+	suite.Equal("admin", entries[0].Actor)
+	suite.Contains(entries[0].Message, "host2")
+	suite.Equal(models.AuditRemediationApproved, entries[1].Action)
+	suite.Equal(models.AuditRemediationRequested, entries[2].Action)
+}
+
+func (suite *RemediationsServiceTestSuite) TestRemediationsService_Reject() {
+	created, _ := suite.remediationsService.Create("cluster1", []string{"1.1.1"}, "operator")
+	suite.remediationsService.Claim("")
+	suite.remediationsService.Complete(created.ID, &models.RemediationResult{})
+
+	rejected, err := suite.remediationsService.Reject(created.ID, "admin")
+	suite.NoError(err)
+	suite.Equal(models.RemediationRejected, rejected.Status)
+	suite.NotNil(rejected.CompletedAt)
+
+	claimed, _ := suite.remediationsService.Claim("")
+	suite.Nil(claimed)
+
+	// a new remediation can be requested once the previous one is done
+	_, err = suite.remediationsService.Create("cluster1", []string{"1.1.1"}, "operator")
+	suite.NoError(err)
+}
+
+func (suite *RemediationsServiceTestSuite) TestRemediationsService_Failed() {
+	created, _ := suite.remediationsService.Create("cluster1", []string{"1.1.1"}, "operator")
+	suite.remediationsService.Claim("")
+
+	exitCode := 2
+	suite.NoError(suite.remediationsService.Complete(created.ID, &models.RemediationResult{
+		Failed:   true,
+		ExitCode: &exitCode,
+		Stderr:   "host2 unreachable",
+	}))
+
+	failed, _ := suite.remediationsService.GetByID(created.ID)
+	suite.Equal(models.RemediationFailed, failed.Status)
+	suite.Equal(2, *failed.ExitCode)
+	suite.Equal("host2 unreachable", failed.Stderr)
+	suite.NotNil(failed.CompletedAt)
+
+	entries, _ := suite.auditLogService.GetAll(nil)
+	suite.Equal(models.AuditRemediationFailed, entries[0].Action)
+	suite.checksExecutionsService.AssertNotCalled(suite.T(), "Enqueue", "cluster1")
+}
+
+func (suite *RemediationsServiceTestSuite) TestRemediationsService_ClaimRecoversOrphaned() {
+	now := time.Now()
+	suite.tx.Create(&entities.Runner{ID: "crashed", HeartbeatAt: now.Add(-time.Hour)})
+	suite.tx.Create(&entities.Runner{ID: "runner1", HeartbeatAt: now})
+	suite.tx.Create(&entities.RunnerLease{ClusterID: "cluster1", RunnerID: "runner1", ExpiresAt: now.Add(time.Minute)})
+
+	previewing := entities.Remediation{ClusterID: "cluster1", Checks: []string{"1.1.1"}, Status: models.RemediationPreviewing, RunnerID: "crashed"}
+	suite.tx.Create(&previewing)
+	applying := entities.Remediation{ClusterID: "cluster2", Checks: []string{"1.1.1"}, Status: models.RemediationApplying, RunnerID: "crashed"}
+	suite.tx.Create(&applying)
+	running := entities.Remediation{ClusterID: "cluster3", Checks: []string{"1.1.1"}, Status: models.RemediationApplying, RunnerID: "runner1"}
+	suite.tx.Create(&running)
+
+	// the preview of the crashed runner is queued again for the runner taking its cluster over
+	claimed, err := suite.remediationsService.Claim("runner1")
+	suite.NoError(err)
+	suite.Equal(previewing.ID, claimed.ID)
+	suite.Equal(models.RemediationPreviewing, claimed.Status)
+
+	// the interrupted apply run is failed, so a new remediation of its cluster can be requested
+	failed, _ := suite.remediationsService.GetByID(applying.ID)
+	suite.Equal(models.RemediationFailed, failed.Status)
+	suite.Equal("the runner crashed stopped while applying the changes", failed.Stderr)
+	suite.NotNil(failed.CompletedAt)
+
+	entries, _ := suite.auditLogService.GetAll(nil)
+	suite.Len(entries, 1)
+	suite.Equal(models.AuditRemediationFailed, entries[0].Action)
+	suite.Equal(remediationResource(applying.ID), entries[0].Resource)
+
+	// the remediations of the live runners are left running
+	stillRunning, _ := suite.remediationsService.GetByID(running.ID)
+	suite.Equal(models.RemediationApplying, stillRunning.Status)
+}
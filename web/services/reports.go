@@ -0,0 +1,208 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var ErrInvalidReportScope = errors.New("invalid report scope")
+
+//go:generate mockery --name=ReportsService --inpackage --filename=reports_mock.go
+
+type ReportsService interface {
+	GetReport(scope string, target string) (*models.Report, error)
+}
+
+type reportsService struct {
+	db            *gorm.DB
+	checksService ChecksService
+}
+
+func NewReportsService(db *gorm.DB, checksService ChecksService) *reportsService {
+	return &reportsService{db: db, checksService: checksService}
+}
+
+// GetReport builds the compliance report of a cluster, of the clusters with a tag or of the whole landscape,
+// from the last checks results of each cluster with the waivers applied.
+// gorm.ErrRecordNotFound is returned when the cluster of a cluster report does not exist
+func (s *reportsService) GetReport(scope string, target string) (*models.Report, error) {
+	db := s.db.Preload("Tags").Preload("Hosts")
+
+	switch scope {
+	case models.ReportScopeCluster:
+		db = db.Where("id = ?", target)
+	case models.ReportScopeTag:
+		db = db.Where("id IN (?)", s.db.Model(&models.Tag{}).
+			Select("resource_id").
+			Where("resource_type = ?", models.TagClusterResourceType).
+			Where("value = ?", target),
+		)
+	case models.ReportScopeLandscape:
+		target = ""
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidReportScope, scope)
+	}
+
+	var clusters []*entities.Cluster
+	if err := db.Order("name").Order("id").Find(&clusters).Error; err != nil {
+		return nil, err
+	}
+
+	if scope == models.ReportScopeCluster && len(clusters) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	catalog, err := s.checksService.GetChecksCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	checksByID := make(map[string]*models.Check, len(catalog))
+	for _, check := range catalog {
+		checksByID[check.ID] = check
+	}
+
+	var waivers []*entities.Waiver
+	if err := s.db.Where("expires_at > ?", time.Now()).Order("id").Find(&waivers).Error; err != nil {
+		return nil, err
+	}
+
+	report := &models.Report{
+		GeneratedAt: time.Now(),
+		Scope:       scope,
+		Target:      target,
+		Aggregated:  &models.AggregatedCheckData{},
+		Clusters:    []*models.ClusterReport{},
+	}
+
+	for _, cluster := range clusters {
+		clusterReport, err := s.getClusterReport(cluster, checksByID, waivers)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Aggregated.PassingCount += clusterReport.Aggregated.PassingCount
+		report.Aggregated.WarningCount += clusterReport.Aggregated.WarningCount
+		report.Aggregated.CriticalCount += clusterReport.Aggregated.CriticalCount
+		report.Clusters = append(report.Clusters, clusterReport)
+	}
+
+	return report, nil
+}
+
+func (s *reportsService) getClusterReport(cluster *entities.Cluster, checksByID map[string]*models.Check, waivers []*entities.Waiver) (*models.ClusterReport, error) {
+	clusterReport := &models.ClusterReport{
+		ID:         cluster.ID,
+		Name:       cluster.Name,
+		SID:        cluster.SID,
+		Health:     models.CheckUndefined,
+		Aggregated: &models.AggregatedCheckData{},
+		Hosts:      []*models.ReportHost{},
+		Checks:     []*models.ReportCheck{},
+		Waivers:    []*models.Waiver{},
+	}
+
+	for _, tag := range cluster.Tags {
+		clusterReport.Tags = append(clusterReport.Tags, tag.Value)
+	}
+
+	hostNames := make(map[string]struct{})
+	for _, host := range cluster.Hosts {
+		hostNames[host.Name] = struct{}{}
+	}
+
+	for _, waiver := range waivers {
+		if waiverCoversCluster(waiver, cluster, hostNames) {
+			clusterReport.Waivers = append(clusterReport.Waivers, waiver.ToModel())
+		}
+	}
+
+	var lastResult entities.ChecksResult
+	err := s.db.Select("id", "created_at").
+		Where("group_id = ?", cluster.ID).
+		Order("id desc").
+		Limit(1).
+		Find(&lastResult).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if lastResult.ID == 0 {
+		return clusterReport, nil
+	}
+	clusterReport.ExecutedAt = &lastResult.CreatedAt
+
+	results, err := s.checksService.GetChecksResultAndMetadataByCluster(cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregated, err := s.checksService.GetAggregatedChecksResultByCluster(cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+	clusterReport.Aggregated = aggregated
+	clusterReport.Health = aggregated.String()
+
+	for name, state := range results.Hosts {
+		clusterReport.Hosts = append(clusterReport.Hosts, &models.ReportHost{
+			Name:      name,
+			Reachable: state.Reachable,
+			Msg:       state.Msg,
+		})
+	}
+	sort.Slice(clusterReport.Hosts, func(i, j int) bool {
+		return clusterReport.Hosts[i].Name < clusterReport.Hosts[j].Name
+	})
+
+	for _, checkResults := range results.Checks {
+		reportCheck := &models.ReportCheck{
+			ID:          checkResults.ID,
+			Group:       checkResults.Group,
+			Description: checkResults.Description,
+			Results:     []*models.ReportCheckResult{},
+		}
+
+		if check, ok := checksByID[checkResults.ID]; ok {
+			reportCheck.Name = check.Name
+			reportCheck.Remediation = check.Remediation
+		}
+
+		for host, result := range checkResults.Hosts {
+			reportCheck.Results = append(reportCheck.Results, &models.ReportCheckResult{
+				Host:         host,
+				Result:       result.Result,
+				WaivedResult: result.WaivedResult,
+				Msg:          result.Msg,
+			})
+		}
+		sort.Slice(reportCheck.Results, func(i, j int) bool {
+			return reportCheck.Results[i].Host < reportCheck.Results[j].Host
+		})
+
+		clusterReport.Checks = append(clusterReport.Checks, reportCheck)
+	}
+
+	return clusterReport, nil
+}
+
+func waiverCoversCluster(waiver *entities.Waiver, cluster *entities.Cluster, hostNames map[string]struct{}) bool {
+	switch waiver.Scope {
+	case models.WaiverScopeCluster:
+		return waiver.Target == cluster.ID
+	case models.WaiverScopeSID:
+		return cluster.SID != "" && waiver.Target == cluster.SID
+	case models.WaiverScopeHost:
+		_, ok := hostNames[waiver.Target]
+		return ok
+	}
+
+	return false
+}
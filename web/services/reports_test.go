@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+type ReportsServiceTestSuite struct {
+	suite.Suite
+	db             *gorm.DB
+	tx             *gorm.DB
+	checksService  *MockChecksService
+	reportsService *reportsService
+}
+
+func TestReportsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportsServiceTestSuite))
+}
+
+func (suite *ReportsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		entities.Cluster{}, entities.Host{}, models.Tag{}, entities.ChecksResult{}, entities.Waiver{})
+}
+
+func (suite *ReportsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		entities.Cluster{}, entities.Host{}, models.Tag{}, entities.ChecksResult{}, entities.Waiver{})
+}
+
+func (suite *ReportsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.checksService = new(MockChecksService)
+	suite.reportsService = NewReportsService(suite.tx, suite.checksService)
+
+	suite.tx.Create(&entities.Cluster{
+		ID:   "cluster1",
+		Name: "hana_cluster",
+		SID:  "PRD",
+		Tags: []*models.Tag{{Value: "production"}},
+		Hosts: []*entities.Host{
+			{AgentID: "agent1", Name: "host1"},
+			{AgentID: "agent2", Name: "host2"},
+		},
+	})
+	suite.tx.Create(&entities.Cluster{ID: "cluster2", Name: "another_cluster"})
+	suite.tx.Create(&entities.ChecksResult{
+		GroupID:   "cluster1",
+		CreatedAt: time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC),
+	})
+	suite.tx.Create(&entities.Waiver{
+		CheckID: "check2", Scope: models.WaiverScopeHost, Target: "host2",
+		Owner: "admin", Justification: "accepted", ExpiresAt: time.Now().Add(time.Hour),
+	})
+	suite.tx.Create(&entities.Waiver{
+		CheckID: "check2", Scope: models.WaiverScopeSID, Target: "QAS",
+		Owner: "admin", Justification: "other system", ExpiresAt: time.Now().Add(time.Hour),
+	})
+	suite.tx.Create(&entities.Waiver{
+		CheckID: "check1", Scope: models.WaiverScopeCluster, Target: "cluster1",
+		Owner: "admin", Justification: "expired", ExpiresAt: time.Now().Add(-time.Hour),
+	})
+
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{
+		&models.Check{ID: "check1", Name: "name1", Remediation: "remediation1"},
+		&models.Check{ID: "check2", Name: "name2", Remediation: "remediation2"},
+	}, nil)
+	suite.checksService.On("GetChecksResultAndMetadataByCluster", "cluster1").Return(&models.ChecksResultAsList{
+		Hosts: map[string]*models.HostState{
+			"host2": {Reachable: false, Msg: "error connecting"},
+			"host1": {Reachable: true},
+		},
+		Checks: []*models.ChecksByHost{
+			{
+				ID:          "check1",
+				Group:       "group1",
+				Description: "description1",
+				Hosts: map[string]*models.Check{
+					"host2": {Result: models.CheckCritical},
+					"host1": {Result: models.CheckPassing},
+				},
+			},
+			{
+				ID:          "check2",
+				Group:       "group1",
+				Description: "description2",
+				Hosts: map[string]*models.Check{
+					"host2": {Result: models.CheckWaived, WaivedResult: models.CheckWarning, Msg: "waived"},
+				},
+			},
+		},
+	}, nil)
+	suite.checksService.On("GetAggregatedChecksResultByCluster", "cluster1").Return(
+		&models.AggregatedCheckData{PassingCount: 1, CriticalCount: 1}, nil)
+}
+
+func (suite *ReportsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *ReportsServiceTestSuite) TestReportsService_GetReportByCluster() {
+	report, err := suite.reportsService.GetReport(models.ReportScopeCluster, "cluster1")
+	suite.NoError(err)
+
+	suite.Equal(models.ReportScopeCluster, report.Scope)
+	suite.Equal("cluster1", report.Target)
+	suite.Equal(&models.AggregatedCheckData{PassingCount: 1, CriticalCount: 1}, report.Aggregated)
+	suite.Len(report.Clusters, 1)
+
+	cluster := report.Clusters[0]
+	suite.Equal("hana_cluster", cluster.Name)
+	suite.Equal("PRD", cluster.SID)
+	suite.Equal([]string{"production"}, cluster.Tags)
+	suite.Equal(models.CheckCritical, cluster.Health)
+	suite.Equal(time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC), cluster.ExecutedAt.UTC())
+	suite.Equal([]*models.ReportHost{
+		{Name: "host1", Reachable: true},
+		{Name: "host2", Reachable: false, Msg: "error connecting"},
+	}, cluster.Hosts)
+	suite.Equal([]*models.ReportCheck{
+		{
+			ID:          "check1",
+			Name:        "name1",
+			Group:       "group1",
+			Description: "description1",
+			Remediation: "remediation1",
+			Results: []*models.ReportCheckResult{
+				{Host: "host1", Result: models.CheckPassing},
+				{Host: "host2", Result: models.CheckCritical},
+			},
+		},
+		{
+			ID:          "check2",
+			Name:        "name2",
+			Group:       "group1",
+			Description: "description2",
+			Remediation: "remediation2",
+			Results: []*models.ReportCheckResult{
+				{Host: "host2", Result: models.CheckWaived, WaivedResult: models.CheckWarning, Msg: "waived"},
+			},
+		},
+	}, cluster.Checks)
+	suite.Len(cluster.Waivers, 1)
+	suite.Equal("accepted", cluster.Waivers[0].Justification)
+}
+
+func (suite *ReportsServiceTestSuite) TestReportsService_GetReportByTag() {
+	report, err := suite.reportsService.GetReport(models.ReportScopeTag, "production")
+	suite.NoError(err)
+	suite.Len(report.Clusters, 1)
+	suite.Equal("cluster1", report.Clusters[0].ID)
+
+	report, err = suite.reportsService.GetReport(models.ReportScopeTag, "unknown")
+	suite.NoError(err)
+	suite.Empty(report.Clusters)
+}
+
+func (suite *ReportsServiceTestSuite) TestReportsService_GetReportOfLandscape() {
+	report, err := suite.reportsService.GetReport(models.ReportScopeLandscape, "ignored")
+	suite.NoError(err)
+
+	suite.Empty(report.Target)
+	suite.Len(report.Clusters, 2)
+	suite.Equal("another_cluster", report.Clusters[0].Name)
+	suite.Nil(report.Clusters[0].ExecutedAt)
+	suite.Equal(models.CheckUndefined, report.Clusters[0].Health)
+	suite.Empty(report.Clusters[0].Checks)
+	suite.Equal("hana_cluster", report.Clusters[1].Name)
+	suite.Equal(&models.AggregatedCheckData{PassingCount: 1, CriticalCount: 1}, report.Aggregated)
+}
+
+func (suite *ReportsServiceTestSuite) TestReportsService_GetReportErrors() {
+	_, err := suite.reportsService.GetReport(models.ReportScopeCluster, "unknown")
+	suite.True(errors.Is(err, gorm.ErrRecordNotFound))
+
+	_, err = suite.reportsService.GetReport("datacenter", "")
+	suite.ErrorIs(err, ErrInvalidReportScope)
+}
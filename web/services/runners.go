@@ -0,0 +1,289 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+const (
+	// RunnerHeartbeatInterval is how often the runners are expected to send a heartbeat
+	RunnerHeartbeatInterval = 30 * time.Second
+	// RunnerLeaseDuration is how long a lease lasts without being renewed, a runner missing
+	// some heartbeats in a row is considered dead and its clusters are leased to the other runners
+	RunnerLeaseDuration = 3 * RunnerHeartbeatInterval
+)
+
+var ErrInvalidRunner = errors.New("invalid runner")
+
+//go:generate mockery --name=RunnersService --inpackage --filename=runners_mock.go
+
+type RunnersService interface {
+	Heartbeat(runner *models.Runner) ([]*models.RunnerLease, error)
+	GetAll() ([]*models.Runner, error)
+	Release(runnerID string) error
+}
+
+type runnersService struct {
+	db *gorm.DB
+}
+
+func NewRunnersService(db *gorm.DB) *runnersService {
+	return &runnersService{db: db}
+}
+
+// Heartbeat registers the runner as alive and returns the leases it holds from now on.
+// The clusters are spread among the live runners they are eligible for: each runner renews the leases of the
+// clusters assigned to it, giving the others back, and takes over the free or expired leases of its clusters
+func (s *runnersService) Heartbeat(runner *models.Runner) ([]*models.RunnerLease, error) {
+	if runner.ID == "" {
+		return nil, fmt.Errorf("%w: the runner id is required", ErrInvalidRunner)
+	}
+
+	now := time.Now()
+
+	entity := &entities.Runner{
+		ID:          runner.ID,
+		Tags:        db.StringArray(runner.Tags),
+		ClusterIDs:  db.StringArray(runner.ClusterIDs),
+		HeartbeatAt: now,
+	}
+
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tags", "cluster_ids", "heartbeat_at"}),
+	}).Create(entity).Error
+	if err != nil {
+		return nil, err
+	}
+
+	clusterTags, err := getCheckTargetsTags(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var liveRunners []*entities.Runner
+	err = s.db.Where("heartbeat_at > ?", now.Add(-RunnerLeaseDuration)).Order("id").Find(&liveRunners).Error
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := assignedClusters(runner.ID, leaseAssignment(liveRunners, clusterTags))
+
+	var leases []*entities.RunnerLease
+	err = s.db.Where("runner_id = ?", runner.ID).Order("acquired_at").Find(&leases).Error
+	if err != nil {
+		return nil, err
+	}
+
+	kept := []*entities.RunnerLease{}
+	leased := make(map[string]bool)
+	for _, lease := range leases {
+		if !internal.Contains(assigned, lease.ClusterID) {
+			err := s.db.Where("cluster_id = ? AND runner_id = ?", lease.ClusterID, runner.ID).
+				Delete(&entities.RunnerLease{}).Error
+			if err != nil {
+				return nil, err
+			}
+			log.Infof("Runner %s released the lease of cluster %s", runner.ID, lease.ClusterID)
+			continue
+		}
+
+		kept = append(kept, lease)
+		leased[lease.ClusterID] = true
+	}
+
+	expiresAt := now.Add(RunnerLeaseDuration)
+	if len(kept) > 0 {
+		err := s.db.Model(&entities.RunnerLease{}).
+			Where("runner_id = ?", runner.ID).
+			Update("expires_at", expiresAt).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := []*models.RunnerLease{}
+	for _, lease := range kept {
+		lease.ExpiresAt = expiresAt
+		result = append(result, lease.ToModel())
+	}
+
+	for _, clusterID := range assigned {
+		if leased[clusterID] {
+			continue
+		}
+
+		// a cluster still leased to another runner is taken over once that runner gives it back
+		lease, err := s.acquire(runner.ID, clusterID, now)
+		if err != nil {
+			return nil, err
+		}
+		if lease != nil {
+			log.Infof("Runner %s acquired the lease of cluster %s", runner.ID, clusterID)
+			result = append(result, lease.ToModel())
+		}
+	}
+
+	return result, nil
+}
+
+// acquire takes the lease of a cluster when it is free or expired, nil is returned when another runner holds it.
+// The lease is inserted or swapped with conditional statements, so concurrent runners never share a cluster
+func (s *runnersService) acquire(runnerID string, clusterID string, now time.Time) (*entities.RunnerLease, error) {
+	lease := &entities.RunnerLease{
+		ClusterID:  clusterID,
+		RunnerID:   runnerID,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(RunnerLeaseDuration),
+	}
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(lease)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		return lease, nil
+	}
+
+	result = s.db.Model(&entities.RunnerLease{}).
+		Where("cluster_id = ? AND expires_at <= ?", clusterID, now).
+		Updates(map[string]interface{}{
+			"runner_id":   lease.RunnerID,
+			"acquired_at": lease.AcquiredAt,
+			"expires_at":  lease.ExpiresAt,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return lease, nil
+}
+
+// GetAll returns the registered runners with their leases, the live ones flagged as alive
+func (s *runnersService) GetAll() ([]*models.Runner, error) {
+	var runners []*entities.Runner
+	if err := s.db.Order("id").Find(&runners).Error; err != nil {
+		return nil, err
+	}
+
+	var leases []*entities.RunnerLease
+	if err := s.db.Order("cluster_id").Find(&leases).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := []*models.Runner{}
+	for _, runner := range runners {
+		model := runner.ToModel()
+		model.Alive = runner.HeartbeatAt.After(now.Add(-RunnerLeaseDuration))
+		model.Leases = []*models.RunnerLease{}
+
+		for _, lease := range leases {
+			if lease.RunnerID == runner.ID && lease.ExpiresAt.After(now) {
+				model.Leases = append(model.Leases, lease.ToModel())
+			}
+		}
+
+		result = append(result, model)
+	}
+
+	return result, nil
+}
+
+// Release gives back the leases of a runner that is stopping, so the other runners take over its clusters
+// right away instead of waiting for the leases to expire. The runner is forgotten until its next heartbeat
+func (s *runnersService) Release(runnerID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("runner_id = ?", runnerID).Delete(&entities.RunnerLease{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", runnerID).Delete(&entities.Runner{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+// leaseAssignment spreads the clusters among the live runners, returning the runner of each cluster.
+// The clusters with the fewest eligible runners are assigned first, each one to the eligible runner having the
+// fewest clusters so far. This way every cluster that a live runner can serve is assigned, and the assignment
+// only depends on the live runners and the clusters, so it is the same whichever runner computes it
+func leaseAssignment(liveRunners []*entities.Runner, clusterTags map[string][]string) map[string]string {
+	runners := []*models.Runner{}
+	for _, runner := range liveRunners {
+		runners = append(runners, runner.ToModel())
+	}
+
+	eligible := make(map[string][]*models.Runner)
+	clusterIDs := []string{}
+	for clusterID, tags := range clusterTags {
+		for _, runner := range runners {
+			if runner.CanServe(clusterID, tags) {
+				eligible[clusterID] = append(eligible[clusterID], runner)
+			}
+		}
+		if len(eligible[clusterID]) > 0 {
+			clusterIDs = append(clusterIDs, clusterID)
+		}
+	}
+
+	sort.Slice(clusterIDs, func(i, j int) bool {
+		a, b := clusterIDs[i], clusterIDs[j]
+		if len(eligible[a]) != len(eligible[b]) {
+			return len(eligible[a]) < len(eligible[b])
+		}
+		return a < b
+	})
+
+	assignment := make(map[string]string)
+	counts := make(map[string]int)
+	for _, clusterID := range clusterIDs {
+		var chosen *models.Runner
+		for _, runner := range eligible[clusterID] {
+			if chosen == nil || counts[runner.ID] < counts[chosen.ID] {
+				chosen = runner
+			}
+		}
+
+		assignment[clusterID] = chosen.ID
+		counts[chosen.ID]++
+	}
+
+	return assignment
+}
+
+// assignedClusters returns the ids of the clusters assigned to the runner, sorted
+func assignedClusters(runnerID string, assignment map[string]string) []string {
+	assigned := []string{}
+	for clusterID, assignedRunnerID := range assignment {
+		if assignedRunnerID == runnerID {
+			assigned = append(assigned, clusterID)
+		}
+	}
+	sort.Strings(assigned)
+
+	return assigned
+}
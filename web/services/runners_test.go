@@ -0,0 +1,221 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+type RunnersServiceTestSuite struct {
+	suite.Suite
+	db             *gorm.DB
+	tx             *gorm.DB
+	runnersService *runnersService
+}
+
+func TestRunnersServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RunnersServiceTestSuite))
+}
+
+func (suite *RunnersServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Cluster{}, entities.Host{}, entities.SAPSystemInstance{}, models.Tag{}, models.SelectedChecks{},
+		entities.HostTelemetry{}, entities.Runner{}, entities.RunnerLease{})
+}
+
+func (suite *RunnersServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Cluster{}, entities.Host{}, entities.SAPSystemInstance{}, models.Tag{}, models.SelectedChecks{},
+		entities.HostTelemetry{}, entities.Runner{}, entities.RunnerLease{})
+}
+
+func (suite *RunnersServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.runnersService = NewRunnersService(suite.tx)
+
+	for _, id := range []string{"cluster1", "cluster2", "cluster3", "cluster4"} {
+		suite.tx.Create(&entities.Cluster{ID: id})
+	}
+	suite.tx.Create(&models.Tag{Value: "dc1", ResourceID: "cluster1", ResourceType: models.TagClusterResourceType})
+	suite.tx.Create(&models.Tag{Value: "dc1", ResourceID: "cluster2", ResourceType: models.TagClusterResourceType})
+	suite.tx.Create(&models.Tag{Value: "dc1", ResourceID: "host1", ResourceType: models.TagHostResourceType})
+}
+
+func (suite *RunnersServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func leasedClusters(leases []*models.RunnerLease) []string {
+	clusterIDs := []string{}
+	for _, lease := range leases {
+		clusterIDs = append(clusterIDs, lease.ClusterID)
+	}
+
+	return clusterIDs
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_HeartbeatSingleRunner() {
+	leases, err := suite.runnersService.Heartbeat(&models.Runner{ID: "runner1"})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster1", "cluster2", "cluster3", "cluster4"}, leasedClusters(leases))
+	suite.Equal("runner1", leases[0].RunnerID)
+	suite.WithinDuration(time.Now().Add(RunnerLeaseDuration), leases[0].ExpiresAt, 5*time.Second)
+
+	// the leases are renewed, not acquired again
+	acquiredAt := leases[0].AcquiredAt
+	leases, err = suite.runnersService.Heartbeat(&models.Runner{ID: "runner1"})
+	suite.NoError(err)
+	suite.Len(leases, 4)
+	suite.WithinDuration(acquiredAt, leases[0].AcquiredAt, time.Second)
+
+	var count int64
+	suite.tx.Model(&entities.RunnerLease{}).Count(&count)
+	suite.EqualValues(4, count)
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_HeartbeatSharesTheClusters() {
+	leases1, _ := suite.runnersService.Heartbeat(&models.Runner{ID: "runner1"})
+	suite.Len(leases1, 4)
+
+	// the second runner only gets the clusters given back by the first one
+	leases2, err := suite.runnersService.Heartbeat(&models.Runner{ID: "runner2"})
+	suite.NoError(err)
+	suite.Empty(leases2)
+
+	leases1, err = suite.runnersService.Heartbeat(&models.Runner{ID: "runner1"})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster1", "cluster3"}, leasedClusters(leases1))
+
+	leases2, err = suite.runnersService.Heartbeat(&models.Runner{ID: "runner2"})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster2", "cluster4"}, leasedClusters(leases2))
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_HeartbeatAffinity() {
+	leases, err := suite.runnersService.Heartbeat(&models.Runner{ID: "dc1-runner", Tags: []string{"dc1"}})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster1", "cluster2"}, leasedClusters(leases))
+
+	leases, err = suite.runnersService.Heartbeat(&models.Runner{ID: "dc2-runner", ClusterIDs: []string{"cluster3", "unknown"}})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster3"}, leasedClusters(leases))
+
+	// the runners with affinity don't compete for the clusters they can't serve
+	leases, err = suite.runnersService.Heartbeat(&models.Runner{ID: "runner"})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster4"}, leasedClusters(leases))
+
+	// a changed affinity gives the clusters not matching anymore back
+	leases, err = suite.runnersService.Heartbeat(&models.Runner{ID: "dc1-runner", ClusterIDs: []string{"cluster2"}})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster2"}, leasedClusters(leases))
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_HeartbeatMixedAffinities() {
+	runners := []*models.Runner{
+		{ID: "runner"},
+		{ID: "dc1-runner", Tags: []string{"dc1"}},
+		{ID: "cluster4-runner", ClusterIDs: []string{"cluster4"}},
+	}
+
+	leases := make(map[string][]string)
+	for round := 0; round < 3; round++ {
+		for _, runner := range runners {
+			runnerLeases, err := suite.runnersService.Heartbeat(runner)
+			suite.NoError(err)
+			leases[runner.ID] = leasedClusters(runnerLeases)
+		}
+
+		// the leases move from a runner to another without leaving any cluster unleased
+		var count int64
+		suite.tx.Model(&entities.RunnerLease{}).Where("expires_at > ?", time.Now()).Count(&count)
+		suite.EqualValues(4, count)
+	}
+
+	// the cluster only the runner without affinity can serve doesn't count against it
+	suite.ElementsMatch([]string{"cluster3"}, leases["runner"])
+	suite.ElementsMatch([]string{"cluster1", "cluster2"}, leases["dc1-runner"])
+	suite.ElementsMatch([]string{"cluster4"}, leases["cluster4-runner"])
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_HeartbeatTakesOverExpiredLeases() {
+	expired := time.Now().Add(-time.Minute)
+	suite.tx.Create(&entities.Runner{ID: "crashed", HeartbeatAt: expired.Add(-RunnerLeaseDuration)})
+	suite.tx.Create(&entities.RunnerLease{ClusterID: "cluster2", RunnerID: "crashed", ExpiresAt: expired})
+	suite.tx.Create(&entities.Runner{ID: "busy", HeartbeatAt: time.Now()})
+	suite.tx.Create(&entities.RunnerLease{ClusterID: "cluster1", RunnerID: "busy", ExpiresAt: time.Now().Add(time.Minute)})
+
+	leases, err := suite.runnersService.Heartbeat(&models.Runner{ID: "runner1"})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster2", "cluster4"}, leasedClusters(leases))
+
+	var lease entities.RunnerLease
+	suite.tx.Where("cluster_id = ?", "cluster2").First(&lease)
+	suite.Equal("runner1", lease.RunnerID)
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_HeartbeatStandaloneTargets() {
+	suite.tx.Create(&entities.Host{AgentID: "host1", Name: "host1"})
+	suite.tx.Create(&entities.Host{AgentID: "host2", Name: "host2"})
+	suite.tx.Create(&entities.Host{AgentID: "node1", Name: "node1", ClusterID: "cluster1"})
+	suite.tx.Create(&entities.SAPSystemInstance{ID: "sapsystem1", AgentID: "host2", InstanceNumber: "00"})
+	suite.tx.Create(&models.Tag{Value: "dc1", ResourceID: "sapsystem1", ResourceType: models.TagSAPSystemResourceType})
+	suite.tx.Create(&models.SelectedChecks{ID: "host1", SelectedChecks: []string{"CAEFF1"}})
+	suite.tx.Create(&models.SelectedChecks{ID: "host2", SelectedChecks: []string{}})
+	suite.tx.Create(&models.SelectedChecks{ID: "node1", SelectedChecks: []string{"CAEFF1"}})
+	suite.tx.Create(&models.SelectedChecks{ID: "sapsystem1", SelectedChecks: []string{"CAEFF1"}})
+
+	// the standalone hosts and SAP systems without selected checks are not leased
+	leases, err := suite.runnersService.Heartbeat(&models.Runner{ID: "dc1-runner", Tags: []string{"dc1"}})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster1", "cluster2", "host1", "sapsystem1"}, leasedClusters(leases))
+
+	leases, err = suite.runnersService.Heartbeat(&models.Runner{ID: "runner"})
+	suite.NoError(err)
+	suite.ElementsMatch([]string{"cluster3", "cluster4"}, leasedClusters(leases))
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_HeartbeatInvalid() {
+	_, err := suite.runnersService.Heartbeat(&models.Runner{})
+	suite.ErrorIs(err, ErrInvalidRunner)
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_GetAll() {
+	suite.runnersService.Heartbeat(&models.Runner{ID: "runner1", Tags: []string{"dc1"}})
+	suite.tx.Create(&entities.Runner{ID: "crashed", HeartbeatAt: time.Now().Add(-time.Hour)})
+
+	runners, err := suite.runnersService.GetAll()
+	suite.NoError(err)
+	suite.Len(runners, 2)
+
+	suite.Equal("crashed", runners[0].ID)
+	suite.False(runners[0].Alive)
+	suite.Empty(runners[0].Leases)
+
+	suite.Equal("runner1", runners[1].ID)
+	suite.True(runners[1].Alive)
+	suite.Equal([]string{"dc1"}, runners[1].Tags)
+	suite.Empty(runners[1].ClusterIDs)
+	suite.ElementsMatch([]string{"cluster1", "cluster2"}, leasedClusters(runners[1].Leases))
+}
+
+func (suite *RunnersServiceTestSuite) TestRunnersService_Release() {
+	suite.runnersService.Heartbeat(&models.Runner{ID: "runner1"})
+	suite.runnersService.Heartbeat(&models.Runner{ID: "runner2"})
+
+	suite.NoError(suite.runnersService.Release("runner1"))
+
+	// the stopped runner clusters are taken over right away
+	leases, err := suite.runnersService.Heartbeat(&models.Runner{ID: "runner2"})
+	suite.NoError(err)
+	suite.Len(leases, 4)
+
+	err = suite.runnersService.Release("runner1")
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
@@ -4,7 +4,7 @@ import (
 	"errors"
 	"net"
 
-	"github.com/lib/pq"
+	trentoDB "github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/gorm"
@@ -101,7 +101,7 @@ func (s *sapSystemsService) GetDatabasesCount() (int, error) {
 }
 
 func (s *sapSystemsService) GetAllApplicationsSIDs() ([]string, error) {
-	var sids pq.StringArray
+	var sids []string
 
 	err := s.db.
 		Model(&entities.SAPSystemInstance{}).
@@ -114,11 +114,11 @@ func (s *sapSystemsService) GetAllApplicationsSIDs() ([]string, error) {
 		return nil, err
 	}
 
-	return []string(sids), nil
+	return sids, nil
 }
 
 func (s *sapSystemsService) GetAllDatabasesSIDs() ([]string, error) {
-	var sids pq.StringArray
+	var sids []string
 
 	err := s.db.
 		Model(&entities.SAPSystemInstance{}).
@@ -131,7 +131,7 @@ func (s *sapSystemsService) GetAllDatabasesSIDs() ([]string, error) {
 		return nil, err
 	}
 
-	return []string(sids), nil
+	return sids, nil
 }
 
 func (s *sapSystemsService) GetAllApplicationsTags() ([]string, error) {
@@ -222,10 +222,10 @@ func (s *sapSystemsService) getAttachedDatabase(dbName string, dbHost string) (*
 	if ip.To4() == nil {
 		db = db.Where("hosts.name = ?", dbHost)
 	} else {
-		db = db.Where("hosts.ip_addresses && ?", pq.Array([]string{dbHost}))
+		db = db.Where(trentoDB.ArrayOverlaps("hosts.ip_addresses", []string{dbHost}))
 	}
 
-	err := db.Where("tenants && ?", pq.Array([]string{dbName})).
+	err := db.Where(trentoDB.ArrayOverlaps("tenants", []string{dbName})).
 		Select("id").
 		First(&primaryInstance).
 		Error
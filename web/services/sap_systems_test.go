@@ -3,8 +3,8 @@ package services
 import (
 	"testing"
 
-	"github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
+	trentoDB "github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -43,7 +43,7 @@ func sapSystemsFixtures() entities.SAPSystemInstances {
 			Type:                    "database",
 			InstanceNumber:          "10",
 			Features:                "features",
-			Tenants:                 pq.StringArray{"tenant"},
+			Tenants:                 trentoDB.StringArray{"tenant"},
 			SystemReplication:       "Primary",
 			SystemReplicationStatus: "SOK",
 			Host: &entities.Host{
@@ -67,7 +67,7 @@ func sapSystemsFixtures() entities.SAPSystemInstances {
 			Type:                    "database",
 			InstanceNumber:          "11",
 			Features:                "features",
-			Tenants:                 pq.StringArray{"tenant"},
+			Tenants:                 trentoDB.StringArray{"tenant"},
 			SystemReplication:       "Secondary",
 			SystemReplicationStatus: "SOK",
 			Host: &entities.Host{
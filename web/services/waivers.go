@@ -0,0 +1,184 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var ErrInvalidWaiver = errors.New("invalid waiver")
+
+//go:generate mockery --name=WaiversService --inpackage --filename=waivers_mock.go
+
+type WaiversService interface {
+	GetAll(page *Page) ([]*models.Waiver, error)
+	GetByID(id int64) (*models.Waiver, error)
+	Create(waiver *models.Waiver) (*models.Waiver, error)
+	Delete(id int64, actor string) error
+	Expire() (int, error)
+}
+
+type waiversService struct {
+	db *gorm.DB
+}
+
+func NewWaiversService(db *gorm.DB) *waiversService {
+	return &waiversService{db: db}
+}
+
+// GetAll returns the waivers, including the expired ones, the most recent first
+func (s *waiversService) GetAll(page *Page) ([]*models.Waiver, error) {
+	var waivers []*entities.Waiver
+
+	err := s.db.Scopes(Paginate(page)).
+		Order("id desc").
+		Find(&waivers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*models.Waiver{}
+	for _, waiver := range waivers {
+		result = append(result, waiver.ToModel())
+	}
+
+	return result, nil
+}
+
+// GetByID returns the waiver, gorm.ErrRecordNotFound is returned when it does not exist
+func (s *waiversService) GetByID(id int64) (*models.Waiver, error) {
+	var waiver entities.Waiver
+	if err := s.db.Where("id = ?", id).First(&waiver).Error; err != nil {
+		return nil, err
+	}
+
+	return waiver.ToModel(), nil
+}
+
+func (s *waiversService) Create(waiver *models.Waiver) (*models.Waiver, error) {
+	if err := validateWaiver(waiver); err != nil {
+		return nil, err
+	}
+
+	entity := &entities.Waiver{
+		CheckID:       waiver.CheckID,
+		Scope:         waiver.Scope,
+		Target:        waiver.Target,
+		Justification: waiver.Justification,
+		Owner:         waiver.Owner,
+		ExpiresAt:     waiver.ExpiresAt,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entity).Error; err != nil {
+			return err
+		}
+
+		return recordAuditLogEntry(tx, models.AuditWaiverCreated, waiverResource(entity.ID), entity.Owner,
+			fmt.Sprintf("Check %s waived on %s %s until %s: %s",
+				entity.CheckID, entity.Scope, entity.Target, entity.ExpiresAt.Format(time.RFC3339), entity.Justification))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ToModel(), nil
+}
+
+// Delete removes the waiver, recording in the audit log who removed it
+func (s *waiversService) Delete(id int64, actor string) error {
+	if actor == "" {
+		return fmt.Errorf("%w: the actor is required", ErrInvalidWaiver)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var waiver entities.Waiver
+		if err := tx.Where("id = ?", id).First(&waiver).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&waiver).Error; err != nil {
+			return err
+		}
+
+		return recordAuditLogEntry(tx, models.AuditWaiverDeleted, waiverResource(waiver.ID), actor,
+			fmt.Sprintf("Waiver of check %s on %s %s deleted", waiver.CheckID, waiver.Scope, waiver.Target))
+	})
+}
+
+// Expire flags the waivers past their expiration date and records it in the audit log.
+// The results they covered are reported again as soon as they expire, regardless of this flag
+func (s *waiversService) Expire() (int, error) {
+	var expired []*entities.Waiver
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("expired = ? AND expires_at <= ?", false, time.Now()).
+			Order("id").
+			Find(&expired).Error
+		if err != nil {
+			return err
+		}
+
+		for _, waiver := range expired {
+			if err := tx.Model(waiver).Update("expired", true).Error; err != nil {
+				return err
+			}
+
+			err := recordAuditLogEntry(tx, models.AuditWaiverExpired, waiverResource(waiver.ID), "",
+				fmt.Sprintf("Waiver of check %s on %s %s expired, owned by %s",
+					waiver.CheckID, waiver.Scope, waiver.Target, waiver.Owner))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(expired) > 0 {
+		log.Infof("%d checks waivers expired", len(expired))
+	}
+
+	return len(expired), nil
+}
+
+func waiverResource(id int64) string {
+	return fmt.Sprintf("waiver/%d", id)
+}
+
+func validateWaiver(waiver *models.Waiver) error {
+	if waiver.CheckID == "" {
+		return fmt.Errorf("%w: the check id is required", ErrInvalidWaiver)
+	}
+
+	switch waiver.Scope {
+	case models.WaiverScopeCluster, models.WaiverScopeHost, models.WaiverScopeSID:
+	default:
+		return fmt.Errorf("%w: the scope must be one of %s, %s or %s", ErrInvalidWaiver,
+			models.WaiverScopeCluster, models.WaiverScopeHost, models.WaiverScopeSID)
+	}
+
+	if waiver.Target == "" {
+		return fmt.Errorf("%w: the target is required", ErrInvalidWaiver)
+	}
+	if waiver.Justification == "" {
+		return fmt.Errorf("%w: the justification is required", ErrInvalidWaiver)
+	}
+	if waiver.Owner == "" {
+		return fmt.Errorf("%w: the owner is required", ErrInvalidWaiver)
+	}
+	if !waiver.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("%w: the expiration date must be in the future", ErrInvalidWaiver)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+type WaiversServiceTestSuite struct {
+	suite.Suite
+	db              *gorm.DB
+	tx              *gorm.DB
+	waiversService  *waiversService
+	auditLogService *auditLogService
+}
+
+func TestWaiversServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WaiversServiceTestSuite))
+}
+
+func (suite *WaiversServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Waiver{}, entities.AuditLogEntry{})
+}
+
+func (suite *WaiversServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Waiver{}, entities.AuditLogEntry{})
+}
+
+func (suite *WaiversServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.waiversService = NewWaiversService(suite.tx)
+	suite.auditLogService = NewAuditLogService(suite.tx)
+}
+
+func (suite *WaiversServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func newWaiver(checkID string, expiresAt time.Time) *models.Waiver {
+	return &models.Waiver{
+		CheckID:       checkID,
+		Scope:         models.WaiverScopeCluster,
+		Target:        "cluster1",
+		Justification: "the storage vendor requires it",
+		Owner:         "admin",
+		ExpiresAt:     expiresAt,
+	}
+}
+
+func (suite *WaiversServiceTestSuite) TestWaiversService_Create() {
+	created, err := suite.waiversService.Create(newWaiver("156F64", time.Now().Add(time.Hour)))
+	suite.NoError(err)
+	suite.NotZero(created.ID)
+	suite.False(created.Expired)
+
+	stored, err := suite.waiversService.GetByID(created.ID)
+	suite.NoError(err)
+	suite.Equal("156F64", stored.CheckID)
+	suite.Equal("the storage vendor requires it", stored.Justification)
+
+	entries, err := suite.auditLogService.GetAll(nil)
+	suite.NoError(err)
+	suite.Len(entries, 1)
+	suite.Equal(models.AuditWaiverCreated, entries[0].Action)
+	suite.Equal(waiverResource(created.ID), entries[0].Resource)
+	suite.Equal("admin", entries[0].Actor)
+}
+
+func (suite *WaiversServiceTestSuite) TestWaiversService_CreateInvalid() {
+	invalidWaivers := []*models.Waiver{
+		newWaiver("", time.Now().Add(time.Hour)),
+		newWaiver("156F64", time.Now().Add(-time.Hour)),
+		{CheckID: "156F64", Scope: "datacenter", Target: "dc1", Justification: "j", Owner: "o", ExpiresAt: time.Now().Add(time.Hour)},
+		{CheckID: "156F64", Scope: models.WaiverScopeHost, Justification: "j", Owner: "o", ExpiresAt: time.Now().Add(time.Hour)},
+		{CheckID: "156F64", Scope: models.WaiverScopeHost, Target: "host1", Owner: "o", ExpiresAt: time.Now().Add(time.Hour)},
+		{CheckID: "156F64", Scope: models.WaiverScopeHost, Target: "host1", Justification: "j", ExpiresAt: time.Now().Add(time.Hour)},
+	}
+
+	for _, waiver := range invalidWaivers {
+		_, err := suite.waiversService.Create(waiver)
+		suite.True(errors.Is(err, ErrInvalidWaiver), err)
+	}
+
+	waivers, err := suite.waiversService.GetAll(nil)
+	suite.NoError(err)
+	suite.Empty(waivers)
+}
+
+func (suite *WaiversServiceTestSuite) TestWaiversService_Delete() {
+	created, err := suite.waiversService.Create(newWaiver("156F64", time.Now().Add(time.Hour)))
+	suite.NoError(err)
+
+	err = suite.waiversService.Delete(created.ID, "")
+	suite.ErrorIs(err, ErrInvalidWaiver)
+
+	err = suite.waiversService.Delete(created.ID, "jdoe")
+	suite.NoError(err)
+
+	_, err = suite.waiversService.GetByID(created.ID)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	err = suite.waiversService.Delete(created.ID, "jdoe")
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	entries, err := suite.auditLogService.GetAll(nil)
+	suite.NoError(err)
+	suite.Len(entries, 2)
+	suite.Equal(models.AuditWaiverDeleted, entries[0].Action)
+	suite.Equal("jdoe", entries[0].Actor)
+}
+
+func (suite *WaiversServiceTestSuite) TestWaiversService_Expire() {
+	active, err := suite.waiversService.Create(newWaiver("156F64", time.Now().Add(time.Hour)))
+	suite.NoError(err)
+
+	suite.tx.Create(&entities.Waiver{
+		CheckID: "A1244C", Scope: models.WaiverScopeHost, Target: "host1",
+		Owner: "admin", Justification: "temporary", ExpiresAt: time.Now().Add(-time.Minute),
+	})
+
+	expired, err := suite.waiversService.Expire()
+	suite.NoError(err)
+	suite.Equal(1, expired)
+
+	expired, err = suite.waiversService.Expire()
+	suite.NoError(err)
+	suite.Equal(0, expired)
+
+	waivers, err := suite.waiversService.GetAll(nil)
+	suite.NoError(err)
+	suite.Len(waivers, 2)
+	suite.True(waivers[0].Expired)
+	suite.Equal(active.ID, waivers[1].ID)
+	suite.False(waivers[1].Expired)
+
+	entries, err := suite.auditLogService.GetAll(&Page{Number: 1, Size: 1})
+	suite.NoError(err)
+	suite.Len(entries, 1)
+	suite.Equal(models.AuditWaiverExpired, entries[0].Action)
+	suite.Equal(waiverResource(waivers[0].ID), entries[0].Resource)
+}
@@ -0,0 +1,186 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONWaiver struct {
+	CheckID       string    `json:"check_id"`
+	Scope         string    `json:"scope"`
+	Target        string    `json:"target"`
+	Justification string    `json:"justification"`
+	Owner         string    `json:"owner"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+func (j *JSONWaiver) toModel() *models.Waiver {
+	return &models.Waiver{
+		CheckID:       j.CheckID,
+		Scope:         j.Scope,
+		Target:        j.Target,
+		Justification: j.Justification,
+		Owner:         j.Owner,
+		ExpiresAt:     j.ExpiresAt,
+	}
+}
+
+// ApiListWaiversHandler godoc
+// @Summary Retrieve the checks waivers, including the expired ones
+// @Produce json
+// @Param page query int false "Page number"
+// @Param per_page query int false "Waivers per page"
+// @Success 200 {array} models.Waiver
+// @Failure 500 {object} map[string]string
+// @Router /checks/waivers [get]
+func ApiListWaiversHandler(s services.WaiversService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		waivers, err := s.GetAll(queryPage(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, waivers)
+	}
+}
+
+// ApiGetWaiverHandler godoc
+// @Summary Retrieve a checks waiver
+// @Produce json
+// @Param id path int true "Waiver Id"
+// @Success 200 {object} models.Waiver
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /checks/waivers/{id} [get]
+func ApiGetWaiverHandler(s services.WaiversService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid waiver id"))
+			return
+		}
+
+		waiver, err := s.GetByID(id)
+		if err != nil {
+			_ = c.Error(waiverError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, waiver)
+	}
+}
+
+// ApiCreateWaiverHandler godoc
+// @Summary Waive the failing results of a check on a cluster, a host or a SID until the expiration date
+// @Accept json
+// @Produce json
+// @Param Body body JSONWaiver true "Waiver"
+// @Success 201 {object} models.Waiver
+// @Failure 400 {object} map[string]string
+// @Router /checks/waivers [post]
+func ApiCreateWaiverHandler(s services.WaiversService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONWaiver
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		waiver, err := s.Create(r.toModel())
+		if err != nil {
+			_ = c.Error(waiverError(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, waiver)
+	}
+}
+
+// ApiDeleteWaiverHandler godoc
+// @Summary Delete a checks waiver, the results it covered are reported again
+// @Param id path int true "Waiver Id"
+// @Param actor query string true "Who deletes the waiver, recorded in the audit log"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /checks/waivers/{id} [delete]
+func ApiDeleteWaiverHandler(s services.WaiversService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid waiver id"))
+			return
+		}
+
+		if err := s.Delete(id, c.Query("actor")); err != nil {
+			_ = c.Error(waiverError(err))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ApiAuditLogHandler godoc
+// @Summary Retrieve the audit log, the most recent entries first
+// @Produce json
+// @Param page query int false "Page number"
+// @Param per_page query int false "Entries per page"
+// @Success 200 {array} models.AuditLogEntry
+// @Failure 500 {object} map[string]string
+// @Router /audit [get]
+func ApiAuditLogHandler(s services.AuditLogService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entries, err := s.GetAll(queryPage(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, entries)
+	}
+}
+
+// queryPage reads the page and per_page query parameters, defaulting to the first 50 items
+func queryPage(c *gin.Context) *services.Page {
+	pageNumber, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		pageNumber = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("per_page", "50"))
+	if err != nil {
+		pageSize = 50
+	}
+
+	return &services.Page{Number: pageNumber, Size: pageSize}
+}
+
+// queryOptionalPage returns the requested page, or nil to get all the items when no page is given
+func queryOptionalPage(c *gin.Context) *services.Page {
+	if c.Query("page") == "" && c.Query("per_page") == "" {
+		return nil
+	}
+
+	return queryPage(c)
+}
+
+func waiverError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFoundError("could not find the waiver")
+	case errors.Is(err, services.ErrInvalidWaiver):
+		return BadRequestError(err.Error())
+	default:
+		return err
+	}
+}
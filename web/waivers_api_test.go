@@ -0,0 +1,168 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiWaiversHandlers(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	waiver := &models.Waiver{
+		ID:            1,
+		CheckID:       "156F64",
+		Scope:         models.WaiverScopeCluster,
+		Target:        "cluster1",
+		Justification: "accepted by the storage vendor",
+		Owner:         "admin",
+		ExpiresAt:     expiresAt,
+	}
+
+	mockWaiversService := new(services.MockWaiversService)
+	mockWaiversService.On("GetAll", &services.Page{Number: 1, Size: 50}).Return([]*models.Waiver{waiver}, nil)
+	mockWaiversService.On("GetByID", int64(1)).Return(waiver, nil)
+	mockWaiversService.On("GetByID", int64(2)).Return(nil, gorm.ErrRecordNotFound)
+	mockWaiversService.On("Create", &models.Waiver{
+		CheckID:       "156F64",
+		Scope:         models.WaiverScopeCluster,
+		Target:        "cluster1",
+		Justification: "accepted by the storage vendor",
+		Owner:         "admin",
+		ExpiresAt:     expiresAt,
+	}).Return(waiver, nil)
+	mockWaiversService.On("Create", &models.Waiver{CheckID: "156F64"}).Return(
+		nil, fmt.Errorf("%w: the scope is not valid", services.ErrInvalidWaiver))
+	mockWaiversService.On("Delete", int64(1), "jdoe").Return(nil)
+	mockWaiversService.On("Delete", int64(2), "jdoe").Return(gorm.ErrRecordNotFound)
+	mockWaiversService.On("Delete", int64(1), "").Return(
+		fmt.Errorf("%w: the actor is required", services.ErrInvalidWaiver))
+
+	deps := setupTestDependencies()
+	deps.waiversService = mockWaiversService
+
+	app, err := NewAppWithDeps(setupAdminTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedWaiver, _ := json.Marshal(waiver)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/waivers", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, "["+string(expectedWaiver)+"]", resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/checks/waivers/1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedWaiver), resp.Body.String())
+
+	body := `{"check_id":"156F64","scope":"cluster","target":"cluster1",
+		"justification":"accepted by the storage vendor","owner":"admin","expires_at":"2030-01-01T00:00:00Z"}`
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/checks/waivers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.JSONEq(t, string(expectedWaiver), resp.Body.String())
+
+	for _, r := range []struct {
+		method string
+		url    string
+		body   string
+		code   int
+	}{
+		{"POST", "/api/checks/waivers", `{"check_id":"156F64"}`, http.StatusBadRequest},
+		{"POST", "/api/checks/waivers", `not json`, http.StatusBadRequest},
+		{"GET", "/api/checks/waivers/2", "", http.StatusNotFound},
+		{"GET", "/api/checks/waivers/foo", "", http.StatusBadRequest},
+		{"DELETE", "/api/checks/waivers/1?actor=jdoe", "", http.StatusNoContent},
+		{"DELETE", "/api/checks/waivers/2?actor=jdoe", "", http.StatusNotFound},
+		{"DELETE", "/api/checks/waivers/1", "", http.StatusBadRequest},
+	} {
+		resp = httptest.NewRecorder()
+		req = httptest.NewRequest(r.method, r.url, bytes.NewBufferString(r.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, r.code, resp.Code, r.method+" "+r.url)
+	}
+
+	mockWaiversService.AssertExpectations(t)
+}
+
+func TestApiWaiversHandlersUnauthorized(t *testing.T) {
+	mockWaiversService := new(services.MockWaiversService)
+
+	deps := setupTestDependencies()
+	deps.waiversService = mockWaiversService
+
+	app, err := NewAppWithDeps(setupAdminTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range []struct {
+		method string
+		url    string
+		body   string
+	}{
+		{"POST", "/api/checks/waivers", `{"check_id":"156F64","owner":"admin"}`},
+		{"DELETE", "/api/checks/waivers/1?actor=admin", ""},
+	} {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(r.method, r.url, bytes.NewBufferString(r.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		app.webEngine.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code, r.method+" "+r.url)
+	}
+
+	mockWaiversService.AssertNotCalled(t, "Create")
+	mockWaiversService.AssertNotCalled(t, "Delete")
+}
+
+func TestApiAuditLogHandler(t *testing.T) {
+	entries := []*models.AuditLogEntry{
+		{ID: 2, Action: models.AuditWaiverExpired, Resource: "waiver/1", Message: "expired"},
+		{ID: 1, Action: models.AuditWaiverCreated, Resource: "waiver/1", Actor: "admin", Message: "created"},
+	}
+
+	mockAuditLogService := new(services.MockAuditLogService)
+	mockAuditLogService.On("GetAll", &services.Page{Number: 2, Size: 10}).Return(entries, nil)
+
+	deps := setupTestDependencies()
+	deps.auditLogService = mockAuditLogService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/audit?page=2&per_page=10", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(entries)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, string(expectedBody), resp.Body.String())
+}
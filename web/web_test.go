@@ -4,6 +4,8 @@ import (
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/trento-project/trento/web/models"
 	"github.com/trento-project/trento/web/services"
 )
 
@@ -15,6 +17,7 @@ func setupTestDependencies() Dependencies {
 		settingsService:         newMockedSettingsService(),
 		subscriptionsService:    newMockedSubscriptionsService(),
 		premiumDetectionService: newMockedPremiumDetectionService(),
+		checkTargetsService:     newMockedCheckTargetsService(),
 	}
 }
 
@@ -48,3 +51,13 @@ func newMockedPremiumDetectionService() services.PremiumDetectionService {
 
 	return premiumDetection
 }
+
+func newMockedCheckTargetsService() services.CheckTargetsService {
+	checkTargetsService := new(services.MockCheckTargetsService)
+	checkTargetsService.On("GetTargetType", mock.Anything).Return(models.CheckTargetCluster, nil)
+	checkTargetsService.On("ValidateSelectedChecks", models.CheckTargetCluster, mock.Anything).Return(nil)
+	checkTargetsService.On("GetNotApplicableChecks", mock.Anything).Return([]string{}, nil)
+	checkTargetsService.On("GetSettingsByID", mock.Anything).Return(nil, nil)
+
+	return checkTargetsService
+}